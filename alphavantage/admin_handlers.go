@@ -0,0 +1,62 @@
+package alphavantage
+
+import (
+	"time"
+
+	"stock/common"
+	"stock/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QuotaResponse defines the response format for the upstream quota
+// @Description Upstream quota response data structure
+type QuotaResponse struct {
+	Version   string       `json:"version"`
+	Timestamp string       `json:"timestamp"`
+	Data      common.Quota `json:"data"`
+}
+
+// GetQuota handles requests for the remaining upstream call budget
+// @Summary Get the remaining Alpha Vantage call budget
+// @Description Returns the configured per-minute and per-day budgets and the calls still available
+// @Tags admin
+// @Produce json,text/csv,application/x-ndjson
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} QuotaResponse "Successful operation"
+// @Router /v1/admin/quota [get]
+func (h *Handler) GetQuota(c *gin.Context) {
+	response := QuotaResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Data:      h.client.Quota(),
+	}
+
+	respond(c, response, []interface{}{response.Data})
+}
+
+// MetricsResponse defines the response format for upstream request metrics
+// @Description Upstream metrics response data structure
+type MetricsResponse struct {
+	Version   string       `json:"version"`
+	Timestamp string       `json:"timestamp"`
+	Data      common.Stats `json:"data"`
+}
+
+// GetMetrics handles requests for upstream request metrics
+// @Summary Get upstream request metrics
+// @Description Returns cache hit/miss counts, upstream fetches and the ratio of requests deduplicated onto an in-flight fetch
+// @Tags admin
+// @Produce json,text/csv,application/x-ndjson
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} MetricsResponse "Successful operation"
+// @Router /v1/admin/metrics [get]
+func (h *Handler) GetMetrics(c *gin.Context) {
+	response := MetricsResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Data:      h.client.Stats(),
+	}
+
+	respond(c, response, []interface{}{response.Data})
+}
@@ -0,0 +1,268 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"stock/common"
+)
+
+// Calculations, named like Alpha Vantage's analytics functions
+const (
+	CalcMin              = "MIN"
+	CalcMax              = "MAX"
+	CalcMean             = "MEAN"
+	CalcMedian           = "MEDIAN"
+	CalcCumulativeReturn = "CUMULATIVE_RETURN"
+	CalcVariance         = "VARIANCE"
+	CalcStdDev           = "STDDEV"
+	CalcMaxDrawdown      = "MAX_DRAWDOWN"
+	CalcHistogram        = "HISTOGRAM"
+	CalcAutocorrelation  = "AUTOCORRELATION"
+	CalcCovariance       = "COVARIANCE"
+	CalcCorrelation      = "CORRELATION"
+)
+
+// Correlation methods
+const (
+	MethodPearson  = "PEARSON"
+	MethodSpearman = "SPEARMAN"
+	MethodKendall  = "KENDALL"
+)
+
+// tradingDaysPerYear annualizes daily variances and covariances
+const tradingDaysPerYear = 252
+
+// Calculation defaults and limits
+const (
+	defaultBins = 10
+	defaultLag  = 1
+	maxBins     = 1000
+
+	// MaxWindowSize bounds the returns of one sliding window, a year of trading days
+	MaxWindowSize = tradingDaysPerYear
+	// maxKendallComparisons bounds the return pairs compared by the sliding
+	// Kendall correlations of one request, Kendall being quadratic in the window size
+	maxKendallComparisons = 1_000_000_000
+)
+
+// fixedCalculations are the calculations available over a fixed window
+var fixedCalculations = map[string]bool{
+	CalcMin: true, CalcMax: true, CalcMean: true, CalcMedian: true, CalcCumulativeReturn: true,
+	CalcVariance: true, CalcStdDev: true, CalcMaxDrawdown: true, CalcHistogram: true,
+	CalcAutocorrelation: true, CalcCovariance: true, CalcCorrelation: true,
+}
+
+// slidingCalculations are the calculations available over sliding windows
+var slidingCalculations = map[string]bool{
+	CalcMean: true, CalcMedian: true, CalcCumulativeReturn: true, CalcVariance: true,
+	CalcStdDev: true, CalcCovariance: true, CalcCorrelation: true,
+}
+
+// Calculation is a requested statistic with its options
+type Calculation struct {
+	Name       string
+	Annualized bool   // VARIANCE, STDDEV and COVARIANCE
+	Bins       int    // HISTOGRAM
+	Lag        int    // AUTOCORRELATION
+	Method     string // CORRELATION
+}
+
+// String returns the calculation in the syntax it is parsed from, listing
+// the options that differ from their defaults
+func (c Calculation) String() string {
+	var options []string
+	if c.Annualized {
+		options = append(options, "annualized=true")
+	}
+	if c.Bins != 0 && c.Bins != defaultBins {
+		options = append(options, "bins="+strconv.Itoa(c.Bins))
+	}
+	if c.Lag != 0 && c.Lag != defaultLag {
+		options = append(options, "lag="+strconv.Itoa(c.Lag))
+	}
+	if c.Method != "" && c.Method != MethodPearson {
+		options = append(options, "method="+strings.ToLower(c.Method))
+	}
+	if len(options) == 0 {
+		return c.Name
+	}
+	return c.Name + "(" + strings.Join(options, ",") + ")"
+}
+
+// pairwise reports whether the calculation compares symbols with each other
+func (c Calculation) pairwise() bool {
+	return c.Name == CalcCovariance || c.Name == CalcCorrelation
+}
+
+// ParseCalculations parses a comma-separated calculation list such as
+// "MEAN,STDDEV(annualized=true),CORRELATION(method=kendall)"
+func ParseCalculations(value string, sliding bool) ([]Calculation, error) {
+	available := fixedCalculations
+	if sliding {
+		available = slidingCalculations
+	}
+
+	var calcs []Calculation
+	seen := make(map[string]bool)
+	for _, item := range splitTopLevel(value) {
+		calc, err := parseCalculation(item)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", common.ErrInvalidParameter, err)
+		}
+		if !available[calc.Name] {
+			return nil, fmt.Errorf("%w: %s is not available over this window", common.ErrInvalidParameter, calc.Name)
+		}
+		if key := calc.String(); !seen[key] {
+			seen[key] = true
+			calcs = append(calcs, calc)
+		}
+	}
+	if len(calcs) == 0 {
+		return nil, fmt.Errorf("%w: at least one calculation is required", common.ErrInvalidParameter)
+	}
+	return calcs, nil
+}
+
+// splitTopLevel splits on commas outside parentheses
+func splitTopLevel(value string) []string {
+	var items []string
+	depth, start := 0, 0
+	for i, r := range value {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				items = append(items, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+	items = append(items, value[start:])
+
+	trimmed := items[:0]
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			trimmed = append(trimmed, item)
+		}
+	}
+	return trimmed
+}
+
+// parseCalculation parses one calculation with its optional options in parentheses
+func parseCalculation(item string) (Calculation, error) {
+	name, options, hasOptions := strings.Cut(item, "(")
+	calc := Calculation{Name: strings.ToUpper(strings.TrimSpace(name))}
+	if !fixedCalculations[calc.Name] {
+		return calc, fmt.Errorf("unknown calculation %q", name)
+	}
+	switch calc.Name {
+	case CalcHistogram:
+		calc.Bins = defaultBins
+	case CalcAutocorrelation:
+		calc.Lag = defaultLag
+	case CalcCorrelation:
+		calc.Method = MethodPearson
+	}
+	if !hasOptions {
+		return calc, nil
+	}
+	if !strings.HasSuffix(options, ")") {
+		return calc, fmt.Errorf("%s: missing closing parenthesis", calc.Name)
+	}
+
+	for _, option := range strings.Split(strings.TrimSuffix(options, ")"), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+		if key == "" {
+			continue
+		}
+
+		var err error
+		switch {
+		case key == "annualized" && (calc.Name == CalcVariance || calc.Name == CalcStdDev || calc.Name == CalcCovariance):
+			calc.Annualized, err = strconv.ParseBool(value)
+		case key == "bins" && calc.Name == CalcHistogram:
+			calc.Bins, err = strconv.Atoi(value)
+			if err == nil && (calc.Bins < 1 || calc.Bins > maxBins) {
+				err = fmt.Errorf("must be from 1 to %d", maxBins)
+			}
+		case key == "lag" && calc.Name == CalcAutocorrelation:
+			calc.Lag, err = strconv.Atoi(value)
+			if err == nil && calc.Lag < 1 {
+				err = fmt.Errorf("must be positive")
+			}
+		case key == "method" && calc.Name == CalcCorrelation:
+			calc.Method = strings.ToUpper(value)
+			if calc.Method != MethodPearson && calc.Method != MethodSpearman && calc.Method != MethodKendall {
+				err = fmt.Errorf("must be pearson, spearman or kendall")
+			}
+		default:
+			return calc, fmt.Errorf("%s has no option %q", calc.Name, key)
+		}
+		if err != nil {
+			return calc, fmt.Errorf("%s option %s=%q: %v", calc.Name, key, value, err)
+		}
+	}
+	return calc, nil
+}
+
+// scalar computes a single-symbol statistic of returns
+func (c Calculation) scalar(returns []float64) float64 {
+	switch c.Name {
+	case CalcMin:
+		lo, _ := minMax(returns)
+		return lo
+	case CalcMax:
+		_, hi := minMax(returns)
+		return hi
+	case CalcMean:
+		return mean(returns)
+	case CalcMedian:
+		return median(returns)
+	case CalcCumulativeReturn:
+		return cumulativeReturn(returns)
+	case CalcVariance:
+		return c.annualize(variance(returns))
+	case CalcStdDev:
+		return math.Sqrt(c.annualize(variance(returns)))
+	case CalcAutocorrelation:
+		return autocorrelation(returns, c.Lag)
+	}
+	return math.NaN()
+}
+
+// pair computes a statistic comparing the returns of two symbols
+func (c Calculation) pair(xs, ys []float64) float64 {
+	if c.Name == CalcCovariance {
+		return c.annualize(covariance(xs, ys))
+	}
+	switch c.Method {
+	case MethodSpearman:
+		return spearman(xs, ys)
+	case MethodKendall:
+		return kendall(xs, ys)
+	}
+	return pearson(xs, ys)
+}
+
+// annualize scales a daily variance or covariance to a yearly one when requested
+func (c Calculation) annualize(v float64) float64 {
+	if c.Annualized {
+		return v * tradingDaysPerYear
+	}
+	return v
+}
+
+// number returns v, or nil when it is not a finite number
+func number(v float64) *float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,301 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"stock/alphavantage/timeseries"
+	"stock/common"
+)
+
+// fetchConcurrency bounds the symbols fetched at once, the rate limiter
+// queues the rest of the calls anyway
+const fetchConcurrency = 4
+
+// compactDays is how far back a range may start to be served by the compact
+// output size, which holds the latest 100 sessions
+const compactDays = 140
+
+// Price types selecting the price returns are computed from
+const (
+	PriceOpen  = "open"
+	PriceHigh  = "high"
+	PriceLow   = "low"
+	PriceClose = "close"
+)
+
+// Range selects the dates of a dataset, either between two dates or a
+// period back from the latest bar. The zero Range covers the full history.
+type Range struct {
+	From, To time.Time // Inclusive bounds, zero when open
+
+	years, months, days int // Relative period back from the latest bar
+}
+
+// relativeRange matches relative ranges such as 5day, 2week, 6month or 1year
+var relativeRange = regexp.MustCompile(`^(\d+)(day|week|month|year)s?$`)
+
+// ParseRange parses the range query values: "full", a relative period such
+// as 5day, 3month or 2year, or a start and end date (YYYY-MM-DD or YYYY-MM)
+func ParseRange(values []string) (Range, error) {
+	switch len(values) {
+	case 1:
+		value := strings.ToLower(strings.TrimSpace(values[0]))
+		if value == "full" {
+			return Range{}, nil
+		}
+		m := relativeRange.FindStringSubmatch(value)
+		if m == nil {
+			return Range{}, fmt.Errorf("range %q must be full, a period such as 1month or two dates", values[0])
+		}
+		n, err := strconv.Atoi(m[1])
+		if err != nil || n < 1 {
+			return Range{}, fmt.Errorf("range %q must be a positive period", values[0])
+		}
+		switch m[2] {
+		case "day":
+			return Range{days: n}, nil
+		case "week":
+			return Range{days: 7 * n}, nil
+		case "month":
+			return Range{months: n}, nil
+		default:
+			return Range{years: n}, nil
+		}
+	case 2:
+		from, err := parseRangeDate(values[0], false)
+		if err != nil {
+			return Range{}, err
+		}
+		to, err := parseRangeDate(values[1], true)
+		if err != nil {
+			return Range{}, err
+		}
+		if to.Before(from) {
+			return Range{}, fmt.Errorf("range end %s is before its start %s", values[1], values[0])
+		}
+		return Range{From: from, To: to}, nil
+	}
+	return Range{}, fmt.Errorf("range takes one value or a start and end date")
+}
+
+// parseRangeDate parses a YYYY-MM-DD or YYYY-MM date, months ending on their last day when end is set
+func parseRangeDate(value string, end bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(common.DateLayout, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid range date %q", value)
+	}
+	if end {
+		t = t.AddDate(0, 1, -1)
+	}
+	return t, nil
+}
+
+// IsFull reports whether the range covers the whole history
+func (r Range) IsFull() bool {
+	return r == Range{}
+}
+
+// start returns the first date of the range given the latest bar date
+func (r Range) start(latest time.Time) time.Time {
+	if !r.From.IsZero() || r.years+r.months+r.days == 0 {
+		return r.From
+	}
+	return latest.AddDate(-r.years, -r.months, -r.days)
+}
+
+// OutputSize returns the time series output size needed to cover the range as of now
+func (r Range) OutputSize(now time.Time) string {
+	if r.IsFull() {
+		return "full"
+	}
+	if start := r.start(now); now.Sub(start) > compactDays*24*time.Hour {
+		return "full"
+	}
+	return "compact"
+}
+
+// DatasetParams holds parameters for building a dataset
+type DatasetParams struct {
+	Symbols []string
+	Range   Range
+	Price   string // open, high, low or close, close when empty
+}
+
+// MissingSymbol is a symbol whose daily series could not be fetched
+type MissingSymbol struct {
+	Symbol string `json:"symbol"`
+	Error  string `json:"error"`
+}
+
+// Dataset holds the daily prices of several symbols on the dates all of them traded
+type Dataset struct {
+	Symbols []string
+	Dates   []time.Time
+	Prices  [][]float64 // One slice per symbol, aligned with Dates
+	Missing []MissingSymbol
+}
+
+// GetDataset fetches the daily bars of every symbol and aligns their prices
+// on common dates. The series come from the time series cache, so repeated
+// analytics over the same symbols do not spend upstream calls. Symbols that
+// fail are left out and reported in Missing, unless every symbol fails. Once
+// a call is rate limited the symbols still waiting are not fetched.
+func GetDataset(ctx context.Context, client *common.Client, params DatasetParams) (*Dataset, error) {
+	if len(params.Symbols) == 0 {
+		return nil, fmt.Errorf("%w: at least one symbol is required", common.ErrInvalidParameter)
+	}
+	if params.Price == "" {
+		params.Price = PriceClose
+	}
+	switch params.Price {
+	case PriceOpen, PriceHigh, PriceLow, PriceClose:
+	default:
+		return nil, fmt.Errorf("%w: ohlc must be %s, %s, %s or %s", common.ErrInvalidParameter, PriceOpen, PriceHigh, PriceLow, PriceClose)
+	}
+
+	type result struct {
+		bars []timeseries.Bar
+		err  error
+	}
+	results := make([]result, len(params.Symbols))
+	outputSize := params.Range.OutputSize(time.Now())
+
+	var mu sync.Mutex
+	var limited error
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, fetchConcurrency)
+	for i, symbol := range params.Symbols {
+		wg.Add(1)
+		go func(i int, symbol string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			mu.Lock()
+			skip := limited
+			mu.Unlock()
+			if skip != nil {
+				results[i].err = fmt.Errorf("not fetched: %w", skip)
+				return
+			}
+
+			results[i].bars, results[i].err = timeseries.GetTimeSeries(ctx, client, timeseries.TimeSeriesParams{
+				Function:   "TIME_SERIES_DAILY",
+				Symbol:     symbol,
+				OutputSize: outputSize,
+			})
+			if errors.Is(results[i].err, common.ErrRateLimited) {
+				mu.Lock()
+				if limited == nil {
+					limited = results[i].err
+				}
+				mu.Unlock()
+			}
+		}(i, symbol)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var symbols []string
+	var bars [][]timeseries.Bar
+	var missing []MissingSymbol
+	var errs []error
+	for i, res := range results {
+		if res.err != nil {
+			errs = append(errs, res.err)
+			missing = append(missing, MissingSymbol{Symbol: params.Symbols[i], Error: res.err.Error()})
+			continue
+		}
+		symbols = append(symbols, params.Symbols[i])
+		bars = append(bars, res.bars)
+	}
+	if len(errs) == len(results) {
+		if len(errs) == 1 {
+			return nil, fmt.Errorf("%s: %w", params.Symbols[0], errs[0])
+		}
+		return nil, fmt.Errorf("no symbol could be fetched: %w", errs[0])
+	}
+
+	dataset := align(symbols, bars, params.Range, params.Price)
+	dataset.Missing = missing
+	return dataset, nil
+}
+
+// align keeps the dates within the range on which every symbol has a bar
+func align(symbols []string, bars [][]timeseries.Bar, r Range, price string) *Dataset {
+	counts := make(map[string]int)
+	var latest time.Time
+	for _, series := range bars {
+		for _, bar := range series {
+			counts[bar.Time.Format(common.DateLayout)]++
+		}
+	}
+	for key, count := range counts {
+		if t, _ := time.Parse(common.DateLayout, key); count == len(bars) && t.After(latest) {
+			latest = t
+		}
+	}
+
+	from, to := r.start(latest), r.To
+	inRange := func(key string) bool {
+		t, _ := time.Parse(common.DateLayout, key)
+		return counts[key] == len(bars) && !t.Before(from) && (to.IsZero() || !t.After(to))
+	}
+
+	dataset := &Dataset{Symbols: symbols, Prices: make([][]float64, len(symbols))}
+	for i, series := range bars {
+		for _, bar := range series {
+			key := bar.Time.Format(common.DateLayout)
+			if !inRange(key) {
+				continue
+			}
+			if i == 0 {
+				t, _ := time.Parse(common.DateLayout, key)
+				dataset.Dates = append(dataset.Dates, t)
+			}
+			dataset.Prices[i] = append(dataset.Prices[i], barPrice(bar, price))
+		}
+	}
+	return dataset
+}
+
+// barPrice returns the price of a bar selected by the price type
+func barPrice(bar timeseries.Bar, price string) float64 {
+	switch price {
+	case PriceOpen:
+		return bar.Open
+	case PriceHigh:
+		return bar.High
+	case PriceLow:
+		return bar.Low
+	}
+	return bar.Close
+}
+
+// Returns lists the simple daily returns of the symbol at index i, one per
+// date after the first
+func (d *Dataset) Returns(i int) []float64 {
+	prices := d.Prices[i]
+	if len(prices) < 2 {
+		return nil
+	}
+	returns := make([]float64, len(prices)-1)
+	for t := 1; t < len(prices); t++ {
+		returns[t-1] = prices[t]/prices[t-1] - 1
+	}
+	return returns
+}
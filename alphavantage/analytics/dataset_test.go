@@ -0,0 +1,154 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"stock/common"
+	"stock/common/commontest"
+)
+
+// dailySeries is a TIME_SERIES_DAILY body closing at the given prices on consecutive days
+func dailySeries(symbol string, closes ...float64) string {
+	body := fmt.Sprintf(`{"Meta Data": {"2. Symbol": %q, "5. Time Zone": "US/Eastern"}, "Time Series (Daily)": {`, symbol)
+	for i, c := range closes {
+		if i > 0 {
+			body += ","
+		}
+		body += fmt.Sprintf(`"2024-03-%02d": {"1. open": "%[2]g", "2. high": "%[2]g", "3. low": "%[2]g", "4. close": "%[2]g", "5. volume": "100"}`, i+4, c)
+	}
+	return body + "}}"
+}
+
+func TestGetDatasetReportsMissingSymbols(t *testing.T) {
+	// AAA and BBB have daily series, NOPE is unknown upstream
+	client := commontest.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch symbol := r.URL.Query().Get("symbol"); symbol {
+		case "AAA":
+			fmt.Fprint(w, dailySeries(symbol, 10, 11, 12))
+		case "BBB":
+			fmt.Fprint(w, dailySeries(symbol, 20, 22, 21))
+		default:
+			fmt.Fprint(w, `{"Error Message": "Invalid API call. Please retry or visit the documentation."}`)
+		}
+	})
+
+	dataset, err := GetDataset(context.Background(), client, DatasetParams{Symbols: []string{"AAA", "NOPE", "BBB"}})
+	if err != nil {
+		t.Fatalf("GetDataset() error = %v", err)
+	}
+	if len(dataset.Symbols) != 2 || dataset.Symbols[0] != "AAA" || dataset.Symbols[1] != "BBB" {
+		t.Errorf("Symbols = %v, want AAA and BBB", dataset.Symbols)
+	}
+	if len(dataset.Missing) != 1 || dataset.Missing[0].Symbol != "NOPE" || dataset.Missing[0].Error == "" {
+		t.Errorf("Missing = %+v, want NOPE with its error", dataset.Missing)
+	}
+	if len(dataset.Dates) != 3 || len(dataset.Prices[1]) != 3 || dataset.Prices[1][1] != 22 {
+		t.Errorf("dataset = %+v, want three aligned dates", dataset)
+	}
+
+	window := Fixed(dataset, []Calculation{{Name: CalcMax}})
+	if len(window.Missing) != 1 {
+		t.Errorf("FixedWindow.Missing = %+v, want NOPE", window.Missing)
+	}
+}
+
+func TestGetDatasetFailsWhenEverySymbolFails(t *testing.T) {
+	// Every call hits the daily limit
+	client := commontest.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Information": "You have reached the rate limit of 25 requests per day."}`)
+	})
+
+	_, err := GetDataset(context.Background(), client, DatasetParams{Symbols: []string{"AAA", "BBB"}})
+	if !errors.Is(err, common.ErrRateLimited) {
+		t.Fatalf("GetDataset() error = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestParseCalculationsBounds(t *testing.T) {
+	valid := []string{"HISTOGRAM", "HISTOGRAM(bins=1)", "HISTOGRAM(bins=1000)", "MEAN,STDDEV(annualized=true)", "CORRELATION(method=kendall)"}
+	for _, value := range valid {
+		if _, err := ParseCalculations(value, false); err != nil {
+			t.Errorf("ParseCalculations(%q) error = %v", value, err)
+		}
+	}
+
+	invalid := []string{"", "HISTOGRAM(bins=0)", "HISTOGRAM(bins=1001)", "HISTOGRAM(bins=1e9)", "AUTOCORRELATION(lag=0)", "MEAN(bins=2)", "UNKNOWN", "HISTOGRAM(bins=2"}
+	for _, value := range invalid {
+		if _, err := ParseCalculations(value, false); !errors.Is(err, common.ErrInvalidParameter) {
+			t.Errorf("ParseCalculations(%q) error = %v, want ErrInvalidParameter", value, err)
+		}
+	}
+	if _, err := ParseCalculations("HISTOGRAM", true); !errors.Is(err, common.ErrInvalidParameter) {
+		t.Errorf("sliding HISTOGRAM error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	edges, counts := histogram([]float64{0, 1, 2, 3, 4}, 2)
+	if len(edges) != 3 || edges[0] != 0 || edges[1] != 2 || edges[2] != 4 {
+		t.Errorf("edges = %v, want [0 2 4]", edges)
+	}
+	if len(counts) != 2 || counts[0] != 2 || counts[1] != 3 {
+		t.Errorf("counts = %v, want [2 3]", counts)
+	}
+	if edges, counts := histogram([]float64{1}, maxBins+1); edges != nil || counts != nil {
+		t.Error("histogram() allocated more than maxBins bins")
+	}
+}
+
+// syntheticDataset returns symbols with n prices each on consecutive days
+func syntheticDataset(symbols, n int) *Dataset {
+	d := &Dataset{Prices: make([][]float64, symbols)}
+	for t := 0; t < n; t++ {
+		d.Dates = append(d.Dates, time.Date(2000, time.January, 1+t, 0, 0, 0, 0, time.UTC))
+	}
+	for i := range d.Prices {
+		d.Symbols = append(d.Symbols, fmt.Sprintf("S%02d", i))
+		for t := 0; t < n; t++ {
+			d.Prices[i] = append(d.Prices[i], 100+float64((t*(i+3))%17))
+		}
+	}
+	return d
+}
+
+func TestSlidingBounds(t *testing.T) {
+	d := syntheticDataset(20, 6000)
+	kendall := []Calculation{{Name: CalcCorrelation, Method: MethodKendall}}
+
+	if _, err := Sliding(d, []Calculation{{Name: CalcMean}}, MaxWindowSize+1); !errors.Is(err, common.ErrInvalidParameter) {
+		t.Errorf("window_size %d error = %v, want ErrInvalidParameter", MaxWindowSize+1, err)
+	}
+	if _, err := Sliding(d, kendall, MaxWindowSize); !errors.Is(err, common.ErrInvalidParameter) {
+		t.Errorf("Kendall over 190 pairs error = %v, want ErrInvalidParameter", err)
+	}
+
+	small := syntheticDataset(2, 300)
+	window, err := Sliding(small, kendall, MaxWindowSize)
+	if err != nil {
+		t.Fatalf("Kendall over one pair error = %v", err)
+	}
+	if got := len(window.Results[kendall[0].String()]["S00/S01"]); got != 300-MaxWindowSize {
+		t.Errorf("%d windows, want %d", got, 300-MaxWindowSize)
+	}
+}
+
+func TestFixedMatrixIsSymmetric(t *testing.T) {
+	d := syntheticDataset(3, 30)
+	window := Fixed(d, []Calculation{{Name: CalcCorrelation, Method: MethodKendall}})
+	matrix := window.Results["CORRELATION(method=kendall)"].(Matrix)
+	for i := range matrix.Index {
+		if v := matrix.Values[i][i]; v == nil || *v != 1 {
+			t.Errorf("diagonal %d = %v, want 1", i, v)
+		}
+		for j := range matrix.Index {
+			if a, b := matrix.Values[i][j], matrix.Values[j][i]; a == nil || b == nil || *a != *b {
+				t.Errorf("values[%d][%d] = %v and values[%d][%d] = %v, want equal", i, j, a, j, i, b)
+			}
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+)
+
+// mean returns the arithmetic mean, NaN without values
+func mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return math.NaN()
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// median returns the middle value, or the mean of the two middle values
+func median(xs []float64) float64 {
+	if len(xs) == 0 {
+		return math.NaN()
+	}
+	sorted := append([]float64(nil), xs...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
+
+// minMax returns the smallest and largest value
+func minMax(xs []float64) (float64, float64) {
+	if len(xs) == 0 {
+		return math.NaN(), math.NaN()
+	}
+	lo, hi := xs[0], xs[0]
+	for _, x := range xs[1:] {
+		lo, hi = math.Min(lo, x), math.Max(hi, x)
+	}
+	return lo, hi
+}
+
+// covariance returns the sample covariance of two equally long series
+func covariance(xs, ys []float64) float64 {
+	if len(xs) < 2 || len(xs) != len(ys) {
+		return math.NaN()
+	}
+	mx, my := mean(xs), mean(ys)
+	var sum float64
+	for i := range xs {
+		sum += (xs[i] - mx) * (ys[i] - my)
+	}
+	return sum / float64(len(xs)-1)
+}
+
+// variance returns the sample variance
+func variance(xs []float64) float64 {
+	return covariance(xs, xs)
+}
+
+// pearson returns the Pearson correlation coefficient
+func pearson(xs, ys []float64) float64 {
+	return covariance(xs, ys) / math.Sqrt(variance(xs)*variance(ys))
+}
+
+// spearman returns the Spearman rank correlation coefficient
+func spearman(xs, ys []float64) float64 {
+	return pearson(ranks(xs), ranks(ys))
+}
+
+// ranks returns the 1-based rank of each value, ties sharing their average rank
+func ranks(xs []float64) []float64 {
+	order := make([]int, len(xs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return xs[order[a]] < xs[order[b]] })
+
+	out := make([]float64, len(xs))
+	for i := 0; i < len(order); {
+		j := i
+		for j+1 < len(order) && xs[order[j+1]] == xs[order[i]] {
+			j++
+		}
+		rank := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			out[order[k]] = rank
+		}
+		i = j + 1
+	}
+	return out
+}
+
+// kendall returns Kendall's tau-b rank correlation coefficient
+func kendall(xs, ys []float64) float64 {
+	if len(xs) < 2 || len(xs) != len(ys) {
+		return math.NaN()
+	}
+	var concordant, discordant, tiesX, tiesY float64
+	for i := 0; i < len(xs); i++ {
+		for j := i + 1; j < len(xs); j++ {
+			dx, dy := xs[i]-xs[j], ys[i]-ys[j]
+			switch {
+			case dx == 0 && dy == 0:
+			case dx == 0:
+				tiesX++
+			case dy == 0:
+				tiesY++
+			case (dx > 0) == (dy > 0):
+				concordant++
+			default:
+				discordant++
+			}
+		}
+	}
+	return (concordant - discordant) / math.Sqrt((concordant+discordant+tiesX)*(concordant+discordant+tiesY))
+}
+
+// autocorrelation returns the sample autocorrelation at lag
+func autocorrelation(xs []float64, lag int) float64 {
+	if lag < 1 || len(xs) <= lag {
+		return math.NaN()
+	}
+	m := mean(xs)
+	var num, den float64
+	for i, x := range xs {
+		den += (x - m) * (x - m)
+		if i >= lag {
+			num += (x - m) * (xs[i-lag] - m)
+		}
+	}
+	return num / den
+}
+
+// cumulativeReturn compounds simple returns
+func cumulativeReturn(returns []float64) float64 {
+	growth := 1.0
+	for _, r := range returns {
+		growth *= 1 + r
+	}
+	return growth - 1
+}
+
+// drawdown returns the largest peak to trough decline of prices as a negative
+// fraction with the indexes of the peak and the trough
+func drawdown(prices []float64) (float64, int, int) {
+	if len(prices) == 0 {
+		return math.NaN(), -1, -1
+	}
+	var worst float64
+	peak, worstPeak, worstTrough := 0, 0, 0
+	for i, p := range prices {
+		if p > prices[peak] {
+			peak = i
+		}
+		if dd := p/prices[peak] - 1; dd < worst {
+			worst, worstPeak, worstTrough = dd, peak, i
+		}
+	}
+	return worst, worstPeak, worstTrough
+}
+
+// histogram counts values into bins of equal width between the smallest and
+// largest value, returning the bins+1 edges and the counts
+func histogram(xs []float64, bins int) ([]float64, []int) {
+	lo, hi := minMax(xs)
+	if len(xs) == 0 || bins < 1 || bins > maxBins {
+		return nil, nil
+	}
+
+	width := (hi - lo) / float64(bins)
+	edges := make([]float64, bins+1)
+	for i := range edges {
+		edges[i] = lo + float64(i)*width
+	}
+	edges[bins] = hi
+
+	counts := make([]int, bins)
+	for _, x := range xs {
+		bin := bins - 1
+		if width > 0 {
+			bin = int((x - lo) / width)
+		}
+		if bin >= bins {
+			bin = bins - 1 // The largest value belongs to the last bin
+		}
+		counts[bin]++
+	}
+	return edges, counts
+}
@@ -0,0 +1,264 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+
+	"stock/common"
+)
+
+// Drawdown is the largest peak to trough decline of a price series
+type Drawdown struct {
+	Drawdown *float64 `json:"drawdown"` // Negative fraction of the peak
+	Peak     string   `json:"peak,omitempty"`
+	Trough   string   `json:"trough,omitempty"`
+}
+
+// Histogram counts returns into bins of equal width
+type Histogram struct {
+	BinEdges  []float64 `json:"bin_edges"` // One more edge than bins, the last bin includes its upper edge
+	BinCounts []int     `json:"bin_counts"`
+}
+
+// Matrix is a symmetric symbol by symbol statistic
+type Matrix struct {
+	Index  []string     `json:"index"`
+	Values [][]*float64 `json:"values"` // Rows and columns follow Index
+}
+
+// FixedWindow holds statistics of the daily returns over a whole range. Each
+// result is keyed by symbol, except covariance and correlation matrices.
+type FixedWindow struct {
+	Symbols      []string               `json:"symbols"`
+	From         string                 `json:"from"`
+	To           string                 `json:"to"`
+	Observations int                    `json:"observations"` // Daily returns per symbol
+	Results      map[string]interface{} `json:"results"`      // Keyed by calculation
+	Missing      []MissingSymbol        `json:"missing_symbols,omitempty"`
+
+	keys []string // Calculation keys in request order
+}
+
+// WindowValue is a statistic of the window ending on Date
+type WindowValue struct {
+	Date  string   `json:"date"`
+	Value *float64 `json:"value"`
+}
+
+// SlidingWindow holds statistics of daily returns over windows of WindowSize
+// returns, one per date. Pairwise results are keyed by "SYMBOL1/SYMBOL2".
+type SlidingWindow struct {
+	Symbols      []string                            `json:"symbols"`
+	From         string                              `json:"from"`
+	To           string                              `json:"to"`
+	Observations int                                 `json:"observations"`
+	WindowSize   int                                 `json:"window_size"`
+	Results      map[string]map[string][]WindowValue `json:"results"` // Keyed by calculation, then symbol
+	Missing      []MissingSymbol                     `json:"missing_symbols,omitempty"`
+
+	keys []string // Calculation keys in request order
+}
+
+// Row is one result in tabular form, Date is only set for sliding windows
+type Row struct {
+	Calculation string      `json:"calculation"`
+	Symbol      string      `json:"symbol"`
+	Date        string      `json:"date,omitempty"`
+	Value       interface{} `json:"value"`
+}
+
+// bounds returns the first and last date of the dataset
+func (d *Dataset) bounds() (string, string) {
+	if len(d.Dates) == 0 {
+		return "", ""
+	}
+	return d.Dates[0].Format(common.DateLayout), d.Dates[len(d.Dates)-1].Format(common.DateLayout)
+}
+
+// Fixed computes the calculations over every return of the dataset
+func Fixed(d *Dataset, calcs []Calculation) *FixedWindow {
+	returns := make([][]float64, len(d.Symbols))
+	for i := range d.Symbols {
+		returns[i] = d.Returns(i)
+	}
+
+	window := &FixedWindow{Symbols: d.Symbols, Missing: d.Missing, Results: make(map[string]interface{}, len(calcs))}
+	window.From, window.To = d.bounds()
+	if len(returns) > 0 {
+		window.Observations = len(returns[0])
+	}
+
+	for _, calc := range calcs {
+		window.keys = append(window.keys, calc.String())
+		switch {
+		case calc.pairwise():
+			matrix := Matrix{Index: d.Symbols, Values: make([][]*float64, len(d.Symbols))}
+			for i := range d.Symbols {
+				matrix.Values[i] = make([]*float64, len(d.Symbols))
+			}
+			// The matrix is symmetric, each pair is computed once
+			for i := range d.Symbols {
+				for j := i; j < len(d.Symbols); j++ {
+					matrix.Values[i][j] = number(calc.pair(returns[i], returns[j]))
+					matrix.Values[j][i] = matrix.Values[i][j]
+				}
+			}
+			window.Results[calc.String()] = matrix
+		case calc.Name == CalcMaxDrawdown:
+			results := make(map[string]Drawdown, len(d.Symbols))
+			for i, symbol := range d.Symbols {
+				value, peak, trough := drawdown(d.Prices[i])
+				result := Drawdown{Drawdown: number(value)}
+				if peak >= 0 && value < 0 {
+					result.Peak = d.Dates[peak].Format(common.DateLayout)
+					result.Trough = d.Dates[trough].Format(common.DateLayout)
+				}
+				results[symbol] = result
+			}
+			window.Results[calc.String()] = results
+		case calc.Name == CalcHistogram:
+			results := make(map[string]Histogram, len(d.Symbols))
+			for i, symbol := range d.Symbols {
+				edges, counts := histogram(returns[i], calc.Bins)
+				results[symbol] = Histogram{BinEdges: edges, BinCounts: counts}
+			}
+			window.Results[calc.String()] = results
+		default:
+			results := make(map[string]*float64, len(d.Symbols))
+			for i, symbol := range d.Symbols {
+				results[symbol] = number(calc.scalar(returns[i]))
+			}
+			window.Results[calc.String()] = results
+		}
+	}
+	return window
+}
+
+// Rows lists the results as one row per calculation and symbol, matrices
+// giving each symbol's row keyed by the other symbols
+func (w *FixedWindow) Rows() []Row {
+	var rows []Row
+	for _, key := range w.keys {
+		switch result := w.Results[key].(type) {
+		case Matrix:
+			for i, symbol := range result.Index {
+				row := make(map[string]*float64, len(result.Index))
+				for j, other := range result.Index {
+					row[other] = result.Values[i][j]
+				}
+				rows = append(rows, Row{Calculation: key, Symbol: symbol, Value: row})
+			}
+		case map[string]Drawdown:
+			for _, symbol := range w.Symbols {
+				rows = append(rows, Row{Calculation: key, Symbol: symbol, Value: result[symbol]})
+			}
+		case map[string]Histogram:
+			for _, symbol := range w.Symbols {
+				rows = append(rows, Row{Calculation: key, Symbol: symbol, Value: result[symbol]})
+			}
+		case map[string]*float64:
+			for _, symbol := range w.Symbols {
+				rows = append(rows, Row{Calculation: key, Symbol: symbol, Value: result[symbol]})
+			}
+		}
+	}
+	return rows
+}
+
+// Sliding computes the calculations over every window of size consecutive
+// returns, dating each result with the window's last day
+func Sliding(d *Dataset, calcs []Calculation, size int) (*SlidingWindow, error) {
+	if size < 2 || size > MaxWindowSize {
+		return nil, fmt.Errorf("%w: window_size must be between 2 and %d", common.ErrInvalidParameter, MaxWindowSize)
+	}
+	returns := make([][]float64, len(d.Symbols))
+	for i := range d.Symbols {
+		returns[i] = d.Returns(i)
+	}
+
+	window := &SlidingWindow{
+		Symbols:    d.Symbols,
+		Missing:    d.Missing,
+		WindowSize: size,
+		Results:    make(map[string]map[string][]WindowValue, len(calcs)),
+	}
+	window.From, window.To = d.bounds()
+	if len(returns) > 0 {
+		window.Observations = len(returns[0])
+	}
+	if window.Observations < size {
+		return nil, fmt.Errorf("%w: window_size %d exceeds the %d returns in range", common.ErrInvalidParameter, size, window.Observations)
+	}
+	pairs := len(d.Symbols) * (len(d.Symbols) - 1) / 2
+	windows := window.Observations - size + 1
+	for _, calc := range calcs {
+		if calc.Name == CalcCorrelation && calc.Method == MethodKendall &&
+			windows*pairs*(size*(size-1)/2) > maxKendallComparisons {
+			return nil, fmt.Errorf("%w: %s over %d windows of %d returns and %d pairs is too expensive, narrow the range, window_size or symbols",
+				common.ErrInvalidParameter, calc, windows, size, pairs)
+		}
+	}
+
+	for _, calc := range calcs {
+		window.keys = append(window.keys, calc.String())
+		results := make(map[string][]WindowValue)
+		if calc.pairwise() {
+			if len(d.Symbols) < 2 {
+				return nil, fmt.Errorf("%w: %s needs at least two symbols", common.ErrInvalidParameter, calc.Name)
+			}
+			for i := range d.Symbols {
+				for j := i + 1; j < len(d.Symbols); j++ {
+					results[d.Symbols[i]+"/"+d.Symbols[j]] = d.slide(size, func(from, to int) float64 {
+						return calc.pair(returns[i][from:to], returns[j][from:to])
+					})
+				}
+			}
+		} else {
+			for i, symbol := range d.Symbols {
+				results[symbol] = d.slide(size, func(from, to int) float64 {
+					return calc.scalar(returns[i][from:to])
+				})
+			}
+		}
+		window.Results[calc.String()] = results
+	}
+	return window, nil
+}
+
+// slide evaluates fn over each window of size returns, given as a half-open
+// range of return indexes. Return t is dated with Dates[t+1].
+func (d *Dataset) slide(size int, fn func(from, to int) float64) []WindowValue {
+	observations := len(d.Dates) - 1
+	values := make([]WindowValue, 0, observations-size+1)
+	for to := size; to <= observations; to++ {
+		values = append(values, WindowValue{
+			Date:  d.Dates[to].Format(common.DateLayout),
+			Value: number(fn(to-size, to)),
+		})
+	}
+	return values
+}
+
+// Rows lists the results as one row per calculation, symbol and window
+func (w *SlidingWindow) Rows() []Row {
+	var rows []Row
+	for _, key := range w.keys {
+		results := w.Results[key]
+		for _, symbol := range sortedKeys(results) {
+			for _, value := range results[symbol] {
+				rows = append(rows, Row{Calculation: key, Symbol: symbol, Date: value.Date, Value: value.Value})
+			}
+		}
+	}
+	return rows
+}
+
+// sortedKeys returns the keys of results in the order encoding/json writes them
+func sortedKeys(results map[string][]WindowValue) []string {
+	keys := make([]string, 0, len(results))
+	for key := range results {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
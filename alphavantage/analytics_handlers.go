@@ -0,0 +1,168 @@
+package alphavantage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"stock/alphavantage/analytics"
+	"stock/common"
+	"stock/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsResponse defines the response format for window analytics
+// @Description Window analytics response data structure
+type AnalyticsResponse struct {
+	Version   string      `json:"version"`
+	Timestamp string      `json:"timestamp"`
+	Cache     string      `json:"cache,omitempty"`
+	Data      interface{} `json:"data"` // *analytics.FixedWindow or *analytics.SlidingWindow
+}
+
+// maxAnalyticsSymbols bounds the symbols of one analytics request, each needs its own series
+const maxAnalyticsSymbols = 20
+
+// defaultWindowSize is the sliding window length in returns when none is given
+const defaultWindowSize = 20
+
+// GetFixedWindowAnalytics handles requests for statistics over a fixed window
+// @Summary Compute return statistics over a fixed window
+// @Description Computes statistics of daily returns over the whole range locally from cached daily series, modeled on ANALYTICS_FIXED_WINDOW. Symbols are aligned on the dates all of them traded, symbols whose series cannot be fetched are listed in missing_symbols. CSV and NDJSON output list one row per calculation and symbol.
+// @Tags analytics
+// @Produce json,text/csv,application/x-ndjson
+// @Param symbols query string true "Comma-separated symbols (e.g., AAPL,MSFT,IBM)"
+// @Param range query []string true "full, a period back from the latest bar (e.g., 5day, 6month, 2year) or a start and end date (YYYY-MM-DD or YYYY-MM) as two values" collectionFormat(multi)
+// @Param ohlc query string false "Price returns are computed from" Enums(open, high, low, close) default(close)
+// @Param calculations query string true "Comma-separated MIN, MAX, MEAN, MEDIAN, CUMULATIVE_RETURN, VARIANCE, STDDEV, MAX_DRAWDOWN, HISTOGRAM, AUTOCORRELATION, COVARIANCE, CORRELATION with options such as STDDEV(annualized=true), HISTOGRAM(bins=20) up to 1000 bins, AUTOCORRELATION(lag=2), CORRELATION(method=kendall)"
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} AnalyticsResponse{data=analytics.FixedWindow} "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/analytics/fixed [get]
+func (h *Handler) GetFixedWindowAnalytics(c *gin.Context) {
+	calcs, err := analytics.ParseCalculations(c.Query("calculations"), false)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	ctx, cache := requestContext(c)
+
+	dataset, err := h.analyticsDataset(ctx, c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	window := analytics.Fixed(dataset, calcs)
+
+	response := AnalyticsResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Cache:     setCacheHeader(c, cache),
+		Data:      window,
+	}
+
+	respond(c, response, window.Rows())
+}
+
+// GetSlidingWindowAnalytics handles requests for statistics over sliding windows
+// @Summary Compute return statistics over sliding windows
+// @Description Computes statistics of daily returns over every window of window_size returns locally from cached daily series, modeled on ANALYTICS_SLIDING_WINDOW. Each window is dated with its last day, pairwise results are keyed by SYMBOL1/SYMBOL2. Symbols whose series cannot be fetched are listed in missing_symbols. CSV and NDJSON output list one row per calculation, symbol and window.
+// @Tags analytics
+// @Produce json,text/csv,application/x-ndjson
+// @Param symbols query string true "Comma-separated symbols (e.g., AAPL,MSFT,IBM)"
+// @Param range query []string true "full, a period back from the latest bar (e.g., 5day, 6month, 2year) or a start and end date (YYYY-MM-DD or YYYY-MM) as two values" collectionFormat(multi)
+// @Param ohlc query string false "Price returns are computed from" Enums(open, high, low, close) default(close)
+// @Param window_size query int false "Returns per window, at most 252" default(20)
+// @Param calculations query string true "Comma-separated MEAN, MEDIAN, CUMULATIVE_RETURN, VARIANCE, STDDEV, COVARIANCE, CORRELATION with options such as STDDEV(annualized=true), CORRELATION(method=spearman)"
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} AnalyticsResponse{data=analytics.SlidingWindow} "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/analytics/sliding [get]
+func (h *Handler) GetSlidingWindowAnalytics(c *gin.Context) {
+	calcs, err := analytics.ParseCalculations(c.Query("calculations"), true)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	size := defaultWindowSize
+	if value := c.Query("window_size"); value != "" {
+		if size, err = strconv.Atoi(value); err != nil {
+			respondError(c, fmt.Errorf("%w: window_size must be an integer", common.ErrInvalidParameter))
+			return
+		}
+	}
+
+	ctx, cache := requestContext(c)
+
+	dataset, err := h.analyticsDataset(ctx, c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	window, err := analytics.Sliding(dataset, calcs, size)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response := AnalyticsResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Cache:     setCacheHeader(c, cache),
+		Data:      window,
+	}
+
+	respond(c, response, window.Rows())
+}
+
+// analyticsDataset fetches the aligned daily prices selected by the symbols, range and ohlc parameters
+func (h *Handler) analyticsDataset(ctx context.Context, c *gin.Context) (*analytics.Dataset, error) {
+	var symbols []string
+	seen := make(map[string]bool)
+	for _, symbol := range strings.Split(c.Query("symbols"), ",") {
+		if symbol = strings.ToUpper(strings.TrimSpace(symbol)); symbol != "" && !seen[symbol] {
+			seen[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+	if len(symbols) == 0 {
+		return nil, fmt.Errorf("%w: symbols is required", common.ErrInvalidParameter)
+	}
+	if len(symbols) > maxAnalyticsSymbols {
+		return nil, fmt.Errorf("%w: at most %d symbols are allowed", common.ErrInvalidParameter, maxAnalyticsSymbols)
+	}
+
+	values := c.QueryArray("range")
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%w: range is required", common.ErrInvalidParameter)
+	}
+	dateRange, err := analytics.ParseRange(values)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrInvalidParameter, err)
+	}
+
+	return h.client.GetAnalyticsDataset(ctx, analytics.DatasetParams{
+		Symbols: symbols,
+		Range:   dateRange,
+		Price:   strings.ToLower(c.DefaultQuery("ohlc", analytics.PriceClose)),
+	})
+}
@@ -1,7 +1,6 @@
 package alphavantage
 
 import (
-	"net/http"
 	"stock/alphavantage/fundamental"
 	"time"
 
@@ -11,22 +10,32 @@ import (
 // BalanceSheetResponse defines the response format for balance sheet data
 // @Description Balance sheet response data structure
 type BalanceSheetResponse struct {
-	Version   string                            `json:"version"`
-	Timestamp string                            `json:"timestamp"`
-	Symbol    string                            `json:"symbol"`
-	Data      *fundamental.BalanceSheetResponse `json:"data"`
+	Version   string      `json:"version"`
+	Timestamp string      `json:"timestamp"`
+	Symbol    string      `json:"symbol"`
+	Cache     string      `json:"cache,omitempty"`
+	Data      interface{} `json:"data"` // *fundamental.ParsedBalanceSheet, or *fundamental.BalanceSheetResponse when raw=true
 }
 
 // GetBalanceSheet handles requests for balance sheet data
 // @Summary Get balance sheet data for a specific symbol
 // @Description Returns the balance sheet data for the specified stock symbol
 // @Tags fundamental
-// @Produce json
+// @Produce json,text/csv,application/x-ndjson
 // @Param symbol path string true "Stock symbol (e.g., AAPL, MSFT)"
-// @Success 200 {object} BalanceSheetResponse "Successful operation"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Param raw query boolean false "Serve the original Alpha Vantage strings instead of typed values" default(false)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} BalanceSheetResponse{data=fundamental.ParsedBalanceSheet} "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
 // @Router /v1/fundamental/balance-sheet/{symbol} [get]
-func GetBalanceSheet(c *gin.Context) {
+func (h *Handler) GetBalanceSheet(c *gin.Context) {
 	symbol := c.Param("symbol")
 
 	// Create params for the fundamental library
@@ -34,22 +43,35 @@ func GetBalanceSheet(c *gin.Context) {
 		Symbol: symbol,
 	}
 
+	ctx, cache := requestContext(c)
+
 	// Get balance sheet data
-	data, err := fundamental.GetBalanceSheet(params)
+	data, err := h.client.GetBalanceSheet(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
+	// Serve typed values unless the caller asked for the original strings
+	var body interface{} = data
+	rows := statementRows(data.AnnualReports, data.QuarterlyReports)
+	if !rawRequested(c) {
+		parsed, err := data.Parse()
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		body, rows = parsed, statementRows(parsed.AnnualReports, parsed.QuarterlyReports)
+	}
+
 	// Create response with versioning
 	response := BalanceSheetResponse{
 		Version:   "1.0", // TODO: Replace with config value once GetConfig() is implemented
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		Symbol:    symbol,
-		Data:      data,
+		Cache:     setCacheHeader(c, cache),
+		Data:      body,
 	}
 
-	c.JSON(http.StatusOK, response)
+	respond(c, response, rows)
 }
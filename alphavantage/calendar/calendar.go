@@ -0,0 +1,60 @@
+package calendar
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Watchlist is a set of upper case symbols calendars can be narrowed to
+type Watchlist map[string]bool
+
+// NewWatchlist builds a watchlist from symbols in any case
+func NewWatchlist(symbols []string) Watchlist {
+	watchlist := make(Watchlist, len(symbols))
+	for _, symbol := range symbols {
+		if symbol = strings.ToUpper(strings.TrimSpace(symbol)); symbol != "" {
+			watchlist[symbol] = true
+		}
+	}
+	return watchlist
+}
+
+// Contains reports whether symbol is on the watchlist
+func (w Watchlist) Contains(symbol string) bool {
+	return w[strings.ToUpper(symbol)]
+}
+
+// FilterEarnings returns the earnings reports of watchlist symbols
+func (w Watchlist) FilterEarnings(events []EarningsEvent) []EarningsEvent {
+	filtered := make([]EarningsEvent, 0, len(events))
+	for _, event := range events {
+		if w.Contains(event.Symbol) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// FilterIPOs returns the offerings of watchlist symbols
+func (w Watchlist) FilterIPOs(events []IPOEvent) []IPOEvent {
+	filtered := make([]IPOEvent, 0, len(events))
+	for _, event := range events {
+		if w.Contains(event.Symbol) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// sortEvents orders an event slice by date, then symbol, given the key of its i-th element
+func sortEvents(events interface{}, key func(i int) (time.Time, string)) {
+	sort.SliceStable(events, func(i, j int) bool {
+		di, si := key(i)
+		dj, sj := key(j)
+		if !di.Equal(dj) {
+			return di.Before(dj)
+		}
+		return si < sj
+	})
+}
@@ -0,0 +1,47 @@
+package calendar
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"stock/common"
+	"stock/common/commontest"
+)
+
+// csvClient serves body for every request and records the last query
+func csvClient(t *testing.T, body string, query *string) *common.Client {
+	t.Helper()
+	return commontest.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if query != nil {
+			*query = r.URL.RawQuery
+		}
+		fmt.Fprint(w, body)
+	})
+}
+
+func TestWatchlist(t *testing.T) {
+	w := NewWatchlist([]string{" ibm", "MSFT ", "", "  "})
+	if len(w) != 2 {
+		t.Errorf("NewWatchlist() = %v, want IBM and MSFT", w)
+	}
+	for symbol, want := range map[string]bool{"IBM": true, "ibm": true, "Msft": true, "AAPL": false, "": false} {
+		if got := w.Contains(symbol); got != want {
+			t.Errorf("Contains(%q) = %v, want %v", symbol, got, want)
+		}
+	}
+
+	earnings := w.FilterEarnings([]EarningsEvent{{Symbol: "AAPL"}, {Symbol: "IBM"}, {Symbol: "msft"}})
+	if len(earnings) != 2 || earnings[0].Symbol != "IBM" || earnings[1].Symbol != "msft" {
+		t.Errorf("FilterEarnings() = %+v, want IBM and msft in order", earnings)
+	}
+	ipos := w.FilterIPOs([]IPOEvent{{Symbol: "NEWCO"}, {Symbol: "IBM"}})
+	if len(ipos) != 1 || ipos[0].Symbol != "IBM" {
+		t.Errorf("FilterIPOs() = %+v, want IBM", ipos)
+	}
+
+	// An empty watchlist keeps nothing
+	if got := NewWatchlist(nil).FilterEarnings([]EarningsEvent{{Symbol: "IBM"}}); len(got) != 0 {
+		t.Errorf("empty FilterEarnings() = %+v, want none", got)
+	}
+}
@@ -0,0 +1,116 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"stock/common"
+)
+
+// Horizons accepted by EARNINGS_CALENDAR
+const (
+	Horizon3Months  = "3month"
+	Horizon6Months  = "6month"
+	Horizon12Months = "12month"
+)
+
+// ValidHorizon reports whether horizon is accepted by EARNINGS_CALENDAR
+func ValidHorizon(horizon string) bool {
+	return horizon == Horizon3Months || horizon == Horizon6Months || horizon == Horizon12Months
+}
+
+// EarningsCalendarParams holds parameters for retrieving upcoming earnings
+type EarningsCalendarParams struct {
+	Symbol  string // All companies when empty
+	Horizon string // 3month, 6month or 12month, 3month when empty
+}
+
+// EarningsEvent is a scheduled earnings report
+type EarningsEvent struct {
+	Symbol           string         `json:"symbol"`
+	Name             string         `json:"name"`
+	ReportDate       time.Time      `json:"reportDate"`
+	FiscalDateEnding *time.Time     `json:"fiscalDateEnding"`
+	Estimate         common.Decimal `json:"estimate"` // Consensus EPS estimate, null when not covered
+	Currency         string         `json:"currency"`
+}
+
+// GetEarningsCalendar fetches the earnings reports expected within the
+// horizon, which Alpha Vantage only serves as CSV
+func GetEarningsCalendar(ctx context.Context, client *common.Client, params EarningsCalendarParams) ([]EarningsEvent, error) {
+	// Building query parameters
+	queryParams := map[string]string{
+		"function": "EARNINGS_CALENDAR",
+	}
+	if params.Symbol != "" {
+		queryParams["symbol"] = params.Symbol
+	}
+	if params.Horizon != "" {
+		queryParams["horizon"] = params.Horizon
+	}
+
+	records, err := client.GetCSV(ctx, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]EarningsEvent, 0, len(records))
+	for _, record := range records {
+		if record["symbol"] == "" {
+			continue
+		}
+
+		// One malformed row should not take down the whole feed
+		event, err := parseEarningsEvent(record)
+		if err != nil {
+			log.Printf("Warning: skipping earnings calendar row: %v", err)
+			continue
+		}
+		events = append(events, event)
+	}
+
+	sortEvents(events, func(i int) (time.Time, string) { return events[i].ReportDate, events[i].Symbol })
+	return events, nil
+}
+
+// parseEarningsEvent decodes one EARNINGS_CALENDAR row
+func parseEarningsEvent(record common.CSVRecord) (EarningsEvent, error) {
+	event := EarningsEvent{
+		Symbol:   record["symbol"],
+		Name:     record["name"],
+		Currency: record["currency"],
+	}
+	reportDate, err := common.ParseDate(record["reportdate"])
+	if err != nil || reportDate == nil {
+		return event, fmt.Errorf("earnings calendar %s: report date %q", event.Symbol, record["reportdate"])
+	}
+	event.ReportDate = *reportDate
+	if event.FiscalDateEnding, err = common.ParseDate(record["fiscaldateending"]); err != nil {
+		return event, fmt.Errorf("earnings calendar %s: fiscal date ending: %w", event.Symbol, err)
+	}
+	if event.Estimate, err = common.ParseDecimal(record["estimate"]); err != nil {
+		return event, fmt.Errorf("earnings calendar %s: estimate: %w", event.Symbol, err)
+	}
+	return event, nil
+}
+
+// Event returns the report as an all-day calendar event
+func (e EarningsEvent) Event() Event {
+	var description []string
+	if e.FiscalDateEnding != nil {
+		description = append(description, "Fiscal period ending "+e.FiscalDateEnding.Format(common.DateLayout))
+	}
+	if e.Estimate.Valid() {
+		description = append(description, strings.TrimSpace("EPS estimate "+e.Estimate.String()+" "+e.Currency))
+	}
+
+	return Event{
+		UID:         fmt.Sprintf("earnings-%s-%s", e.Symbol, e.ReportDate.Format(icsDateLayout)),
+		Date:        e.ReportDate,
+		Summary:     fmt.Sprintf("%s earnings", e.Symbol),
+		Description: strings.Join(description, "\n"),
+	}
+}
@@ -0,0 +1,69 @@
+package calendar
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGetEarningsCalendar(t *testing.T) {
+	const body = "symbol,name,reportDate,fiscalDateEnding,estimate,currency\r\n" +
+		"MSFT,Microsoft Corp,2025-01-29,2024-12-31,3.11,USD\r\n" +
+		"IBM,International Business Machines,2025-01-22,2024-12-31,3.75,USD\r\n" +
+		"AAPL,Apple Inc,2025-01-29,2024-12-31,,USD\r\n" +
+		",Blank symbol,2025-01-30,2024-12-31,1.00,USD\r\n" +
+		"BAD1,Bad report date,soon,2024-12-31,1.00,USD\r\n" +
+		"BAD2,Missing report date,,2024-12-31,1.00,USD\r\n" +
+		"BAD3,Bad estimate,2025-01-30,2024-12-31,three,USD\r\n"
+
+	var query string
+	events, err := GetEarningsCalendar(context.Background(), csvClient(t, body, &query), EarningsCalendarParams{Symbol: "IBM", Horizon: Horizon6Months})
+	if err != nil {
+		t.Fatalf("GetEarningsCalendar() error = %v, want malformed rows skipped", err)
+	}
+	for _, want := range []string{"function=EARNINGS_CALENDAR", "symbol=IBM", "horizon=6month"} {
+		if !strings.Contains(query, want) {
+			t.Errorf("query = %q, want %s", query, want)
+		}
+	}
+
+	// Sorted by date, then symbol
+	var symbols []string
+	for _, event := range events {
+		symbols = append(symbols, event.Symbol)
+	}
+	if got := strings.Join(symbols, ","); got != "IBM,AAPL,MSFT" {
+		t.Fatalf("symbols = %s, want IBM,AAPL,MSFT", got)
+	}
+
+	ibm := events[0]
+	if ibm.ReportDate.Format("2006-01-02") != "2025-01-22" || ibm.FiscalDateEnding == nil || ibm.Estimate.String() != "3.75" || ibm.Currency != "USD" {
+		t.Errorf("IBM = %+v", ibm)
+	}
+	if events[1].Estimate.Valid() {
+		t.Errorf("AAPL estimate = %s, want null", events[1].Estimate)
+	}
+}
+
+func TestEarningsEvent(t *testing.T) {
+	events, err := GetEarningsCalendar(context.Background(), csvClient(t, "symbol,name,reportDate,fiscalDateEnding,estimate,currency\nIBM,IBM,2025-01-22,2024-12-31,3.75,USD\nAAPL,Apple,2025-01-29,,,\n", nil), EarningsCalendarParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ibm := events[0].Event()
+	if ibm.UID != "earnings-IBM-20250122" || ibm.Summary != "IBM earnings" || ibm.Description != "Fiscal period ending 2024-12-31\nEPS estimate 3.75 USD" {
+		t.Errorf("IBM Event() = %+v", ibm)
+	}
+	if aapl := events[1].Event(); aapl.Description != "" {
+		t.Errorf("AAPL Event() description = %q, want empty", aapl.Description)
+	}
+}
+
+func TestValidHorizon(t *testing.T) {
+	for horizon, want := range map[string]bool{"3month": true, "6month": true, "12month": true, "": false, "1month": false} {
+		if got := ValidHorizon(horizon); got != want {
+			t.Errorf("ValidHorizon(%q) = %v, want %v", horizon, got, want)
+		}
+	}
+}
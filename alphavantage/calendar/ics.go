@@ -0,0 +1,88 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icsDateLayout is the iCalendar DATE value layout
+const icsDateLayout = "20060102"
+
+// icsTimestampLayout is the iCalendar UTC DATE-TIME value layout
+const icsTimestampLayout = "20060102T150405Z"
+
+// icsLineLimit is the longest content line in octets before it is folded (RFC 5545 3.1)
+const icsLineLimit = 75
+
+// icsDomain qualifies event UIDs so they stay unique across calendars
+const icsDomain = "stock"
+
+// Event is an all-day calendar entry
+type Event struct {
+	UID         string
+	Date        time.Time // Only the year, month and day are used
+	Summary     string
+	Description string
+}
+
+// WriteICS writes events as an iCalendar (RFC 5545) feed named name, stamped with now
+func WriteICS(w io.Writer, name string, events []Event, now time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(content string) {
+		writeFolded(bw, content)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//" + icsDomain + "//calendar//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escapeText(name))
+
+	stamp := now.UTC().Format(icsTimestampLayout)
+	for _, event := range events {
+		y, m, d := event.Date.Date()
+		day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+		line("BEGIN:VEVENT")
+		line("UID:" + escapeText(event.UID) + "@" + icsDomain)
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;VALUE=DATE:" + day.Format(icsDateLayout))
+		line("DTEND;VALUE=DATE:" + day.AddDate(0, 0, 1).Format(icsDateLayout))
+		line("SUMMARY:" + escapeText(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION:" + escapeText(event.Description))
+		}
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+// escapeText escapes an iCalendar TEXT value
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// writeFolded writes a content line, folding it into CRLF-separated chunks of
+// at most icsLineLimit octets without splitting UTF-8 sequences
+func writeFolded(w *bufio.Writer, content string) {
+	limit := icsLineLimit
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		fmt.Fprintf(w, "%s\r\n ", content[:cut])
+		content = content[cut:]
+		// Continuation lines start with a space that counts toward the limit
+		limit = icsLineLimit - 1
+	}
+	fmt.Fprintf(w, "%s\r\n", content)
+}
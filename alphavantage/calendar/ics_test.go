@@ -0,0 +1,116 @@
+package calendar
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{`back\slash`, `back\\slash`},
+		{"a;b,c", `a\;b\,c`},
+		{"line\nbreak", `line\nbreak`},
+		{"crlf\r\nbreak", `crlf\nbreak`},
+	}
+	for _, tt := range tests {
+		if got := escapeText(tt.in); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteFolded(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		lines   int
+	}{
+		{"short", "SUMMARY:IBM earnings", 1},
+		{"exactly the limit", strings.Repeat("a", icsLineLimit), 1},
+		{"one over the limit", strings.Repeat("a", icsLineLimit+1), 2},
+		{"long", "DESCRIPTION:" + strings.Repeat("x", 300), 5},
+		{"multibyte", "SUMMARY:" + strings.Repeat("é", 100), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := bufio.NewWriter(&buf)
+			writeFolded(w, tt.content)
+			w.Flush()
+
+			out := buf.String()
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("writeFolded() = %q, want a CRLF ending", out)
+			}
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			if len(lines) != tt.lines {
+				t.Errorf("writeFolded() = %d lines, want %d", len(lines), tt.lines)
+			}
+
+			var unfolded strings.Builder
+			for i, line := range lines {
+				if len(line) > icsLineLimit {
+					t.Errorf("line %d is %d octets, want at most %d", i, len(line), icsLineLimit)
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d = %q splits a UTF-8 sequence", i, line)
+				}
+				if i > 0 {
+					if !strings.HasPrefix(line, " ") {
+						t.Errorf("continuation line %d = %q, want a leading space", i, line)
+					}
+					line = line[1:]
+				}
+				unfolded.WriteString(line)
+			}
+			if unfolded.String() != tt.content {
+				t.Errorf("unfolded = %q, want %q", unfolded.String(), tt.content)
+			}
+		})
+	}
+}
+
+func TestWriteICS(t *testing.T) {
+	events := []Event{{
+		UID:         "earnings-IBM-20250122",
+		Date:        time.Date(2025, time.January, 22, 18, 0, 0, 0, time.FixedZone("EST", -5*3600)),
+		Summary:     "IBM earnings",
+		Description: "Fiscal period ending 2024-12-31\nEPS estimate 3.75, USD",
+	}}
+	now := time.Date(2025, time.January, 2, 9, 30, 0, 0, time.FixedZone("EST", -5*3600))
+
+	var buf bytes.Buffer
+	if err := WriteICS(&buf, "Earnings; watchlist", events, now); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	if strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\n") {
+		t.Error("WriteICS() has bare LF line endings, want CRLF")
+	}
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"VERSION:2.0\r\n",
+		`X-WR-CALNAME:Earnings\; watchlist` + "\r\n",
+		"UID:earnings-IBM-20250122@stock\r\n",
+		"DTSTAMP:20250102T143000Z\r\n",
+		"DTSTART;VALUE=DATE:20250122\r\n", // The local date, not the UTC one
+		"DTEND;VALUE=DATE:20250123\r\n",
+		`DESCRIPTION:Fiscal period ending 2024-12-31\nEPS estimate 3.75\, USD` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteICS() is missing %q in\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "BEGIN:VEVENT") != 1 || strings.Count(out, "END:VEVENT") != 1 {
+		t.Errorf("WriteICS() = %q, want one event", out)
+	}
+}
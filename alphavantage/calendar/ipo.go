@@ -0,0 +1,98 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"stock/common"
+)
+
+// IPOEvent is an initial public offering expected in the next three months
+type IPOEvent struct {
+	Symbol         string         `json:"symbol"`
+	Name           string         `json:"name"`
+	IPODate        time.Time      `json:"ipoDate"`
+	PriceRangeLow  common.Decimal `json:"priceRangeLow"`
+	PriceRangeHigh common.Decimal `json:"priceRangeHigh"`
+	Currency       string         `json:"currency"`
+	Exchange       string         `json:"exchange"`
+}
+
+// GetIPOCalendar fetches the IPOs expected in the next three months, which
+// Alpha Vantage only serves as CSV
+func GetIPOCalendar(ctx context.Context, client *common.Client) ([]IPOEvent, error) {
+	// Building query parameters
+	queryParams := map[string]string{
+		"function": "IPO_CALENDAR",
+	}
+
+	records, err := client.GetCSV(ctx, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]IPOEvent, 0, len(records))
+	for _, record := range records {
+		if record["symbol"] == "" {
+			continue
+		}
+
+		// One malformed row should not take down the whole feed
+		event, err := parseIPOEvent(record)
+		if err != nil {
+			log.Printf("Warning: skipping IPO calendar row: %v", err)
+			continue
+		}
+		events = append(events, event)
+	}
+
+	sortEvents(events, func(i int) (time.Time, string) { return events[i].IPODate, events[i].Symbol })
+	return events, nil
+}
+
+// parseIPOEvent decodes one IPO_CALENDAR row
+func parseIPOEvent(record common.CSVRecord) (IPOEvent, error) {
+	event := IPOEvent{
+		Symbol:   record["symbol"],
+		Name:     record["name"],
+		Currency: record["currency"],
+		Exchange: record["exchange"],
+	}
+	ipoDate, err := common.ParseDate(record["ipodate"])
+	if err != nil || ipoDate == nil {
+		return event, fmt.Errorf("IPO calendar %s: IPO date %q", event.Symbol, record["ipodate"])
+	}
+	event.IPODate = *ipoDate
+	if event.PriceRangeLow, err = common.ParseDecimal(record["pricerangelow"]); err != nil {
+		return event, fmt.Errorf("IPO calendar %s: price range low: %w", event.Symbol, err)
+	}
+	if event.PriceRangeHigh, err = common.ParseDecimal(record["pricerangehigh"]); err != nil {
+		return event, fmt.Errorf("IPO calendar %s: price range high: %w", event.Symbol, err)
+	}
+	return event, nil
+}
+
+// Event returns the offering as an all-day calendar event
+func (e IPOEvent) Event() Event {
+	var description []string
+	if e.Name != "" {
+		description = append(description, e.Name)
+	}
+	if e.Exchange != "" {
+		description = append(description, "Exchange "+e.Exchange)
+	}
+	// Alpha Vantage reports 0 for offerings without a price range yet
+	if e.PriceRangeLow.Sign() > 0 || e.PriceRangeHigh.Sign() > 0 {
+		description = append(description, strings.TrimSpace(fmt.Sprintf("Price range %s-%s %s", e.PriceRangeLow, e.PriceRangeHigh, e.Currency)))
+	}
+
+	return Event{
+		UID:         fmt.Sprintf("ipo-%s-%s", e.Symbol, e.IPODate.Format(icsDateLayout)),
+		Date:        e.IPODate,
+		Summary:     fmt.Sprintf("%s IPO", e.Symbol),
+		Description: strings.Join(description, "\n"),
+	}
+}
@@ -0,0 +1,51 @@
+package calendar
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGetIPOCalendar(t *testing.T) {
+	const body = "symbol,name,ipoDate,priceRangeLow,priceRangeHigh,currency,exchange\r\n" +
+		"NEWB,Newco B,2025-02-10,0,0,USD,NYSE\r\n" +
+		"NEWA,Newco A,2025-02-10,14.00,16.00,USD,NASDAQ\r\n" +
+		"BAD1,Bad date,next week,14.00,16.00,USD,NASDAQ\r\n" +
+		"BAD2,Bad price,2025-02-11,fourteen,16.00,USD,NASDAQ\r\n" +
+		"EARLY,Early Inc,2025-02-03,9.50,10.50,USD,NYSE\r\n"
+
+	var query string
+	events, err := GetIPOCalendar(context.Background(), csvClient(t, body, &query))
+	if err != nil {
+		t.Fatalf("GetIPOCalendar() error = %v, want malformed rows skipped", err)
+	}
+	if !strings.Contains(query, "function=IPO_CALENDAR") {
+		t.Errorf("query = %q, want function=IPO_CALENDAR", query)
+	}
+
+	var symbols []string
+	for _, event := range events {
+		symbols = append(symbols, event.Symbol)
+	}
+	if got := strings.Join(symbols, ","); got != "EARLY,NEWA,NEWB" {
+		t.Fatalf("symbols = %s, want EARLY,NEWA,NEWB", got)
+	}
+	if e := events[1]; e.PriceRangeLow.String() != "14.00" || e.PriceRangeHigh.String() != "16.00" || e.Exchange != "NASDAQ" {
+		t.Errorf("NEWA = %+v", e)
+	}
+}
+
+func TestIPOEvent(t *testing.T) {
+	events, err := GetIPOCalendar(context.Background(), csvClient(t, "symbol,name,ipoDate,priceRangeLow,priceRangeHigh,currency,exchange\nNEWA,Newco A,2025-02-10,14.00,16.00,USD,NASDAQ\nNEWB,Newco B,2025-02-10,0,0,USD,NYSE\n", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e := events[0].Event(); e.UID != "ipo-NEWA-20250210" || e.Summary != "NEWA IPO" || e.Description != "Newco A\nExchange NASDAQ\nPrice range 14.00-16.00 USD" {
+		t.Errorf("NEWA Event() = %+v", e)
+	}
+	// Offerings without a price range leave it out
+	if e := events[1].Event(); e.Description != "Newco B\nExchange NYSE" {
+		t.Errorf("NEWB Event() description = %q", e.Description)
+	}
+}
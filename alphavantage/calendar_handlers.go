@@ -0,0 +1,223 @@
+package alphavantage
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"stock/alphavantage/calendar"
+	"stock/common"
+	"stock/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EarningsCalendarResponse defines the response format for upcoming earnings
+// @Description Earnings calendar response data structure
+type EarningsCalendarResponse struct {
+	Version   string                   `json:"version"`
+	Timestamp string                   `json:"timestamp"`
+	Symbol    string                   `json:"symbol,omitempty"`
+	Horizon   string                   `json:"horizon"`
+	Watchlist bool                     `json:"watchlist"` // Only watchlist symbols are listed
+	Cache     string                   `json:"cache,omitempty"`
+	Data      []calendar.EarningsEvent `json:"data"`
+}
+
+// IPOCalendarResponse defines the response format for upcoming IPOs
+// @Description IPO calendar response data structure
+type IPOCalendarResponse struct {
+	Version   string              `json:"version"`
+	Timestamp string              `json:"timestamp"`
+	Watchlist bool                `json:"watchlist"` // Only watchlist symbols are listed
+	Cache     string              `json:"cache,omitempty"`
+	Data      []calendar.IPOEvent `json:"data"`
+}
+
+// outputICS is the iCalendar encoding offered by the calendar endpoints
+const outputICS = "ics"
+
+// mimeICS is the iCalendar media type
+const mimeICS = "text/calendar"
+
+// GetEarningsCalendar handles requests for upcoming earnings reports
+// @Summary Get upcoming earnings reports
+// @Description Returns the earnings reports expected within the horizon from EARNINGS_CALENDAR, ordered by report date. Request text/calendar or format=ics to subscribe from a calendar app.
+// @Tags calendar
+// @Produce json,text/csv,application/x-ndjson,text/calendar
+// @Param symbol query string false "Stock symbol (e.g., AAPL), all companies when omitted"
+// @Param horizon query string false "How far ahead to look" Enums(3month, 6month, 12month) default(3month)
+// @Param watchlist query boolean false "Only list symbols of the configured WATCHLIST" default(false)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson, ics) default(json)
+// @Success 200 {object} EarningsCalendarResponse "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/calendar/earnings [get]
+func (h *Handler) GetEarningsCalendar(c *gin.Context) {
+	params := calendar.EarningsCalendarParams{
+		Symbol:  strings.ToUpper(strings.TrimSpace(c.Query("symbol"))),
+		Horizon: c.DefaultQuery("horizon", calendar.Horizon3Months),
+	}
+	if !calendar.ValidHorizon(params.Horizon) {
+		respondError(c, fmt.Errorf("%w: horizon must be %s, %s or %s", common.ErrInvalidParameter,
+			calendar.Horizon3Months, calendar.Horizon6Months, calendar.Horizon12Months))
+		return
+	}
+
+	watchlist, filter, err := parseWatchlist(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	format, err := calendarFormat(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	ctx, cache := requestContext(c)
+
+	events, err := h.client.GetEarningsCalendar(ctx, params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if filter {
+		events = watchlist.FilterEarnings(events)
+	}
+
+	cacheStatus := setCacheHeader(c, cache)
+	if format == outputICS {
+		icsEvents := make([]calendar.Event, len(events))
+		for i, event := range events {
+			icsEvents[i] = event.Event()
+		}
+		writeICS(c, "Earnings", icsEvents)
+		return
+	}
+
+	response := EarningsCalendarResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Symbol:    params.Symbol,
+		Horizon:   params.Horizon,
+		Watchlist: filter,
+		Cache:     cacheStatus,
+		Data:      events,
+	}
+
+	respond(c, response, events)
+}
+
+// GetIPOCalendar handles requests for upcoming IPOs
+// @Summary Get upcoming IPOs
+// @Description Returns the initial public offerings expected in the next three months from IPO_CALENDAR, ordered by IPO date. Request text/calendar or format=ics to subscribe from a calendar app.
+// @Tags calendar
+// @Produce json,text/csv,application/x-ndjson,text/calendar
+// @Param watchlist query boolean false "Only list symbols of the configured WATCHLIST" default(false)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson, ics) default(json)
+// @Success 200 {object} IPOCalendarResponse "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/calendar/ipo [get]
+func (h *Handler) GetIPOCalendar(c *gin.Context) {
+	watchlist, filter, err := parseWatchlist(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	format, err := calendarFormat(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	ctx, cache := requestContext(c)
+
+	events, err := h.client.GetIPOCalendar(ctx)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if filter {
+		events = watchlist.FilterIPOs(events)
+	}
+
+	cacheStatus := setCacheHeader(c, cache)
+	if format == outputICS {
+		icsEvents := make([]calendar.Event, len(events))
+		for i, event := range events {
+			icsEvents[i] = event.Event()
+		}
+		writeICS(c, "IPOs", icsEvents)
+		return
+	}
+
+	response := IPOCalendarResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Watchlist: filter,
+		Cache:     cacheStatus,
+		Data:      events,
+	}
+
+	respond(c, response, events)
+}
+
+// parseWatchlist returns the configured watchlist and whether watchlist=true asked to filter by it
+func parseWatchlist(c *gin.Context) (calendar.Watchlist, bool, error) {
+	filter, err := strconv.ParseBool(c.DefaultQuery("watchlist", "false"))
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: watchlist must be true or false", common.ErrInvalidParameter)
+	}
+	if !filter {
+		return nil, false, nil
+	}
+
+	watchlist := calendar.NewWatchlist(config.GetConfig().Watchlist)
+	if len(watchlist) == 0 {
+		return nil, false, fmt.Errorf("%w: no WATCHLIST is configured", common.ErrInvalidParameter)
+	}
+	return watchlist, true, nil
+}
+
+// calendarFormat returns the requested encoding, which may also be iCalendar
+func calendarFormat(c *gin.Context) (string, error) {
+	switch c.Query("format") {
+	case outputICS:
+		return outputICS, nil
+	case "":
+		if c.NegotiateFormat(gin.MIMEJSON, mimeCSV, mimeNDJSON, mimeICS) == mimeICS {
+			return outputICS, nil
+		}
+	}
+	format, err := outputFormat(c)
+	if err != nil {
+		return "", fmt.Errorf("%w: format must be %s, %s, %s or %s", common.ErrInvalidParameter, outputJSON, outputCSV, outputNDJSON, outputICS)
+	}
+	return format, nil
+}
+
+// writeICS writes events as an iCalendar feed. The status is already sent
+// when writing fails, so the error is only logged.
+func writeICS(c *gin.Context, name string, events []calendar.Event) {
+	c.Header("Content-Type", mimeICS+"; charset=utf-8")
+	c.Status(http.StatusOK)
+	if err := calendar.WriteICS(c.Writer, name, events, time.Now()); err != nil {
+		log.Printf("Warning: writing %s calendar: %v", name, err)
+	}
+}
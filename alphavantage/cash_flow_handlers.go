@@ -1,7 +1,6 @@
 package alphavantage
 
 import (
-	"net/http"
 	"stock/alphavantage/fundamental"
 	"time"
 
@@ -11,22 +10,32 @@ import (
 // CashFlowResponse defines the response format for cash flow data
 // @Description Cash flow response data structure
 type CashFlowResponse struct {
-	Version   string                        `json:"version"`
-	Timestamp string                        `json:"timestamp"`
-	Symbol    string                        `json:"symbol"`
-	Data      *fundamental.CashFlowResponse `json:"data"`
+	Version   string      `json:"version"`
+	Timestamp string      `json:"timestamp"`
+	Symbol    string      `json:"symbol"`
+	Cache     string      `json:"cache,omitempty"`
+	Data      interface{} `json:"data"` // *fundamental.ParsedCashFlow, or *fundamental.CashFlowResponse when raw=true
 }
 
 // GetCashFlow handles requests for cash flow data
 // @Summary Get cash flow data for a specific symbol
 // @Description Returns the cash flow data for the specified stock symbol
 // @Tags fundamental
-// @Produce json
+// @Produce json,text/csv,application/x-ndjson
 // @Param symbol path string true "Stock symbol (e.g., AAPL, MSFT)"
-// @Success 200 {object} CashFlowResponse "Successful operation"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Param raw query boolean false "Serve the original Alpha Vantage strings instead of typed values" default(false)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} CashFlowResponse{data=fundamental.ParsedCashFlow} "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
 // @Router /v1/fundamental/cash-flow/{symbol} [get]
-func GetCashFlow(c *gin.Context) {
+func (h *Handler) GetCashFlow(c *gin.Context) {
 	symbol := c.Param("symbol")
 
 	// Create params for the fundamental library
@@ -34,22 +43,35 @@ func GetCashFlow(c *gin.Context) {
 		Symbol: symbol,
 	}
 
+	ctx, cache := requestContext(c)
+
 	// Get cash flow data
-	data, err := fundamental.GetCashFlow(params)
+	data, err := h.client.GetCashFlow(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
+	// Serve typed values unless the caller asked for the original strings
+	var body interface{} = data
+	rows := statementRows(data.AnnualReports, data.QuarterlyReports)
+	if !rawRequested(c) {
+		parsed, err := data.Parse()
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		body, rows = parsed, statementRows(parsed.AnnualReports, parsed.QuarterlyReports)
+	}
+
 	// Create response with versioning
 	response := CashFlowResponse{
 		Version:   "1.0", // TODO: Replace with config value once GetConfig() is implemented
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		Symbol:    symbol,
-		Data:      data,
+		Cache:     setCacheHeader(c, cache),
+		Data:      body,
 	}
 
-	c.JSON(http.StatusOK, response)
+	respond(c, response, rows)
 }
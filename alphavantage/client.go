@@ -0,0 +1,251 @@
+package alphavantage
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"stock/alphavantage/analytics"
+	"stock/alphavantage/calendar"
+	"stock/alphavantage/fundamental"
+	"stock/alphavantage/market"
+	"stock/alphavantage/news"
+	"stock/alphavantage/options"
+	"stock/alphavantage/search"
+	"stock/alphavantage/timeseries"
+	"stock/common"
+	"stock/config"
+)
+
+// Client fetches data from the Alpha Vantage API
+type Client struct {
+	api   *common.Client
+	index *search.Index      // Local symbol index, refreshed by RunSymbolIndex
+	rates options.RateSource // Risk-free rate of option valuations
+}
+
+// ClientOption customizes a Client created by NewClient
+type ClientOption func(*common.Client)
+
+// WithBaseURL points the client at a different upstream, such as a local fake server
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *common.Client) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithHTTPClient replaces the underlying http.Client
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *common.Client) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithTransport replaces the RoundTripper used for upstream calls
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *common.Client) {
+		c.HTTPClient.Transport = transport
+	}
+}
+
+// WithRateLimiter replaces the limiter built from the config, nil disables throttling
+func WithRateLimiter(limiter *common.RateLimiter) ClientOption {
+	return func(c *common.Client) {
+		c.Limiter = limiter
+	}
+}
+
+// WithRetryPolicy replaces the retry policy built from the config
+func WithRetryPolicy(policy common.RetryPolicy) ClientOption {
+	return func(c *common.Client) {
+		c.Retry = policy
+	}
+}
+
+// WithCache replaces the cache built from the config, nil disables caching
+func WithCache(cache common.Cache) ClientOption {
+	return func(c *common.Client) {
+		c.Cache = cache
+	}
+}
+
+// NewClient creates a client from the application config
+func NewClient(cfg *config.Config, opts ...ClientOption) *Client {
+	api := &common.Client{
+		BaseURL:    cfg.AlphaVantageBaseURL,
+		APIKey:     cfg.AlphaVantageAPIKey,
+		HTTPClient: common.NewHTTPClient(cfg.UpstreamTimeout, nil),
+		Limiter:    common.NewRateLimiter(cfg.RateLimitPerMinute, cfg.RateLimitPerDay, cfg.RateLimitMaxWait),
+		Retry: common.RetryPolicy{
+			MaxAttempts: cfg.RetryMaxAttempts,
+			BaseDelay:   cfg.RetryBaseDelay,
+			MaxDelay:    cfg.RetryMaxDelay,
+		},
+		Cache:    newCache(cfg),
+		CacheTTL: common.CalendarTTL(market.NYSE.NextClose),
+	}
+
+	for _, opt := range opts {
+		opt(api)
+	}
+
+	return &Client{api: api, index: search.NewIndex(api, cfg.SymbolIndexRefresh), rates: newRateSource(cfg)}
+}
+
+// newRateSource builds the risk-free rate source described by the config
+func newRateSource(cfg *config.Config) options.RateSource {
+	switch cfg.RiskFreeRateSource {
+	case "treasury":
+		return options.TreasuryRate{Maturity: cfg.RiskFreeRateMaturity}
+	case "fixed", "":
+	default:
+		log.Printf("Warning: unknown RISK_FREE_RATE_SOURCE %q, using RISK_FREE_RATE", cfg.RiskFreeRateSource)
+	}
+	return options.FixedRate(cfg.RiskFreeRate)
+}
+
+// newCache builds the response cache described by the config
+func newCache(cfg *config.Config) common.Cache {
+	if cfg.CacheMaxBytes <= 0 {
+		return nil
+	}
+
+	memory := common.NewLRUCache(cfg.CacheMaxBytes)
+	if cfg.CacheDir == "" {
+		return memory
+	}
+
+	disk, err := common.NewDiskCache(cfg.CacheDir, cfg.CacheDiskMaxBytes)
+	if err != nil {
+		log.Printf("Warning: disk cache disabled: %v", err)
+		return memory
+	}
+	return &common.TieredCache{Memory: memory, Disk: disk}
+}
+
+// Quota returns the remaining upstream call budget
+func (c *Client) Quota() common.Quota {
+	return c.api.Limiter.Quota()
+}
+
+// Stats returns the upstream request counters
+func (c *Client) Stats() common.Stats {
+	return c.api.Stats()
+}
+
+// GetBalanceSheet fetches balance sheet data for a symbol
+func (c *Client) GetBalanceSheet(ctx context.Context, params fundamental.BalanceSheetParams) (*fundamental.BalanceSheetResponse, error) {
+	return fundamental.GetBalanceSheet(ctx, c.api, params)
+}
+
+// GetCashFlow fetches cash flow data for a symbol
+func (c *Client) GetCashFlow(ctx context.Context, params fundamental.CashFlowParams) (*fundamental.CashFlowResponse, error) {
+	return fundamental.GetCashFlow(ctx, c.api, params)
+}
+
+// GetIncomeStatement fetches income statement data for a symbol
+func (c *Client) GetIncomeStatement(ctx context.Context, params fundamental.IncomeStatementParams) (*fundamental.IncomeStatementResponse, error) {
+	return fundamental.GetIncomeStatement(ctx, c.api, params)
+}
+
+// GetEarnings fetches annual and quarterly EPS history for a symbol
+func (c *Client) GetEarnings(ctx context.Context, params fundamental.EarningsParams) (*fundamental.EarningsResponse, error) {
+	return fundamental.GetEarnings(ctx, c.api, params)
+}
+
+// GetDividends fetches the dividend history for a symbol
+func (c *Client) GetDividends(ctx context.Context, params fundamental.DividendsParams) (*fundamental.DividendsResponse, error) {
+	return fundamental.GetDividends(ctx, c.api, params)
+}
+
+// GetSplits fetches the split history for a symbol
+func (c *Client) GetSplits(ctx context.Context, params fundamental.SplitsParams) (*fundamental.SplitsResponse, error) {
+	return fundamental.GetSplits(ctx, c.api, params)
+}
+
+// GetCompanyOverview fetches company overview data for a symbol
+func (c *Client) GetCompanyOverview(ctx context.Context, params fundamental.CompanyOverviewParams) (*fundamental.CompanyOverviewResponse, error) {
+	return fundamental.GetCompanyOverview(ctx, c.api, params)
+}
+
+// GetETFProfile fetches the profile, sector weights and holdings of an ETF
+func (c *Client) GetETFProfile(ctx context.Context, params fundamental.ETFProfileParams) (*fundamental.ETFProfileResponse, error) {
+	return fundamental.GetETFProfile(ctx, c.api, params)
+}
+
+// GetETFLookthrough fetches an ETF's holdings and sums their weights by the sector and country of each issuer
+func (c *Client) GetETFLookthrough(ctx context.Context, params fundamental.LookthroughParams) (*fundamental.Lookthrough, error) {
+	return fundamental.GetLookthrough(ctx, c.api, params)
+}
+
+// GetTimeSeries fetches time series data for a symbol as chronologically ordered bars
+func (c *Client) GetTimeSeries(ctx context.Context, params timeseries.TimeSeriesParams) ([]timeseries.Bar, error) {
+	return timeseries.GetTimeSeries(ctx, c.api, params)
+}
+
+// GetRawTimeSeries fetches time series data for a symbol in the upstream shape
+func (c *Client) GetRawTimeSeries(ctx context.Context, params timeseries.TimeSeriesParams) (*timeseries.TimeSeriesResponse, error) {
+	return timeseries.GetRawTimeSeries(ctx, c.api, params)
+}
+
+// GetIntradayHistory fetches intraday bars for every month from from through to (YYYY-MM)
+func (c *Client) GetIntradayHistory(ctx context.Context, params timeseries.TimeSeriesParams, from, to string) (*timeseries.StitchedSeries, error) {
+	return timeseries.GetIntradayHistory(ctx, c.api, params, from, to)
+}
+
+// GetAnalyticsDataset fetches the daily prices of several symbols aligned on common dates
+func (c *Client) GetAnalyticsDataset(ctx context.Context, params analytics.DatasetParams) (*analytics.Dataset, error) {
+	return analytics.GetDataset(ctx, c.api, params)
+}
+
+// GetMarketStatus fetches the open or closed state of global markets
+func (c *Client) GetMarketStatus(ctx context.Context) (*market.MarketStatusResponse, error) {
+	return market.GetMarketStatus(ctx, c.api)
+}
+
+// GetEarningsCalendar fetches the earnings reports expected within a horizon
+func (c *Client) GetEarningsCalendar(ctx context.Context, params calendar.EarningsCalendarParams) ([]calendar.EarningsEvent, error) {
+	return calendar.GetEarningsCalendar(ctx, c.api, params)
+}
+
+// GetIPOCalendar fetches the IPOs expected in the next three months
+func (c *Client) GetIPOCalendar(ctx context.Context) ([]calendar.IPOEvent, error) {
+	return calendar.GetIPOCalendar(ctx, c.api)
+}
+
+// GetOptionsChain fetches the options chain of a symbol on a date, valued against the underlying's close
+func (c *Client) GetOptionsChain(ctx context.Context, params options.ChainParams) (*options.Chain, error) {
+	if params.Rates == nil {
+		params.Rates = c.rates
+	}
+	return options.GetChain(ctx, c.api, params)
+}
+
+// GetVolatilitySurface fetches the options chain of a symbol on a date and builds its implied volatility surface
+func (c *Client) GetVolatilitySurface(ctx context.Context, params options.SurfaceParams) (*options.Surface, error) {
+	if params.Rates == nil {
+		params.Rates = c.rates
+	}
+	return options.GetSurface(ctx, c.api, params)
+}
+
+// GetNewsAndSentiment fetches news articles and their sentiment
+func (c *Client) GetNewsAndSentiment(ctx context.Context, params news.GetNewsAndSentimentParams) (*news.GetNewsAndSentimentResponse, error) {
+	return news.GetNewsAndSentiment(ctx, c.api, params)
+}
+
+// SearchSymbols finds symbols matching keywords upstream
+func (c *Client) SearchSymbols(ctx context.Context, params search.SymbolSearchParams) ([]search.Match, error) {
+	return search.SearchSymbols(ctx, c.api, params)
+}
+
+// RunSymbolIndex refreshes the local symbol index on the configured
+// interval until ctx is done
+func (c *Client) RunSymbolIndex(ctx context.Context) {
+	c.index.Run(ctx)
+}
+
+// SearchIndex finds symbols in the local index without calling upstream
+func (c *Client) SearchIndex(query string, limit int) ([]search.Match, error) {
+	return c.index.Search(query, limit)
+}
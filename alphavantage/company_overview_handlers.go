@@ -1,7 +1,6 @@
 package alphavantage
 
 import (
-	"net/http"
 	"stock/alphavantage/fundamental"
 	"time"
 
@@ -11,22 +10,32 @@ import (
 // CompanyOverviewResponse defines the response format for company overview data
 // @Description Company overview response data structure
 type CompanyOverviewResponse struct {
-	Version   string                               `json:"version"`
-	Timestamp string                               `json:"timestamp"`
-	Symbol    string                               `json:"symbol"`
-	Data      *fundamental.CompanyOverviewResponse `json:"data"`
+	Version   string      `json:"version"`
+	Timestamp string      `json:"timestamp"`
+	Symbol    string      `json:"symbol"`
+	Cache     string      `json:"cache,omitempty"`
+	Data      interface{} `json:"data"` // *fundamental.ParsedCompanyOverview, or *fundamental.CompanyOverviewResponse when raw=true
 }
 
 // GetCompanyOverview handles requests for company overview data
 // @Summary Get company overview data for a specific symbol
 // @Description Returns the company overview data for the specified stock symbol (sector, industry, PE ratio, EBITDA, and more)
 // @Tags fundamental
-// @Produce json
+// @Produce json,text/csv,application/x-ndjson
 // @Param symbol path string true "Stock symbol (e.g., AAPL, MSFT)"
-// @Success 200 {object} CompanyOverviewResponse "Successful operation"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Param raw query boolean false "Serve the original Alpha Vantage strings instead of typed values" default(false)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} CompanyOverviewResponse{data=fundamental.ParsedCompanyOverview} "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
 // @Router /v1/fundamental/company-overview/{symbol} [get]
-func GetCompanyOverview(c *gin.Context) {
+func (h *Handler) GetCompanyOverview(c *gin.Context) {
 	symbol := c.Param("symbol")
 
 	// Create params for the fundamental library
@@ -34,22 +43,32 @@ func GetCompanyOverview(c *gin.Context) {
 		Symbol: symbol,
 	}
 
+	ctx, cache := requestContext(c)
+
 	// Get company overview data
-	data, err := fundamental.GetCompanyOverview(params)
+	data, err := h.client.GetCompanyOverview(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
+	// Serve typed values unless the caller asked for the original strings
+	var body interface{} = data
+	if !rawRequested(c) {
+		if body, err = data.Parse(); err != nil {
+			respondError(c, err)
+			return
+		}
+	}
+
 	// Create response with versioning
 	response := CompanyOverviewResponse{
 		Version:   "1.0", // TODO: Replace with config value once GetConfig() is implemented
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		Symbol:    symbol,
-		Data:      data,
+		Cache:     setCacheHeader(c, cache),
+		Data:      body,
 	}
 
-	c.JSON(http.StatusOK, response)
+	respond(c, response, []interface{}{body})
 }
@@ -0,0 +1,148 @@
+package alphavantage
+
+import (
+	"time"
+
+	"stock/alphavantage/fundamental"
+	"stock/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DividendsResponse defines the response format for dividend history
+// @Description Dividend history response data structure
+type DividendsResponse struct {
+	Version   string      `json:"version"`
+	Timestamp string      `json:"timestamp"`
+	Symbol    string      `json:"symbol"`
+	Cache     string      `json:"cache,omitempty"`
+	Data      interface{} `json:"data"` // *fundamental.ParsedDividends, or *fundamental.DividendsResponse when raw=true
+}
+
+// SplitsResponse defines the response format for split history
+// @Description Split history response data structure
+type SplitsResponse struct {
+	Version   string      `json:"version"`
+	Timestamp string      `json:"timestamp"`
+	Symbol    string      `json:"symbol"`
+	Cache     string      `json:"cache,omitempty"`
+	Data      interface{} `json:"data"` // *fundamental.ParsedSplits, or *fundamental.SplitsResponse when raw=true
+}
+
+// GetDividends handles requests for dividend history
+// @Summary Get dividend history for a specific symbol
+// @Description Returns every dividend with its ex-dividend, declaration, record and payment dates, the annual totals, 1/3/5/10 year growth rates and the streak of consecutive yearly increases. CSV and NDJSON output list the dividends.
+// @Tags fundamental
+// @Produce json,text/csv,application/x-ndjson
+// @Param symbol path string true "Stock symbol (e.g., AAPL, MSFT)"
+// @Param raw query boolean false "Serve the original Alpha Vantage strings instead of typed values" default(false)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} DividendsResponse{data=fundamental.ParsedDividends} "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/fundamental/dividends/{symbol} [get]
+func (h *Handler) GetDividends(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	// Create params for the fundamental library
+	params := fundamental.DividendsParams{
+		Symbol: symbol,
+	}
+
+	ctx, cache := requestContext(c)
+
+	// Get dividend data
+	data, err := h.client.GetDividends(ctx, params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Serve typed values unless the caller asked for the original strings
+	var body interface{} = data
+	var rows interface{} = data.Data
+	if !rawRequested(c) {
+		parsed, err := data.Parse()
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		body, rows = parsed, parsed.Data
+	}
+
+	// Create response with versioning
+	response := DividendsResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Symbol:    symbol,
+		Cache:     setCacheHeader(c, cache),
+		Data:      body,
+	}
+
+	respond(c, response, rows)
+}
+
+// GetSplits handles requests for split history
+// @Summary Get split history for a specific symbol
+// @Description Returns every stock split with its effective date and split factor
+// @Tags fundamental
+// @Produce json,text/csv,application/x-ndjson
+// @Param symbol path string true "Stock symbol (e.g., AAPL, MSFT)"
+// @Param raw query boolean false "Serve the original Alpha Vantage strings instead of typed values" default(false)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} SplitsResponse{data=fundamental.ParsedSplits} "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/fundamental/splits/{symbol} [get]
+func (h *Handler) GetSplits(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	// Create params for the fundamental library
+	params := fundamental.SplitsParams{
+		Symbol: symbol,
+	}
+
+	ctx, cache := requestContext(c)
+
+	// Get split data
+	data, err := h.client.GetSplits(ctx, params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Serve typed values unless the caller asked for the original strings
+	var body interface{} = data
+	var rows interface{} = data.Data
+	if !rawRequested(c) {
+		parsed, err := data.Parse()
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		body, rows = parsed, parsed.Data
+	}
+
+	// Create response with versioning
+	response := SplitsResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Symbol:    symbol,
+		Cache:     setCacheHeader(c, cache),
+		Data:      body,
+	}
+
+	respond(c, response, rows)
+}
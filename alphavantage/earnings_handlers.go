@@ -0,0 +1,80 @@
+package alphavantage
+
+import (
+	"time"
+
+	"stock/alphavantage/fundamental"
+	"stock/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EarningsResponse defines the response format for earnings data
+// @Description Earnings response data structure
+type EarningsResponse struct {
+	Version   string      `json:"version"`
+	Timestamp string      `json:"timestamp"`
+	Symbol    string      `json:"symbol"`
+	Cache     string      `json:"cache,omitempty"`
+	Data      interface{} `json:"data"` // *fundamental.ParsedEarnings, or *fundamental.EarningsResponse when raw=true
+}
+
+// GetEarnings handles requests for earnings data
+// @Summary Get EPS history for a specific symbol
+// @Description Returns annual and quarterly reported and estimated EPS with the trailing four quarter EPS and the current beat or miss streak. CSV and NDJSON output list the quarterly earnings.
+// @Tags fundamental
+// @Produce json,text/csv,application/x-ndjson
+// @Param symbol path string true "Stock symbol (e.g., AAPL, MSFT)"
+// @Param raw query boolean false "Serve the original Alpha Vantage strings instead of typed values" default(false)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} EarningsResponse{data=fundamental.ParsedEarnings} "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/fundamental/earnings/{symbol} [get]
+func (h *Handler) GetEarnings(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	// Create params for the fundamental library
+	params := fundamental.EarningsParams{
+		Symbol: symbol,
+	}
+
+	ctx, cache := requestContext(c)
+
+	// Get earnings data
+	data, err := h.client.GetEarnings(ctx, params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Serve typed values unless the caller asked for the original strings.
+	// Annual figures only repeat the quarters, so rows list the quarters.
+	var body interface{} = data
+	var rows interface{} = data.QuarterlyEarnings
+	if !rawRequested(c) {
+		parsed, err := data.Parse()
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		body, rows = parsed, parsed.QuarterlyEarnings
+	}
+
+	// Create response with versioning
+	response := EarningsResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Symbol:    symbol,
+		Cache:     setCacheHeader(c, cache),
+		Data:      body,
+	}
+
+	respond(c, response, rows)
+}
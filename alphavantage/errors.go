@@ -0,0 +1,63 @@
+package alphavantage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"stock/alphavantage/options"
+	"stock/alphavantage/search"
+	"stock/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorResponse defines the error body returned by every endpoint
+// @Description Error response data structure
+type ErrorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+// Error codes reported in ErrorResponse.Code
+const (
+	ErrCodeRateLimited      = "rate_limited"
+	ErrCodeInvalidSymbol    = "invalid_symbol"
+	ErrCodePremiumRequired  = "premium_required"
+	ErrCodeInvalidParameter = "invalid_parameter"
+	ErrCodeUpstream         = "upstream_error"
+	ErrCodeTimeout          = "upstream_timeout"
+	ErrCodeInternal         = "internal_error"
+	ErrCodeIndexNotReady    = "index_not_ready"
+	ErrCodeContractNotFound = "contract_not_found"
+)
+
+// respondError writes err to the client using the status code matching its kind
+func respondError(c *gin.Context, err error) {
+	status, code := http.StatusInternalServerError, ErrCodeInternal
+
+	var statusErr *common.StatusError
+	switch {
+	case errors.Is(err, common.ErrRateLimited):
+		status, code = http.StatusTooManyRequests, ErrCodeRateLimited
+	case errors.Is(err, common.ErrInvalidSymbol):
+		status, code = http.StatusNotFound, ErrCodeInvalidSymbol
+	case errors.Is(err, common.ErrPremiumRequired):
+		status, code = http.StatusPaymentRequired, ErrCodePremiumRequired
+	case errors.Is(err, common.ErrInvalidParameter):
+		status, code = http.StatusBadRequest, ErrCodeInvalidParameter
+	case errors.As(err, &statusErr):
+		status, code = http.StatusBadGateway, ErrCodeUpstream
+	case errors.Is(err, context.DeadlineExceeded):
+		status, code = http.StatusGatewayTimeout, ErrCodeTimeout
+	case errors.Is(err, search.ErrIndexNotReady):
+		status, code = http.StatusServiceUnavailable, ErrCodeIndexNotReady
+	case errors.Is(err, options.ErrContractNotFound):
+		status, code = http.StatusNotFound, ErrCodeContractNotFound
+	}
+
+	c.JSON(status, ErrorResponse{
+		Error: err.Error(),
+		Code:  code,
+	})
+}
@@ -0,0 +1,58 @@
+package alphavantage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"stock/alphavantage/options"
+	"stock/alphavantage/search"
+	"stock/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRespondError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"rate limited", &common.APIError{Kind: common.ErrRateLimited, Function: "OVERVIEW"}, http.StatusTooManyRequests, ErrCodeRateLimited},
+		{"local quota", common.ErrQuotaExceeded, http.StatusTooManyRequests, ErrCodeRateLimited},
+		{"invalid symbol", &common.APIError{Kind: common.ErrInvalidSymbol}, http.StatusNotFound, ErrCodeInvalidSymbol},
+		{"premium", &common.APIError{Kind: common.ErrPremiumRequired}, http.StatusPaymentRequired, ErrCodePremiumRequired},
+		{"invalid parameter", fmt.Errorf("%w: limit must be positive", common.ErrInvalidParameter), http.StatusBadRequest, ErrCodeInvalidParameter},
+		{"upstream status", &common.StatusError{StatusCode: http.StatusServiceUnavailable, Function: "OVERVIEW"}, http.StatusBadGateway, ErrCodeUpstream},
+		{"timeout", fmt.Errorf("fetch: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, ErrCodeTimeout},
+		{"index not ready", search.ErrIndexNotReady, http.StatusServiceUnavailable, ErrCodeIndexNotReady},
+		{"contract not found", options.ErrContractNotFound, http.StatusNotFound, ErrCodeContractNotFound},
+		{"anything else", errors.New("boom"), http.StatusInternalServerError, ErrCodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			respondError(c, tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var body ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body %q is not an ErrorResponse: %v", w.Body.String(), err)
+			}
+			if body.Code != tt.wantCode || body.Error != tt.err.Error() {
+				t.Errorf("body = %+v, want code %s and error %q", body, tt.wantCode, tt.err.Error())
+			}
+		})
+	}
+}
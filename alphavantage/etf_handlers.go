@@ -0,0 +1,141 @@
+package alphavantage
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"stock/alphavantage/fundamental"
+	"stock/common"
+	"stock/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Look-through sizes, every holding costs an OVERVIEW call unless it is cached
+const (
+	defaultLookthroughHoldings = 10 // Largest holdings covered by default
+	maxLookthroughHoldings     = 50 // Most holdings a request may cover
+)
+
+// ETFProfileResponse defines the response format for ETF profiles
+// @Description ETF profile response data structure
+type ETFProfileResponse struct {
+	Version   string      `json:"version"`
+	Timestamp string      `json:"timestamp"`
+	Symbol    string      `json:"symbol"`
+	Cache     string      `json:"cache,omitempty"`
+	Data      interface{} `json:"data"` // *fundamental.ParsedETFProfile, or *fundamental.ETFProfileResponse when raw=true
+}
+
+// ETFLookthroughResponse defines the response format for ETF look-through exposures
+// @Description ETF look-through response data structure
+type ETFLookthroughResponse struct {
+	Version   string                   `json:"version"`
+	Timestamp string                   `json:"timestamp"`
+	Symbol    string                   `json:"symbol"`
+	Cache     string                   `json:"cache,omitempty"`
+	Data      *fundamental.Lookthrough `json:"data"`
+}
+
+// GetETFProfile handles requests for ETF profiles
+// @Summary Get the profile and holdings of an ETF
+// @Description Returns the net assets, expense ratio, turnover, dividend yield, inception date, sector weights and holdings of an ETF from ETF_PROFILE. Ratios and weights are fractions. CSV and NDJSON output list the holdings.
+// @Tags etf
+// @Produce json,text/csv,application/x-ndjson
+// @Param symbol path string true "ETF symbol (e.g., QQQ)"
+// @Param raw query boolean false "Serve the original Alpha Vantage strings instead of typed values" default(false)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} ETFProfileResponse{data=fundamental.ParsedETFProfile} "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/etf/{symbol} [get]
+func (h *Handler) GetETFProfile(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	ctx, cache := requestContext(c)
+
+	data, err := h.client.GetETFProfile(ctx, fundamental.ETFProfileParams{Symbol: symbol})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Serve typed values unless the caller asked for the original strings
+	var body interface{} = data
+	var rows interface{} = data.Holdings
+	if !rawRequested(c) {
+		parsed, err := data.Parse()
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		body, rows = parsed, parsed.Holdings
+	}
+
+	response := ETFProfileResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Symbol:    symbol,
+		Cache:     setCacheHeader(c, cache),
+		Data:      body,
+	}
+
+	respond(c, response, rows)
+}
+
+// GetETFLookthrough handles requests for the sector and country exposure of an ETF
+// @Summary Get the look-through sector and country exposure of an ETF
+// @Description Sums the weights of the ETF's largest holdings by the sector and country of each holding's company overview. Holdings without an overview, such as cash or other funds, are UNCLASSIFIED. Holdings whose overview fails are UNCLASSIFIED too and listed in missing with partial set, holdings left after a rate limit are not fetched. Every holding looked through costs an OVERVIEW call unless it is cached. CSV and NDJSON output list the holdings with their sector and country.
+// @Tags etf
+// @Produce json,text/csv,application/x-ndjson
+// @Param symbol path string true "ETF symbol (e.g., QQQ)"
+// @Param holdings query int false "Largest holdings to look through" default(10) minimum(1) maximum(50)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} ETFLookthroughResponse "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/etf/{symbol}/lookthrough [get]
+func (h *Handler) GetETFLookthrough(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	params := fundamental.LookthroughParams{Symbol: symbol, MaxHoldings: defaultLookthroughHoldings}
+	if value := c.Query("holdings"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxLookthroughHoldings {
+			respondError(c, fmt.Errorf("%w: holdings must be an integer from 1 to %d", common.ErrInvalidParameter, maxLookthroughHoldings))
+			return
+		}
+		params.MaxHoldings = n
+	}
+
+	ctx, cache := requestContext(c)
+
+	lookthrough, err := h.client.GetETFLookthrough(ctx, params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response := ETFLookthroughResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Symbol:    symbol,
+		Cache:     setCacheHeader(c, cache),
+		Data:      lookthrough,
+	}
+
+	respond(c, response, lookthrough.Holdings)
+}
@@ -1,9 +1,10 @@
 package fundamental
 
 import (
-	"encoding/json"
+	"context"
+	"fmt"
 	"stock/common"
-	"stock/config"
+	"time"
 )
 
 // BalanceSheetParams holds parameters for retrieving balance sheet data
@@ -60,30 +61,92 @@ type BalanceSheetReport struct {
 	CommonStockSharesOutstanding           string `json:"commonStockSharesOutstanding"`
 }
 
-// GetBalanceSheet fetches balance sheet data from Alpha Vantage API
-func GetBalanceSheet(params BalanceSheetParams) (*BalanceSheetResponse, error) {
-	// Get API configuration
-	cfg := config.GetConfig()
+// ParsedBalanceSheet is the typed form of BalanceSheetResponse
+type ParsedBalanceSheet struct {
+	Symbol           string                     `json:"symbol"`
+	AnnualReports    []ParsedBalanceSheetReport `json:"annualReports"`
+	QuarterlyReports []ParsedBalanceSheetReport `json:"quarterlyReports"`
+}
+
+// ParsedBalanceSheetReport is a balance sheet report with exact numbers, missing values are null
+type ParsedBalanceSheetReport struct {
+	FiscalDateEnding                       *time.Time     `json:"fiscalDateEnding"`
+	ReportedCurrency                       string         `json:"reportedCurrency"`
+	TotalAssets                            common.Decimal `json:"totalAssets"`
+	TotalCurrentAssets                     common.Decimal `json:"totalCurrentAssets"`
+	CashAndCashEquivalentsAtCarryingValue  common.Decimal `json:"cashAndCashEquivalentsAtCarryingValue"`
+	CashAndShortTermInvestments            common.Decimal `json:"cashAndShortTermInvestments"`
+	Inventory                              common.Decimal `json:"inventory"`
+	CurrentNetReceivables                  common.Decimal `json:"currentNetReceivables"`
+	TotalNonCurrentAssets                  common.Decimal `json:"totalNonCurrentAssets"`
+	PropertyPlantEquipment                 common.Decimal `json:"propertyPlantEquipment"`
+	AccumulatedDepreciationAmortizationPPE common.Decimal `json:"accumulatedDepreciationAmortizationPPE"`
+	IntangibleAssets                       common.Decimal `json:"intangibleAssets"`
+	IntangibleAssetsExcludingGoodwill      common.Decimal `json:"intangibleAssetsExcludingGoodwill"`
+	Goodwill                               common.Decimal `json:"goodwill"`
+	Investments                            common.Decimal `json:"investments"`
+	LongTermInvestments                    common.Decimal `json:"longTermInvestments"`
+	ShortTermInvestments                   common.Decimal `json:"shortTermInvestments"`
+	OtherCurrentAssets                     common.Decimal `json:"otherCurrentAssets"`
+	OtherNonCurrentAssets                  common.Decimal `json:"otherNonCurrentAssets"`
+	TotalLiabilities                       common.Decimal `json:"totalLiabilities"`
+	TotalCurrentLiabilities                common.Decimal `json:"totalCurrentLiabilities"`
+	CurrentAccountsPayable                 common.Decimal `json:"currentAccountsPayable"`
+	DeferredRevenue                        common.Decimal `json:"deferredRevenue"`
+	CurrentDebt                            common.Decimal `json:"currentDebt"`
+	ShortTermDebt                          common.Decimal `json:"shortTermDebt"`
+	TotalNonCurrentLiabilities             common.Decimal `json:"totalNonCurrentLiabilities"`
+	CapitalLeaseObligations                common.Decimal `json:"capitalLeaseObligations"`
+	LongTermDebt                           common.Decimal `json:"longTermDebt"`
+	CurrentLongTermDebt                    common.Decimal `json:"currentLongTermDebt"`
+	LongTermDebtNoncurrent                 common.Decimal `json:"longTermDebtNoncurrent"`
+	ShortLongTermDebtTotal                 common.Decimal `json:"shortLongTermDebtTotal"`
+	OtherCurrentLiabilities                common.Decimal `json:"otherCurrentLiabilities"`
+	OtherNonCurrentLiabilities             common.Decimal `json:"otherNonCurrentLiabilities"`
+	TotalShareholderEquity                 common.Decimal `json:"totalShareholderEquity"`
+	TreasuryStock                          common.Decimal `json:"treasuryStock"`
+	RetainedEarnings                       common.Decimal `json:"retainedEarnings"`
+	CommonStock                            common.Decimal `json:"commonStock"`
+	CommonStockSharesOutstanding           common.Decimal `json:"commonStockSharesOutstanding"`
+}
+
+// Parse converts the raw string report values into typed ones
+func (r *BalanceSheetResponse) Parse() (*ParsedBalanceSheet, error) {
+	parsed := &ParsedBalanceSheet{Symbol: r.Symbol}
+
+	var err error
+	if parsed.AnnualReports, err = parseBalanceSheetReports(r.AnnualReports); err != nil {
+		return nil, err
+	}
+	if parsed.QuarterlyReports, err = parseBalanceSheetReports(r.QuarterlyReports); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+func parseBalanceSheetReports(reports []BalanceSheetReport) ([]ParsedBalanceSheetReport, error) {
+	parsed := make([]ParsedBalanceSheetReport, len(reports))
+	for i := range reports {
+		if err := parseFields(&reports[i], &parsed[i]); err != nil {
+			return nil, fmt.Errorf("report %s: %w", reports[i].FiscalDateEnding, err)
+		}
+	}
+	return parsed, nil
+}
 
+// GetBalanceSheet fetches balance sheet data from Alpha Vantage API
+func GetBalanceSheet(ctx context.Context, client *common.Client, params BalanceSheetParams) (*BalanceSheetResponse, error) {
 	// Building query parameters
 	queryParams := map[string]string{
 		"function": "BALANCE_SHEET",
 		"symbol":   params.Symbol,
-		"apikey":   cfg.AlphaVantageAPIKey,
-	}
-
-	// Make HTTP request
-	respBody, err := common.GetAPIRequest(cfg.AlphaVantageBaseURL, queryParams)
-	if err != nil {
-		return nil, err
 	}
-	defer respBody.Close()
 
 	// Decode JSON response into BalanceSheetResponse struct
 	balanceSheetResp := &BalanceSheetResponse{
 		Symbol: params.Symbol,
 	}
-	if err := json.NewDecoder(respBody).Decode(balanceSheetResp); err != nil {
+	if err := client.GetJSON(ctx, queryParams, balanceSheetResp); err != nil {
 		return nil, err
 	}
 
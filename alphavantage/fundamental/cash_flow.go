@@ -1,9 +1,10 @@
 package fundamental
 
 import (
-	"encoding/json"
+	"context"
+	"fmt"
 	"stock/common"
-	"stock/config"
+	"time"
 )
 
 // CashFlowParams holds parameters for retrieving cash flow data
@@ -51,30 +52,83 @@ type CashFlowReport struct {
 	NetIncome                                                 string `json:"netIncome"`
 }
 
-// GetCashFlow fetches cash flow data from Alpha Vantage API
-func GetCashFlow(params CashFlowParams) (*CashFlowResponse, error) {
-	// Get API configuration
-	cfg := config.GetConfig()
+// ParsedCashFlow is the typed form of CashFlowResponse
+type ParsedCashFlow struct {
+	Symbol           string                 `json:"symbol"`
+	AnnualReports    []ParsedCashFlowReport `json:"annualReports"`
+	QuarterlyReports []ParsedCashFlowReport `json:"quarterlyReports"`
+}
+
+// ParsedCashFlowReport is a cash flow report with exact numbers, missing values are null
+type ParsedCashFlowReport struct {
+	FiscalDateEnding                                          *time.Time     `json:"fiscalDateEnding"`
+	ReportedCurrency                                          string         `json:"reportedCurrency"`
+	OperatingCashflow                                         common.Decimal `json:"operatingCashflow"`
+	PaymentsForOperatingActivities                            common.Decimal `json:"paymentsForOperatingActivities"`
+	ProceedsFromOperatingActivities                           common.Decimal `json:"proceedsFromOperatingActivities"`
+	ChangeInOperatingLiabilities                              common.Decimal `json:"changeInOperatingLiabilities"`
+	ChangeInOperatingAssets                                   common.Decimal `json:"changeInOperatingAssets"`
+	DepreciationDepletionAndAmortization                      common.Decimal `json:"depreciationDepletionAndAmortization"`
+	CapitalExpenditures                                       common.Decimal `json:"capitalExpenditures"`
+	ChangeInReceivables                                       common.Decimal `json:"changeInReceivables"`
+	ChangeInInventory                                         common.Decimal `json:"changeInInventory"`
+	ProfitLoss                                                common.Decimal `json:"profitLoss"`
+	CashflowFromInvestment                                    common.Decimal `json:"cashflowFromInvestment"`
+	CashflowFromFinancing                                     common.Decimal `json:"cashflowFromFinancing"`
+	ProceedsFromRepaymentsOfShortTermDebt                     common.Decimal `json:"proceedsFromRepaymentsOfShortTermDebt"`
+	PaymentsForRepurchaseOfCommonStock                        common.Decimal `json:"paymentsForRepurchaseOfCommonStock"`
+	PaymentsForRepurchaseOfEquity                             common.Decimal `json:"paymentsForRepurchaseOfEquity"`
+	PaymentsForRepurchaseOfPreferredStock                     common.Decimal `json:"paymentsForRepurchaseOfPreferredStock"`
+	DividendPayout                                            common.Decimal `json:"dividendPayout"`
+	DividendPayoutCommonStock                                 common.Decimal `json:"dividendPayoutCommonStock"`
+	DividendPayoutPreferredStock                              common.Decimal `json:"dividendPayoutPreferredStock"`
+	ProceedsFromIssuanceOfCommonStock                         common.Decimal `json:"proceedsFromIssuanceOfCommonStock"`
+	ProceedsFromIssuanceOfLongTermDebtAndCapitalSecuritiesNet common.Decimal `json:"proceedsFromIssuanceOfLongTermDebtAndCapitalSecuritiesNet"`
+	ProceedsFromIssuanceOfPreferredStock                      common.Decimal `json:"proceedsFromIssuanceOfPreferredStock"`
+	ProceedsFromRepurchaseOfEquity                            common.Decimal `json:"proceedsFromRepurchaseOfEquity"`
+	ProceedsFromSaleOfTreasuryStock                           common.Decimal `json:"proceedsFromSaleOfTreasuryStock"`
+	ChangeInCashAndCashEquivalents                            common.Decimal `json:"changeInCashAndCashEquivalents"`
+	ChangeInExchangeRate                                      common.Decimal `json:"changeInExchangeRate"`
+	NetIncome                                                 common.Decimal `json:"netIncome"`
+}
+
+// Parse converts the raw string report values into typed ones
+func (r *CashFlowResponse) Parse() (*ParsedCashFlow, error) {
+	parsed := &ParsedCashFlow{Symbol: r.Symbol}
+
+	var err error
+	if parsed.AnnualReports, err = parseCashFlowReports(r.AnnualReports); err != nil {
+		return nil, err
+	}
+	if parsed.QuarterlyReports, err = parseCashFlowReports(r.QuarterlyReports); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+func parseCashFlowReports(reports []CashFlowReport) ([]ParsedCashFlowReport, error) {
+	parsed := make([]ParsedCashFlowReport, len(reports))
+	for i := range reports {
+		if err := parseFields(&reports[i], &parsed[i]); err != nil {
+			return nil, fmt.Errorf("report %s: %w", reports[i].FiscalDateEnding, err)
+		}
+	}
+	return parsed, nil
+}
 
+// GetCashFlow fetches cash flow data from Alpha Vantage API
+func GetCashFlow(ctx context.Context, client *common.Client, params CashFlowParams) (*CashFlowResponse, error) {
 	// Building query parameters
 	queryParams := map[string]string{
 		"function": "CASH_FLOW",
 		"symbol":   params.Symbol,
-		"apikey":   cfg.AlphaVantageAPIKey,
-	}
-
-	// Make HTTP request
-	respBody, err := common.GetAPIRequest(cfg.AlphaVantageBaseURL, queryParams)
-	if err != nil {
-		return nil, err
 	}
-	defer respBody.Close()
 
 	// Convert generic response to CashFlowResponse
 	cashFlowResp := &CashFlowResponse{
 		Symbol: params.Symbol,
 	}
-	if err := json.NewDecoder(respBody).Decode(cashFlowResp); err != nil {
+	if err := client.GetJSON(ctx, queryParams, cashFlowResp); err != nil {
 		return nil, err
 	}
 
@@ -1,9 +1,9 @@
 package fundamental
 
 import (
-	"encoding/json"
+	"context"
 	"stock/common"
-	"stock/config"
+	"time"
 )
 
 // CompanyOverviewParams holds parameters for retrieving company overview data
@@ -72,30 +72,89 @@ type CompanyOverviewResponse struct {
 	LastSplitDate              string `json:"lastSplitDate"`
 }
 
-// GetCompanyOverview fetches company overview data from Alpha Vantage API
-func GetCompanyOverview(params CompanyOverviewParams) (*CompanyOverviewResponse, error) {
-	// Get API configuration
-	cfg := config.GetConfig()
+// ParsedCompanyOverview is the typed form of CompanyOverviewResponse, missing values are null
+type ParsedCompanyOverview struct {
+	Symbol                     string         `json:"symbol"`
+	Name                       string         `json:"name"`
+	Description                string         `json:"description"`
+	Exchange                   string         `json:"exchange"`
+	Currency                   string         `json:"currency"`
+	Country                    string         `json:"country"`
+	Sector                     string         `json:"sector"`
+	Industry                   string         `json:"industry"`
+	Address                    string         `json:"address"`
+	FiscalYearEnd              string         `json:"fiscalYearEnd"`
+	LatestQuarter              *time.Time     `json:"latestQuarter"`
+	MarketCapitalization       common.Decimal `json:"marketCapitalization"`
+	EBITDA                     common.Decimal `json:"ebitda"`
+	PERatio                    common.Decimal `json:"peRatio"`
+	PEGRatio                   common.Decimal `json:"pegRatio"`
+	BookValue                  common.Decimal `json:"bookValue"`
+	DividendPerShare           common.Decimal `json:"dividendPerShare"`
+	DividendYield              common.Decimal `json:"dividendYield"`
+	EPS                        common.Decimal `json:"eps"`
+	RevenuePerShareTTM         common.Decimal `json:"revenuePerShareTTM"`
+	ProfitMargin               common.Decimal `json:"profitMargin"`
+	OperatingMarginTTM         common.Decimal `json:"operatingMarginTTM"`
+	ReturnOnAssetsTTM          common.Decimal `json:"returnOnAssetsTTM"`
+	ReturnOnEquityTTM          common.Decimal `json:"returnOnEquityTTM"`
+	RevenueTTM                 common.Decimal `json:"revenueTTM"`
+	GrossProfitTTM             common.Decimal `json:"grossProfitTTM"`
+	DilutedEPSTTM              common.Decimal `json:"dilutedEPSTTM"`
+	QuarterlyEarningsGrowthYOY common.Decimal `json:"quarterlyEarningsGrowthYOY"`
+	QuarterlyRevenueGrowthYOY  common.Decimal `json:"quarterlyRevenueGrowthYOY"`
+	AnalystTargetPrice         common.Decimal `json:"analystTargetPrice"`
+	TrailingPE                 common.Decimal `json:"trailingPE"`
+	ForwardPE                  common.Decimal `json:"forwardPE"`
+	PriceToSalesRatioTTM       common.Decimal `json:"priceToSalesRatioTTM"`
+	PriceToBookRatio           common.Decimal `json:"priceToBookRatio"`
+	EVToRevenue                common.Decimal `json:"evToRevenue"`
+	EVToEBITDA                 common.Decimal `json:"evToEBITDA"`
+	Beta                       common.Decimal `json:"beta"`
+	WeekHigh52                 common.Decimal `json:"52WeekHigh"`
+	WeekLow52                  common.Decimal `json:"52WeekLow"`
+	DayMovingAverage50         common.Decimal `json:"50DayMovingAverage"`
+	DayMovingAverage200        common.Decimal `json:"200DayMovingAverage"`
+	SharesOutstanding          common.Decimal `json:"sharesOutstanding"`
+	SharesFloat                common.Decimal `json:"sharesFloat"`
+	SharesShort                common.Decimal `json:"sharesShort"`
+	SharesShortPriorMonth      common.Decimal `json:"sharesShortPriorMonth"`
+	ShortRatio                 common.Decimal `json:"shortRatio"`
+	ShortPercentOutstanding    common.Decimal `json:"shortPercentOutstanding"`
+	ShortPercentFloat          common.Decimal `json:"shortPercentFloat"`
+	PercentInsiders            common.Decimal `json:"percentInsiders"`
+	PercentInstitutions        common.Decimal `json:"percentInstitutions"`
+	ForwardAnnualDividendRate  common.Decimal `json:"forwardAnnualDividendRate"`
+	ForwardAnnualDividendYield common.Decimal `json:"forwardAnnualDividendYield"`
+	PayoutRatio                common.Decimal `json:"payoutRatio"`
+	DividendDate               *time.Time     `json:"dividendDate"`
+	ExDividendDate             *time.Time     `json:"exDividendDate"`
+	LastSplitFactor            string         `json:"lastSplitFactor"`
+	LastSplitDate              *time.Time     `json:"lastSplitDate"`
+}
+
+// Parse converts the raw string overview values into typed ones
+func (r *CompanyOverviewResponse) Parse() (*ParsedCompanyOverview, error) {
+	parsed := &ParsedCompanyOverview{}
+	if err := parseFields(r, parsed); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
 
+// GetCompanyOverview fetches company overview data from Alpha Vantage API
+func GetCompanyOverview(ctx context.Context, client *common.Client, params CompanyOverviewParams) (*CompanyOverviewResponse, error) {
 	// Building query parameters
 	queryParams := map[string]string{
 		"function": "OVERVIEW",
 		"symbol":   params.Symbol,
-		"apikey":   cfg.AlphaVantageAPIKey,
-	}
-
-	// Make HTTP request
-	respBody, err := common.GetAPIRequest(cfg.AlphaVantageBaseURL, queryParams)
-	if err != nil {
-		return nil, err
 	}
-	defer respBody.Close()
 
 	// Convert generic response to CompanyOverviewResponse
 	overview := &CompanyOverviewResponse{
 		Symbol: params.Symbol,
 	}
-	if err := json.NewDecoder(respBody).Decode(overview); err != nil {
+	if err := client.GetJSON(ctx, queryParams, overview); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,227 @@
+package fundamental
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"stock/common"
+)
+
+// dividend returns a paid dividend going ex on date (YYYY-MM-DD), or without an ex-date when date is empty
+func dividend(date, amount string) ParsedDividend {
+	d := ParsedDividend{Amount: common.MustParseDecimal(amount)}
+	if date != "" {
+		t, _ := time.Parse(common.DateLayout, date)
+		d.ExDividendDate = &t
+	}
+	return d
+}
+
+func TestAnnualDividends(t *testing.T) {
+	type total struct {
+		year     int
+		total    string
+		payments int
+		partial  bool
+	}
+	tests := []struct {
+		name      string
+		dividends []ParsedDividend
+		want      []total
+	}{
+		{
+			name: "partial current year",
+			dividends: []ParsedDividend{ // Newest first, like upstream
+				dividend("2024-02-09", "1.66"),
+				dividend("2023-11-09", "1.66"),
+				dividend("2023-08-09", "1.66"),
+				dividend("2023-05-09", "1.66"),
+				dividend("2023-02-09", "1.65"),
+			},
+			want: []total{{2023, "6.63", 4, false}, {2024, "1.66", 1, true}},
+		},
+		{
+			name: "gap years",
+			dividends: []ParsedDividend{
+				dividend("2021-06-01", "0.5"),
+				dividend("2019-06-01", "0.2"),
+				dividend("2019-12-01", "0.1"),
+			},
+			want: []total{{2019, "0.3", 2, false}, {2021, "0.5", 1, false}},
+		},
+		{
+			name: "missing ex-date and amount",
+			dividends: []ParsedDividend{
+				dividend("2023-06-01", "0.5"),
+				dividend("", "9.99"),
+				{ExDividendDate: dividend("2023-09-01", "0").ExDividendDate},
+			},
+			want: []total{{2023, "0.5", 1, false}},
+		},
+		{
+			name:      "no dividends",
+			dividends: nil,
+			want:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := annualDividends(tt.dividends, 2024)
+			if len(got) != len(tt.want) {
+				t.Fatalf("annualDividends() = %+v, want %+v", got, tt.want)
+			}
+			for i, want := range tt.want {
+				g := got[i]
+				if g.Year != want.year || g.Total.String() != want.total || g.Payments != want.payments || g.Partial != want.partial {
+					t.Errorf("year %d = {%d %s %d %v}, want %+v", i, g.Year, g.Total, g.Payments, g.Partial, want)
+				}
+			}
+		})
+	}
+}
+
+// show formats an optional rate
+func show(r *float64) string {
+	if r == nil {
+		return "nil"
+	}
+	return fmt.Sprint(*r)
+}
+
+// totals builds annual totals from year to amount
+func totals(amounts map[int]string) []AnnualDividend {
+	var out []AnnualDividend
+	for year, amount := range amounts {
+		out = append(out, AnnualDividend{Year: year, Total: common.MustParseDecimal(amount)})
+	}
+	return out
+}
+
+func TestDividendGrowth(t *testing.T) {
+	// Ten years of 10% increases, 2013 through 2023
+	steady := map[int]string{
+		2013: "1", 2014: "1.1", 2015: "1.21", 2016: "1.331", 2017: "1.4641", 2018: "1.61051",
+		2019: "1.771561", 2020: "1.9487171", 2021: "2.14358881", 2022: "2.357947691", 2023: "2.5937424601",
+	}
+
+	rate := func(r float64) *float64 { return &r }
+	tests := []struct {
+		name       string
+		amounts    map[int]string
+		want       DividendGrowth
+		wantStreak int
+	}{
+		{
+			name:       "steady growth",
+			amounts:    steady,
+			want:       DividendGrowth{OneYear: rate(0.1), ThreeYear: rate(0.1), FiveYear: rate(0.1), TenYear: rate(0.1)},
+			wantStreak: 10, // 2014 through 2023, 2013 has no year before it
+		},
+		{
+			name:       "doubling over three years",
+			amounts:    map[int]string{2020: "1", 2021: "1.2", 2022: "1.5", 2023: "8"},
+			want:       DividendGrowth{OneYear: rate(8/1.5 - 1), ThreeYear: rate(1)},
+			wantStreak: 3,
+		},
+		{
+			name:       "streak cut by a cut",
+			amounts:    map[int]string{2019: "1", 2020: "1.2", 2021: "1.3", 2022: "1.1", 2023: "1.2"},
+			want:       DividendGrowth{OneYear: rate(1.2/1.1 - 1), ThreeYear: rate(0)},
+			wantStreak: 1,
+		},
+		{
+			name:       "streak cut by a flat year",
+			amounts:    map[int]string{2021: "1", 2022: "1", 2023: "1.5"},
+			want:       DividendGrowth{OneYear: rate(0.5)},
+			wantStreak: 1,
+		},
+		{
+			name:       "zero start year",
+			amounts:    map[int]string{2020: "0", 2021: "1", 2022: "1.1", 2023: "1.21"},
+			want:       DividendGrowth{OneYear: rate(0.1)},
+			wantStreak: 2, // The increase from nothing in 2020 does not count
+		},
+		{
+			name:       "gap year",
+			amounts:    map[int]string{2018: "1", 2020: "2", 2021: "3", 2023: "4"},
+			want:       DividendGrowth{ThreeYear: rate(math.Cbrt(2) - 1), FiveYear: rate(math.Pow(4, 0.2) - 1)},
+			wantStreak: 0, // Nothing was paid in 2022
+		},
+		{
+			name:       "last year without dividends",
+			amounts:    map[int]string{2020: "1", 2021: "2", 2022: "3"},
+			want:       DividendGrowth{},
+			wantStreak: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			growth, streak := dividendGrowth(totals(tt.amounts), 2023)
+			if streak != tt.wantStreak {
+				t.Errorf("streak = %d, want %d", streak, tt.wantStreak)
+			}
+			check := func(name string, got, want *float64) {
+				switch {
+				case got == nil && want == nil:
+				case got == nil || want == nil:
+					t.Errorf("%s = %s, want %s", name, show(got), show(want))
+				case math.Abs(*got-*want) > 1e-9:
+					t.Errorf("%s = %v, want %v", name, *got, *want)
+				}
+			}
+			check("OneYear", growth.OneYear, tt.want.OneYear)
+			check("ThreeYear", growth.ThreeYear, tt.want.ThreeYear)
+			check("FiveYear", growth.FiveYear, tt.want.FiveYear)
+			check("TenYear", growth.TenYear, tt.want.TenYear)
+		})
+	}
+}
+
+func TestDividendsParseMissingExDate(t *testing.T) {
+	year := time.Now().Year()
+	resp := &DividendsResponse{Symbol: "IBM", Data: []Dividend{
+		{ExDividendDate: "None", DeclarationDate: "None", RecordDate: "None", PaymentDate: "None", Amount: "0.5"},
+		{ExDividendDate: time.Date(year-1, time.May, 9, 0, 0, 0, 0, time.UTC).Format(common.DateLayout), DeclarationDate: "None", RecordDate: "None", PaymentDate: "None", Amount: "1.66"},
+	}}
+
+	parsed, err := resp.Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.Data[0].ExDividendDate != nil {
+		t.Errorf("ExDividendDate = %v, want nil for None", parsed.Data[0].ExDividendDate)
+	}
+	if len(parsed.AnnualTotals) != 1 || parsed.AnnualTotals[0].Year != year-1 || parsed.AnnualTotals[0].Total.String() != "1.66" {
+		t.Errorf("AnnualTotals = %+v, want only %d with 1.66", parsed.AnnualTotals, year-1)
+	}
+
+	data, err := json.Marshal(parsed)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for _, want := range []string{`"ex_dividend_date":null`, `"annual_totals":`, `"increase_streak":0`, `"one_year":null`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Marshal() = %s, want it to contain %s", data, want)
+		}
+	}
+}
+
+func TestSplitsParseMissingDate(t *testing.T) {
+	resp := &SplitsResponse{Symbol: "IBM", Data: []Split{
+		{EffectiveDate: "1999-05-27", SplitFactor: "2.0"},
+		{EffectiveDate: "None", SplitFactor: "1.0"},
+	}}
+	parsed, err := resp.Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.Data[0].EffectiveDate == nil || parsed.Data[0].EffectiveDate.Format(common.DateLayout) != "1999-05-27" || parsed.Data[1].EffectiveDate != nil {
+		t.Errorf("EffectiveDate = %v and %v, want 1999-05-27 and nil", parsed.Data[0].EffectiveDate, parsed.Data[1].EffectiveDate)
+	}
+}
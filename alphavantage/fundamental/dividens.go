@@ -1 +1,229 @@
 package fundamental
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"stock/common"
+	"time"
+)
+
+// DividendsParams holds parameters for retrieving dividend history
+type DividendsParams struct {
+	Symbol string
+}
+
+// DividendsResponse defines the structure for dividend history
+type DividendsResponse struct {
+	Symbol string     `json:"symbol"`
+	Data   []Dividend `json:"data"`
+}
+
+// Dividend represents a single dividend distribution
+type Dividend struct {
+	ExDividendDate  string `json:"ex_dividend_date"`
+	DeclarationDate string `json:"declaration_date"`
+	RecordDate      string `json:"record_date"`
+	PaymentDate     string `json:"payment_date"`
+	Amount          string `json:"amount"`
+}
+
+// ParsedDividends is the typed form of DividendsResponse with derived figures.
+// Dividends are attributed to the year of their ex-dividend date, those
+// without one are listed but left out of the annual totals.
+type ParsedDividends struct {
+	Symbol         string           `json:"symbol"`
+	Data           []ParsedDividend `json:"data"`
+	AnnualTotals   []AnnualDividend `json:"annual_totals"`
+	Growth         DividendGrowth   `json:"growth"`
+	IncreaseStreak int              `json:"increase_streak"` // Consecutive complete years with a higher total than the year before
+}
+
+// ParsedDividend is the typed form of Dividend, missing dates are nil
+type ParsedDividend struct {
+	ExDividendDate  *time.Time     `json:"ex_dividend_date"`
+	DeclarationDate *time.Time     `json:"declaration_date"`
+	RecordDate      *time.Time     `json:"record_date"`
+	PaymentDate     *time.Time     `json:"payment_date"`
+	Amount          common.Decimal `json:"amount"`
+}
+
+// AnnualDividend is the total paid per share in one calendar year
+type AnnualDividend struct {
+	Year     int            `json:"year"`
+	Total    common.Decimal `json:"total"`
+	Payments int            `json:"payments"`
+	Partial  bool           `json:"partial"` // The year is still in progress
+}
+
+// DividendGrowth holds compound annual growth rates of the annual totals,
+// ending with the last complete year. Rates are null without enough history.
+type DividendGrowth struct {
+	OneYear   *float64 `json:"one_year"`
+	ThreeYear *float64 `json:"three_year"`
+	FiveYear  *float64 `json:"five_year"`
+	TenYear   *float64 `json:"ten_year"`
+}
+
+// SplitsParams holds parameters for retrieving split history
+type SplitsParams struct {
+	Symbol string
+}
+
+// SplitsResponse defines the structure for split history
+type SplitsResponse struct {
+	Symbol string  `json:"symbol"`
+	Data   []Split `json:"data"`
+}
+
+// Split represents a single stock split
+type Split struct {
+	EffectiveDate string `json:"effective_date"`
+	SplitFactor   string `json:"split_factor"`
+}
+
+// ParsedSplits is the typed form of SplitsResponse
+type ParsedSplits struct {
+	Symbol string        `json:"symbol"`
+	Data   []ParsedSplit `json:"data"`
+}
+
+// ParsedSplit is the typed form of Split, a missing date is nil
+type ParsedSplit struct {
+	EffectiveDate *time.Time     `json:"effective_date"`
+	SplitFactor   common.Decimal `json:"split_factor"`
+}
+
+// Parse converts the raw string values into typed ones and derives annual
+// totals, growth rates and the increase streak as of today
+func (r *DividendsResponse) Parse() (*ParsedDividends, error) {
+	parsed := &ParsedDividends{
+		Symbol: r.Symbol,
+		Data:   make([]ParsedDividend, len(r.Data)),
+	}
+
+	for i := range r.Data {
+		if err := parseFields(&r.Data[i], &parsed.Data[i]); err != nil {
+			return nil, fmt.Errorf("dividend %s: %w", r.Data[i].ExDividendDate, err)
+		}
+	}
+
+	currentYear := time.Now().Year()
+	parsed.AnnualTotals = annualDividends(parsed.Data, currentYear)
+	parsed.Growth, parsed.IncreaseStreak = dividendGrowth(parsed.AnnualTotals, currentYear-1)
+	return parsed, nil
+}
+
+// annualDividends sums dividends per ex-dividend year in chronological order,
+// skipping those without an amount or an ex-dividend date
+func annualDividends(dividends []ParsedDividend, currentYear int) []AnnualDividend {
+	byYear := make(map[int]*AnnualDividend)
+	for _, dividend := range dividends {
+		if !dividend.Amount.Valid() || dividend.ExDividendDate == nil {
+			continue
+		}
+
+		year := dividend.ExDividendDate.Year()
+		total, ok := byYear[year]
+		if !ok {
+			total = &AnnualDividend{Year: year, Total: common.NewDecimal(0, 0), Partial: year >= currentYear}
+			byYear[year] = total
+		}
+		total.Total = total.Total.Add(dividend.Amount)
+		total.Payments++
+	}
+
+	totals := make([]AnnualDividend, 0, len(byYear))
+	for _, total := range byYear {
+		totals = append(totals, *total)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		return totals[i].Year < totals[j].Year
+	})
+	return totals
+}
+
+// dividendGrowth computes growth rates and the increase streak ending with lastYear
+func dividendGrowth(totals []AnnualDividend, lastYear int) (DividendGrowth, int) {
+	byYear := make(map[int]float64, len(totals))
+	for _, total := range totals {
+		if f, ok := total.Total.Float64(); ok {
+			byYear[total.Year] = f
+		}
+	}
+
+	cagr := func(years int) *float64 {
+		end, start := byYear[lastYear], byYear[lastYear-years]
+		if end <= 0 || start <= 0 {
+			return nil
+		}
+		rate := math.Pow(end/start, 1/float64(years)) - 1
+		return &rate
+	}
+	growth := DividendGrowth{
+		OneYear:   cagr(1),
+		ThreeYear: cagr(3),
+		FiveYear:  cagr(5),
+		TenYear:   cagr(10),
+	}
+
+	streak := 0
+	for year := lastYear; byYear[year] > byYear[year-1] && byYear[year-1] > 0; year-- {
+		streak++
+	}
+	return growth, streak
+}
+
+// Parse converts the raw string values into typed ones
+func (r *SplitsResponse) Parse() (*ParsedSplits, error) {
+	parsed := &ParsedSplits{
+		Symbol: r.Symbol,
+		Data:   make([]ParsedSplit, len(r.Data)),
+	}
+
+	for i := range r.Data {
+		if err := parseFields(&r.Data[i], &parsed.Data[i]); err != nil {
+			return nil, fmt.Errorf("split %s: %w", r.Data[i].EffectiveDate, err)
+		}
+	}
+	return parsed, nil
+}
+
+// GetDividends retrieves the dividend history for a given symbol
+func GetDividends(ctx context.Context, client *common.Client, params DividendsParams) (*DividendsResponse, error) {
+	// Building query parameters
+	queryParams := map[string]string{
+		"function": "DIVIDENDS",
+		"symbol":   params.Symbol,
+	}
+
+	// Make HTTP request and parse the response
+	result := &DividendsResponse{
+		Symbol: params.Symbol,
+	}
+	if err := client.GetJSON(ctx, queryParams, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetSplits retrieves the split history for a given symbol
+func GetSplits(ctx context.Context, client *common.Client, params SplitsParams) (*SplitsResponse, error) {
+	// Building query parameters
+	queryParams := map[string]string{
+		"function": "SPLITS",
+		"symbol":   params.Symbol,
+	}
+
+	// Make HTTP request and parse the response
+	result := &SplitsResponse{
+		Symbol: params.Symbol,
+	}
+	if err := client.GetJSON(ctx, queryParams, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -1 +1,154 @@
 package fundamental
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"stock/common"
+	"time"
+)
+
+// EarningsParams holds parameters for retrieving earnings data
+type EarningsParams struct {
+	Symbol string
+}
+
+// EarningsResponse defines the structure for annual and quarterly EPS history
+type EarningsResponse struct {
+	Symbol            string              `json:"symbol"`
+	AnnualEarnings    []AnnualEarnings    `json:"annualEarnings"`
+	QuarterlyEarnings []QuarterlyEarnings `json:"quarterlyEarnings"`
+}
+
+// AnnualEarnings represents the reported EPS of one fiscal year
+type AnnualEarnings struct {
+	FiscalDateEnding string `json:"fiscalDateEnding"`
+	ReportedEPS      string `json:"reportedEPS"`
+}
+
+// QuarterlyEarnings represents the reported and estimated EPS of one fiscal quarter
+type QuarterlyEarnings struct {
+	FiscalDateEnding   string `json:"fiscalDateEnding"`
+	ReportedDate       string `json:"reportedDate"`
+	ReportedEPS        string `json:"reportedEPS"`
+	EstimatedEPS       string `json:"estimatedEPS"`
+	Surprise           string `json:"surprise"`
+	SurprisePercentage string `json:"surprisePercentage"`
+	ReportTime         string `json:"reportTime"`
+}
+
+// ParsedEarnings is the typed form of EarningsResponse with derived figures
+type ParsedEarnings struct {
+	Symbol            string                    `json:"symbol"`
+	AnnualEarnings    []ParsedAnnualEarnings    `json:"annualEarnings"`
+	QuarterlyEarnings []ParsedQuarterlyEarnings `json:"quarterlyEarnings"`
+	TrailingEPS       common.Decimal            `json:"trailingEPS"` // Sum of the four latest quarters, null when one is missing
+	BeatStreak        int                       `json:"beatStreak"`  // Consecutive latest quarters reported above the estimate
+	MissStreak        int                       `json:"missStreak"`  // Consecutive latest quarters reported below the estimate
+}
+
+// ParsedAnnualEarnings is the typed form of AnnualEarnings
+type ParsedAnnualEarnings struct {
+	FiscalDateEnding *time.Time     `json:"fiscalDateEnding"`
+	ReportedEPS      common.Decimal `json:"reportedEPS"`
+}
+
+// ParsedQuarterlyEarnings is the typed form of QuarterlyEarnings
+type ParsedQuarterlyEarnings struct {
+	FiscalDateEnding   *time.Time     `json:"fiscalDateEnding"`
+	ReportedDate       *time.Time     `json:"reportedDate"`
+	ReportedEPS        common.Decimal `json:"reportedEPS"`
+	EstimatedEPS       common.Decimal `json:"estimatedEPS"`
+	Surprise           common.Decimal `json:"surprise"`
+	SurprisePercentage common.Decimal `json:"surprisePercentage"`
+	ReportTime         string         `json:"reportTime"`
+}
+
+// Parse converts the raw string values into typed ones and derives the
+// trailing EPS and the current beat or miss streak
+func (r *EarningsResponse) Parse() (*ParsedEarnings, error) {
+	parsed := &ParsedEarnings{
+		Symbol:            r.Symbol,
+		AnnualEarnings:    make([]ParsedAnnualEarnings, len(r.AnnualEarnings)),
+		QuarterlyEarnings: make([]ParsedQuarterlyEarnings, len(r.QuarterlyEarnings)),
+	}
+
+	for i := range r.AnnualEarnings {
+		if err := parseFields(&r.AnnualEarnings[i], &parsed.AnnualEarnings[i]); err != nil {
+			return nil, fmt.Errorf("annual earnings %s: %w", r.AnnualEarnings[i].FiscalDateEnding, err)
+		}
+	}
+	for i := range r.QuarterlyEarnings {
+		if err := parseFields(&r.QuarterlyEarnings[i], &parsed.QuarterlyEarnings[i]); err != nil {
+			return nil, fmt.Errorf("quarterly earnings %s: %w", r.QuarterlyEarnings[i].FiscalDateEnding, err)
+		}
+	}
+
+	// Derived figures look at the latest quarters first, undated ones last
+	latest := make([]ParsedQuarterlyEarnings, len(parsed.QuarterlyEarnings))
+	copy(latest, parsed.QuarterlyEarnings)
+	sort.SliceStable(latest, func(i, j int) bool {
+		a, b := latest[i].FiscalDateEnding, latest[j].FiscalDateEnding
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.After(*b)
+	})
+
+	parsed.TrailingEPS = trailingEPS(latest)
+	parsed.BeatStreak, parsed.MissStreak = surpriseStreaks(latest)
+	return parsed, nil
+}
+
+// trailingEPS sums the reported EPS of the four latest quarters
+func trailingEPS(latest []ParsedQuarterlyEarnings) common.Decimal {
+	if len(latest) < 4 {
+		return common.Decimal{}
+	}
+
+	total := common.NewDecimal(0, 0)
+	for _, quarter := range latest[:4] {
+		total = total.Add(quarter.ReportedEPS)
+	}
+	return total
+}
+
+// surpriseStreaks counts how many of the latest quarters in a row beat or
+// missed the estimate, at most one of the two is non-zero
+func surpriseStreaks(latest []ParsedQuarterlyEarnings) (beat, miss int) {
+	for _, quarter := range latest {
+		if !quarter.ReportedEPS.Valid() || !quarter.EstimatedEPS.Valid() {
+			break
+		}
+
+		cmp := quarter.ReportedEPS.Cmp(quarter.EstimatedEPS)
+		switch {
+		case cmp > 0 && miss == 0:
+			beat++
+		case cmp < 0 && beat == 0:
+			miss++
+		default:
+			return beat, miss
+		}
+	}
+	return beat, miss
+}
+
+// GetEarnings retrieves annual and quarterly EPS history for a given symbol
+func GetEarnings(ctx context.Context, client *common.Client, params EarningsParams) (*EarningsResponse, error) {
+	// Building query parameters
+	queryParams := map[string]string{
+		"function": "EARNINGS",
+		"symbol":   params.Symbol,
+	}
+
+	// Make HTTP request and parse the response
+	result := &EarningsResponse{
+		Symbol: params.Symbol,
+	}
+	if err := client.GetJSON(ctx, queryParams, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,165 @@
+package fundamental
+
+import (
+	"testing"
+
+	"stock/common"
+)
+
+// quarter returns a raw quarter ending on date (YYYY-MM-DD) with the reported and estimated EPS
+func quarter(date, reported, estimated string) QuarterlyEarnings {
+	return QuarterlyEarnings{FiscalDateEnding: date, ReportedEPS: reported, EstimatedEPS: estimated}
+}
+
+func TestEarningsParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		quarters []QuarterlyEarnings
+		trailing string // "None" when null
+		beat     int
+		miss     int
+	}{
+		{
+			name: "four quarters beating",
+			quarters: []QuarterlyEarnings{ // Newest first, like upstream
+				quarter("2024-03-31", "1.50", "1.40"),
+				quarter("2023-12-31", "2.18", "2.10"),
+				quarter("2023-09-30", "1.46", "1.39"),
+				quarter("2023-06-30", "1.26", "1.19"),
+				quarter("2023-03-31", "1.52", "1.43"),
+			},
+			trailing: "6.40",
+			beat:     5,
+		},
+		{
+			name: "fewer than four quarters",
+			quarters: []QuarterlyEarnings{
+				quarter("2024-03-31", "1.50", "1.40"),
+				quarter("2023-12-31", "2.18", "2.10"),
+				quarter("2023-09-30", "1.46", "1.39"),
+			},
+			trailing: "None",
+			beat:     3,
+		},
+		{
+			name: "null latest quarter",
+			quarters: []QuarterlyEarnings{
+				quarter("2024-03-31", "None", "1.40"),
+				quarter("2023-12-31", "2.18", "2.10"),
+				quarter("2023-09-30", "1.46", "1.39"),
+				quarter("2023-06-30", "1.26", "1.19"),
+				quarter("2023-03-31", "1.52", "1.43"),
+			},
+			trailing: "None",
+		},
+		{
+			name: "null older quarter",
+			quarters: []QuarterlyEarnings{
+				quarter("2024-03-31", "1.50", "1.60"),
+				quarter("2023-12-31", "2.18", "2.20"),
+				quarter("2023-09-30", "1.46", "1.39"),
+				quarter("2023-06-30", "-", "1.19"),
+			},
+			trailing: "None",
+			miss:     2,
+		},
+		{
+			name: "miss streak broken by a beat",
+			quarters: []QuarterlyEarnings{
+				quarter("2024-03-31", "1.30", "1.40"),
+				quarter("2023-12-31", "2.00", "2.10"),
+				quarter("2023-09-30", "1.46", "1.39"),
+				quarter("2023-06-30", "1.00", "1.19"),
+			},
+			trailing: "5.76",
+			miss:     2,
+		},
+		{
+			name: "beat streak broken by an equal estimate",
+			quarters: []QuarterlyEarnings{
+				quarter("2024-03-31", "1.50", "1.40"),
+				quarter("2023-12-31", "2.10", "2.1"),
+				quarter("2023-09-30", "1.46", "1.39"),
+				quarter("2023-06-30", "1.26", "1.19"),
+			},
+			trailing: "6.32",
+			beat:     1,
+		},
+		{
+			name: "beat streak broken by a missing estimate",
+			quarters: []QuarterlyEarnings{
+				quarter("2024-03-31", "1.50", "1.40"),
+				quarter("2023-12-31", "2.18", "2.10"),
+				quarter("2023-09-30", "1.46", "None"),
+				quarter("2023-06-30", "1.26", "1.19"),
+			},
+			trailing: "6.40",
+			beat:     2,
+		},
+		{
+			name: "equal latest quarter",
+			quarters: []QuarterlyEarnings{
+				quarter("2024-03-31", "1.40", "1.40"),
+				quarter("2023-12-31", "2.18", "2.10"),
+			},
+			trailing: "None",
+		},
+		{
+			name: "unsorted quarters",
+			quarters: []QuarterlyEarnings{
+				quarter("2023-06-30", "1.00", "1.19"),
+				quarter("2024-03-31", "1.50", "1.40"),
+				quarter("2023-09-30", "1.46", "1.39"),
+				quarter("2023-12-31", "2.18", "2.10"),
+			},
+			trailing: "6.14",
+			beat:     3,
+		},
+		{
+			name: "undated quarters last",
+			quarters: []QuarterlyEarnings{
+				quarter("", "9.00", "1.00"),
+				quarter("2024-03-31", "1.30", "1.40"),
+				quarter("None", "9.00", "1.00"),
+				quarter("2023-12-31", "2.00", "2.10"),
+				quarter("2023-09-30", "1.40", "1.50"),
+			},
+			trailing: "13.70", // The three dated quarters and the first undated one
+			miss:     3,
+		},
+	}
+	for _, tt := range tests {
+		response := &EarningsResponse{Symbol: "IBM", QuarterlyEarnings: tt.quarters}
+		parsed, err := response.Parse()
+		if err != nil {
+			t.Fatalf("%s: Parse() error = %v", tt.name, err)
+		}
+		if got := parsed.TrailingEPS.String(); got != tt.trailing {
+			t.Errorf("%s: TrailingEPS = %s, want %s", tt.name, got, tt.trailing)
+		}
+		if parsed.BeatStreak != tt.beat || parsed.MissStreak != tt.miss {
+			t.Errorf("%s: streaks = beat %d, miss %d, want beat %d, miss %d", tt.name, parsed.BeatStreak, parsed.MissStreak, tt.beat, tt.miss)
+		}
+		for i, q := range parsed.QuarterlyEarnings {
+			if q.ReportedEPS.String() != common.MustParseDecimal(tt.quarters[i].ReportedEPS).String() {
+				t.Errorf("%s: quarter %d reported %s, want the upstream order kept", tt.name, i, q.ReportedEPS)
+			}
+		}
+	}
+}
+
+func TestEarningsParseUndatedQuarter(t *testing.T) {
+	response := &EarningsResponse{QuarterlyEarnings: []QuarterlyEarnings{quarter("None", "1.00", "1.00")}}
+	parsed, err := response.Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.QuarterlyEarnings[0].FiscalDateEnding != nil {
+		t.Errorf("FiscalDateEnding = %v, want null", parsed.QuarterlyEarnings[0].FiscalDateEnding)
+	}
+
+	response = &EarningsResponse{QuarterlyEarnings: []QuarterlyEarnings{quarter("2024-13-45", "1.00", "1.00")}}
+	if _, err := response.Parse(); err == nil {
+		t.Error("Parse() accepted an invalid fiscal date")
+	}
+}
@@ -0,0 +1,253 @@
+package fundamental
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"stock/common"
+	"strings"
+	"sync"
+)
+
+// Unclassified groups holdings without a company overview, such as cash,
+// futures, other funds or listings Alpha Vantage does not cover
+const Unclassified = "UNCLASSIFIED"
+
+// overviewConcurrency bounds the overviews fetched at once, the rate limiter
+// queues the rest of the calls anyway
+const overviewConcurrency = 4
+
+// LookthroughParams holds parameters for a look-through of an ETF
+type LookthroughParams struct {
+	Symbol      string
+	MaxHoldings int // Largest holdings looked through, all when 0
+}
+
+// Exposure is the share of a fund held in one sector or country
+type Exposure struct {
+	Name     string         `json:"name"`
+	Weight   common.Decimal `json:"weight"`   // Fraction of the fund
+	Holdings int            `json:"holdings"` // Holdings contributing to it
+}
+
+// MissingHolding is a holding whose company overview could not be fetched
+type MissingHolding struct {
+	Symbol string         `json:"symbol"`
+	Weight common.Decimal `json:"weight"`
+	Error  string         `json:"error"`
+}
+
+// LookthroughHolding is a holding with the sector and country of its issuer
+type LookthroughHolding struct {
+	Symbol      string         `json:"symbol"`
+	Description string         `json:"description"`
+	Weight      common.Decimal `json:"weight"`
+	Sector      string         `json:"sector"`
+	Country     string         `json:"country"`
+}
+
+// Lookthrough is the exposure of an ETF to the sectors and countries of its holdings
+type Lookthrough struct {
+	Symbol          string               `json:"symbol"`
+	HoldingsWeight  common.Decimal       `json:"holdings_weight"`  // Weight of the holdings looked through
+	RemainderWeight common.Decimal       `json:"remainder_weight"` // Weight of the holdings left out by MaxHoldings
+	Sectors         []Exposure           `json:"sectors"`          // Largest first
+	Countries       []Exposure           `json:"countries"`        // Largest first
+	ReportedSectors []ParsedETFSector    `json:"reported_sectors"` // Sector weights as the fund reports them
+	Holdings        []LookthroughHolding `json:"holdings"`
+	Partial         bool                 `json:"partial"`           // Some overviews failed, their holdings are UNCLASSIFIED
+	Missing         []MissingHolding     `json:"missing,omitempty"` // Holdings whose overview failed
+}
+
+// GetLookthrough fetches the profile of an ETF and the company overview of
+// each of its largest holdings, then sums the holding weights by the
+// overview's sector and country. Overviews are cached, so funds sharing
+// constituents share the upstream calls. Holdings whose overview fails are
+// counted as Unclassified and listed in Missing instead of failing the whole
+// look-through, unless every overview fails.
+func GetLookthrough(ctx context.Context, client *common.Client, params LookthroughParams) (*Lookthrough, error) {
+	data, err := GetETFProfile(ctx, client, ETFProfileParams{Symbol: params.Symbol})
+	if err != nil {
+		return nil, err
+	}
+	profile, err := data.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	holdings := make([]ParsedETFHolding, 0, len(profile.Holdings))
+	for _, h := range profile.Holdings {
+		if h.Weight.Valid() {
+			holdings = append(holdings, h)
+		}
+	}
+	sort.SliceStable(holdings, func(i, j int) bool { return holdings[i].Weight.Cmp(holdings[j].Weight) > 0 })
+
+	result := &Lookthrough{
+		Symbol:          params.Symbol,
+		HoldingsWeight:  common.NewDecimal(0, 0),
+		RemainderWeight: common.NewDecimal(0, 0),
+		ReportedSectors: profile.Sectors,
+	}
+	if params.MaxHoldings > 0 && len(holdings) > params.MaxHoldings {
+		for _, h := range holdings[params.MaxHoldings:] {
+			result.RemainderWeight = result.RemainderWeight.Add(h.Weight)
+		}
+		holdings = holdings[:params.MaxHoldings]
+	}
+
+	overviews, errs := holdingOverviews(ctx, client, holdings)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sectors, countries := map[string]*Exposure{}, map[string]*Exposure{}
+	add := func(exposures map[string]*Exposure, name string, weight common.Decimal) {
+		exposure, ok := exposures[name]
+		if !ok {
+			exposure = &Exposure{Name: name, Weight: common.NewDecimal(0, 0)}
+			exposures[name] = exposure
+		}
+		exposure.Weight = exposure.Weight.Add(weight)
+		exposure.Holdings++
+	}
+
+	for i, h := range holdings {
+		holding := LookthroughHolding{
+			Symbol:      h.Symbol,
+			Description: h.Description,
+			Weight:      h.Weight,
+			Sector:      Unclassified,
+			Country:     Unclassified,
+		}
+		if overview := overviews[i]; overview != nil {
+			holding.Sector = classification(overview.Sector)
+			holding.Country = classification(overview.Country)
+		}
+		if err := errs[i]; err != nil {
+			result.Missing = append(result.Missing, MissingHolding{Symbol: h.Symbol, Weight: h.Weight, Error: err.Error()})
+		}
+		result.Holdings = append(result.Holdings, holding)
+		result.HoldingsWeight = result.HoldingsWeight.Add(h.Weight)
+		add(sectors, holding.Sector, h.Weight)
+		add(countries, holding.Country, h.Weight)
+	}
+
+	if n := len(result.Missing); n > 0 {
+		if n == fetched(holdings) {
+			return nil, fmt.Errorf("no holding overview could be fetched: %w", firstError(errs))
+		}
+		result.Partial = true
+	}
+
+	result.Sectors, result.Countries = sortedExposures(sectors), sortedExposures(countries)
+	return result, nil
+}
+
+// holdingOverviews fetches the company overview of every holding. Overviews
+// are nil for holdings Alpha Vantage has no overview of and for those that
+// failed, whose errors are returned at the same index. Once a call is rate
+// limited the holdings still waiting are not fetched, since they would only
+// spend more of the quota failing.
+func holdingOverviews(ctx context.Context, client *common.Client, holdings []ParsedETFHolding) ([]*CompanyOverviewResponse, []error) {
+	overviews := make([]*CompanyOverviewResponse, len(holdings))
+	errs := make([]error, len(holdings))
+
+	var mu sync.Mutex
+	var limited error
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, overviewConcurrency)
+	for i, h := range holdings {
+		symbol := lookupSymbol(h)
+		if symbol == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, symbol string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			mu.Lock()
+			skip := limited
+			mu.Unlock()
+			if skip != nil {
+				errs[i] = fmt.Errorf("not fetched: %w", skip)
+				return
+			}
+
+			overview, err := GetCompanyOverview(ctx, client, CompanyOverviewParams{Symbol: symbol})
+			switch {
+			case errors.Is(err, common.ErrInvalidSymbol):
+				return
+			case errors.Is(err, common.ErrRateLimited):
+				mu.Lock()
+				if limited == nil {
+					limited = err
+				}
+				mu.Unlock()
+			}
+			overviews[i], errs[i] = overview, err
+		}(i, symbol)
+	}
+	wg.Wait()
+	return overviews, errs
+}
+
+// lookupSymbol returns the symbol to fetch the overview of a holding with,
+// empty for holdings without one such as cash
+func lookupSymbol(h ParsedETFHolding) string {
+	symbol := strings.TrimSpace(h.Symbol)
+	if strings.EqualFold(symbol, "n/a") {
+		return ""
+	}
+	return symbol
+}
+
+// fetched counts the holdings an overview is requested for
+func fetched(holdings []ParsedETFHolding) int {
+	n := 0
+	for _, h := range holdings {
+		if lookupSymbol(h) != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// firstError returns the first non-nil error
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// classification normalizes an overview sector or country, Unclassified when missing
+func classification(value string) string {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" || value == "NONE" || value == "-" {
+		return Unclassified
+	}
+	return value
+}
+
+// sortedExposures orders exposures by weight, largest first, then by name
+func sortedExposures(exposures map[string]*Exposure) []Exposure {
+	sorted := make([]Exposure, 0, len(exposures))
+	for _, exposure := range exposures {
+		sorted = append(sorted, *exposure)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if cmp := sorted[i].Weight.Cmp(sorted[j].Weight); cmp != 0 {
+			return cmp > 0
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
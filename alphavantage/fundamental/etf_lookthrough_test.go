@@ -0,0 +1,109 @@
+package fundamental
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"stock/common"
+	"stock/common/commontest"
+)
+
+const testProfile = `{
+	"net_assets": "1000000",
+	"holdings": [
+		{"symbol": "AAA", "description": "A CORP", "weight": "0.4"},
+		{"symbol": "BBB", "description": "B CORP", "weight": "0.3"},
+		{"symbol": "n/a", "description": "CASH", "weight": "0.1"},
+		{"symbol": "FUND", "description": "OTHER FUND", "weight": "0.1"},
+		{"symbol": "ZZZ", "description": "SMALL CORP", "weight": "0.1"}
+	]
+}`
+
+func TestLookthroughDegradesOnFailedOverviews(t *testing.T) {
+	// AAA has an overview, FUND an empty one, which is not a failure, and every other overview fails
+	client := commontest.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case query.Get("function") == "ETF_PROFILE":
+			fmt.Fprint(w, testProfile)
+		case query.Get("symbol") == "AAA":
+			fmt.Fprint(w, `{"Sector": "TECHNOLOGY", "Country": "USA"}`)
+		case query.Get("symbol") == "FUND":
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	})
+
+	result, err := GetLookthrough(context.Background(), client, LookthroughParams{Symbol: "ETF", MaxHoldings: 4})
+	if err != nil {
+		t.Fatalf("GetLookthrough() error = %v", err)
+	}
+
+	if !result.Partial || len(result.Missing) != 1 || result.Missing[0].Symbol != "BBB" || result.Missing[0].Weight.String() != "0.3" {
+		t.Errorf("Partial = %v, Missing = %+v, want only BBB", result.Partial, result.Missing)
+	}
+	if result.HoldingsWeight.String() != "0.9" || result.RemainderWeight.String() != "0.1" {
+		t.Errorf("holdings weight %s, remainder %s, want 0.9 and 0.1", result.HoldingsWeight, result.RemainderWeight)
+	}
+
+	want := []Exposure{
+		{Name: Unclassified, Weight: common.MustParseDecimal("0.5"), Holdings: 3},
+		{Name: "TECHNOLOGY", Weight: common.MustParseDecimal("0.4"), Holdings: 1},
+	}
+	if len(result.Sectors) != len(want) {
+		t.Fatalf("Sectors = %+v, want %+v", result.Sectors, want)
+	}
+	for i, exposure := range result.Sectors {
+		if exposure.Name != want[i].Name || exposure.Weight.Cmp(want[i].Weight) != 0 || exposure.Holdings != want[i].Holdings {
+			t.Errorf("Sectors[%d] = %+v, want %+v", i, exposure, want[i])
+		}
+	}
+}
+
+func TestLookthroughStopsAfterRateLimit(t *testing.T) {
+	// AAA has an overview, the overview after it is rate limited
+	client := commontest.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case query.Get("function") == "ETF_PROFILE":
+			fmt.Fprint(w, testProfile)
+		case query.Get("symbol") == "AAA":
+			fmt.Fprint(w, `{"Sector": "TECHNOLOGY", "Country": "USA"}`)
+		default:
+			fmt.Fprint(w, `{"Note": "Our standard API call frequency is 5 calls per minute."}`)
+		}
+	})
+
+	result, err := GetLookthrough(context.Background(), client, LookthroughParams{Symbol: "ETF"})
+	if err != nil {
+		t.Fatalf("GetLookthrough() error = %v", err)
+	}
+	if !result.Partial || len(result.Missing) != 3 {
+		t.Errorf("Missing = %+v, want BBB, FUND and ZZZ", result.Missing)
+	}
+	for _, missing := range result.Missing {
+		if missing.Error == "" {
+			t.Errorf("%s is missing without an error", missing.Symbol)
+		}
+	}
+}
+
+func TestLookthroughFailsWhenEveryOverviewFails(t *testing.T) {
+	// Every overview is rate limited
+	client := commontest.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("function") == "ETF_PROFILE" {
+			fmt.Fprint(w, testProfile)
+			return
+		}
+		fmt.Fprint(w, `{"Note": "Our standard API call frequency is 5 calls per minute."}`)
+	})
+
+	_, err := GetLookthrough(context.Background(), client, LookthroughParams{Symbol: "ETF"})
+	if !errors.Is(err, common.ErrRateLimited) {
+		t.Fatalf("GetLookthrough() error = %v, want ErrRateLimited", err)
+	}
+}
@@ -0,0 +1,108 @@
+package fundamental
+
+import (
+	"context"
+	"fmt"
+	"stock/common"
+	"time"
+)
+
+// ETFProfileParams holds parameters for retrieving an ETF profile
+type ETFProfileParams struct {
+	Symbol string
+}
+
+// ETFProfileResponse defines the structure of ETF_PROFILE
+type ETFProfileResponse struct {
+	Symbol            string       `json:"symbol"`
+	NetAssets         string       `json:"net_assets"`
+	NetExpenseRatio   string       `json:"net_expense_ratio"`
+	PortfolioTurnover string       `json:"portfolio_turnover"`
+	DividendYield     string       `json:"dividend_yield"`
+	InceptionDate     string       `json:"inception_date"`
+	Leveraged         string       `json:"leveraged"`
+	Sectors           []ETFSector  `json:"sectors"`
+	Holdings          []ETFHolding `json:"holdings"`
+}
+
+// ETFSector is the weight of a sector in the fund
+type ETFSector struct {
+	Sector string `json:"sector"`
+	Weight string `json:"weight"`
+}
+
+// ETFHolding is the weight of a constituent in the fund
+type ETFHolding struct {
+	Symbol      string `json:"symbol"`
+	Description string `json:"description"`
+	Weight      string `json:"weight"`
+}
+
+// ParsedETFProfile is the typed form of ETFProfileResponse. Ratios and
+// weights are fractions, 0.0945 is 9.45%.
+type ParsedETFProfile struct {
+	Symbol            string             `json:"symbol"`
+	NetAssets         common.Decimal     `json:"net_assets"`
+	NetExpenseRatio   common.Decimal     `json:"net_expense_ratio"`
+	PortfolioTurnover common.Decimal     `json:"portfolio_turnover"`
+	DividendYield     common.Decimal     `json:"dividend_yield"`
+	InceptionDate     *time.Time         `json:"inception_date"`
+	Leveraged         bool               `json:"leveraged"`
+	Sectors           []ParsedETFSector  `json:"sectors"`
+	Holdings          []ParsedETFHolding `json:"holdings"`
+}
+
+// ParsedETFSector is the typed form of ETFSector
+type ParsedETFSector struct {
+	Sector string         `json:"sector"`
+	Weight common.Decimal `json:"weight"`
+}
+
+// ParsedETFHolding is the typed form of ETFHolding
+type ParsedETFHolding struct {
+	Symbol      string         `json:"symbol"`
+	Description string         `json:"description"`
+	Weight      common.Decimal `json:"weight"`
+}
+
+// Parse converts the ETF profile strings into typed values
+func (r *ETFProfileResponse) Parse() (*ParsedETFProfile, error) {
+	parsed := &ParsedETFProfile{
+		Sectors:  make([]ParsedETFSector, len(r.Sectors)),
+		Holdings: make([]ParsedETFHolding, len(r.Holdings)),
+	}
+	if err := parseFields(r, parsed); err != nil {
+		return nil, err
+	}
+
+	for i := range r.Sectors {
+		if err := parseFields(&r.Sectors[i], &parsed.Sectors[i]); err != nil {
+			return nil, fmt.Errorf("sector %s: %w", r.Sectors[i].Sector, err)
+		}
+	}
+	for i := range r.Holdings {
+		if err := parseFields(&r.Holdings[i], &parsed.Holdings[i]); err != nil {
+			return nil, fmt.Errorf("holding %s: %w", r.Holdings[i].Symbol, err)
+		}
+	}
+	return parsed, nil
+}
+
+// GetETFProfile fetches the profile, sector weights and holdings of an ETF from Alpha Vantage API
+func GetETFProfile(ctx context.Context, client *common.Client, params ETFProfileParams) (*ETFProfileResponse, error) {
+	// Building query parameters
+	queryParams := map[string]string{
+		"function": "ETF_PROFILE",
+		"symbol":   params.Symbol,
+	}
+
+	// Make HTTP request and parse the response
+	result := &ETFProfileResponse{
+		Symbol: params.Symbol,
+	}
+	if err := client.GetJSON(ctx, queryParams, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
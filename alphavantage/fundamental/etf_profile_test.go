@@ -0,0 +1,92 @@
+package fundamental
+
+import (
+	"encoding/json"
+	"testing"
+
+	"stock/common"
+)
+
+func TestETFProfileParse(t *testing.T) {
+	var response ETFProfileResponse
+	err := json.Unmarshal([]byte(`{
+		"net_assets": "284600000000",
+		"net_expense_ratio": "0.002",
+		"portfolio_turnover": "0.08",
+		"dividend_yield": "n/a",
+		"inception_date": "1999-03-10",
+		"leveraged": "NO",
+		"sectors": [{"sector": "INFORMATION TECHNOLOGY", "weight": "0.514"}],
+		"holdings": [
+			{"symbol": "MSFT", "description": "MICROSOFT CORP", "weight": "0.0883"},
+			{"symbol": "n/a", "description": "CASH", "weight": "n/a"}
+		]
+	}`), &response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Symbol = "QQQ"
+
+	parsed, err := response.Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.Symbol != "QQQ" || parsed.Leveraged {
+		t.Errorf("Symbol = %s, Leveraged = %v, want QQQ and false", parsed.Symbol, parsed.Leveraged)
+	}
+	for _, field := range []struct {
+		name      string
+		got, want string
+	}{
+		{"NetAssets", parsed.NetAssets.String(), "284600000000"},
+		{"NetExpenseRatio", parsed.NetExpenseRatio.String(), "0.002"},
+		{"PortfolioTurnover", parsed.PortfolioTurnover.String(), "0.08"},
+		{"DividendYield", parsed.DividendYield.String(), "None"}, // "n/a" is null
+		{"Sectors[0].Weight", parsed.Sectors[0].Weight.String(), "0.514"},
+		{"Holdings[0].Weight", parsed.Holdings[0].Weight.String(), "0.0883"},
+		{"Holdings[1].Weight", parsed.Holdings[1].Weight.String(), "None"},
+	} {
+		if field.got != field.want {
+			t.Errorf("%s = %s, want %s", field.name, field.got, field.want)
+		}
+	}
+	if parsed.InceptionDate == nil || parsed.InceptionDate.Format(common.DateLayout) != "1999-03-10" {
+		t.Errorf("InceptionDate = %v, want 1999-03-10", parsed.InceptionDate)
+	}
+	if parsed.Holdings[1].Symbol != "n/a" || parsed.Holdings[1].Description != "CASH" {
+		t.Errorf("Holdings[1] = %+v, want the cash line kept as is", parsed.Holdings[1])
+	}
+}
+
+func TestETFProfileParseLeveraged(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    bool
+		wantErr bool
+	}{
+		{"YES", true, false},
+		{"yes", true, false},
+		{"NO", false, false},
+		{"", false, false},
+		{"n/a", false, false},
+		{"MAYBE", false, true},
+		{"2x", false, true},
+	}
+	for _, tt := range tests {
+		response := &ETFProfileResponse{Leveraged: tt.value}
+		parsed, err := response.Parse()
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Parse(leveraged %q) succeeded, want an invalid flag error", tt.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(leveraged %q) error = %v", tt.value, err)
+			continue
+		}
+		if parsed.Leveraged != tt.want {
+			t.Errorf("Parse(leveraged %q).Leveraged = %v, want %v", tt.value, parsed.Leveraged, tt.want)
+		}
+	}
+}
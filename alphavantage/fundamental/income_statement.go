@@ -1,9 +1,10 @@
 package fundamental
 
 import (
-	"encoding/json"
+	"context"
+	"fmt"
 	"stock/common"
-	"stock/config"
+	"time"
 )
 
 // IncomeStatementParams holds parameters for retrieving income statement data
@@ -48,28 +49,80 @@ type IncomeStatementReport struct {
 	NetIncome                         string `json:"netIncome"`
 }
 
-// GetIncomeStatement retrieves income statement data for a given symbol
-func GetIncomeStatement(params IncomeStatementParams) (*IncomeStatementResponse, error) {
-	cfg := config.GetConfig()
+// ParsedIncomeStatement is the typed form of IncomeStatementResponse
+type ParsedIncomeStatement struct {
+	Symbol           string                        `json:"symbol"`
+	AnnualReports    []ParsedIncomeStatementReport `json:"annualReports"`
+	QuarterlyReports []ParsedIncomeStatementReport `json:"quarterlyReports"`
+}
+
+// ParsedIncomeStatementReport is a income statement report with exact numbers, missing values are null
+type ParsedIncomeStatementReport struct {
+	FiscalDateEnding                  *time.Time     `json:"fiscalDateEnding"`
+	ReportedCurrency                  string         `json:"reportedCurrency"`
+	GrossProfit                       common.Decimal `json:"grossProfit"`
+	TotalRevenue                      common.Decimal `json:"totalRevenue"`
+	CostOfRevenue                     common.Decimal `json:"costOfRevenue"`
+	CostofGoodsAndServicesSold        common.Decimal `json:"costofGoodsAndServicesSold"`
+	OperatingIncome                   common.Decimal `json:"operatingIncome"`
+	SellingGeneralAndAdministrative   common.Decimal `json:"sellingGeneralAndAdministrative"`
+	ResearchAndDevelopment            common.Decimal `json:"researchAndDevelopment"`
+	OperatingExpenses                 common.Decimal `json:"operatingExpenses"`
+	InvestmentIncomeNet               common.Decimal `json:"investmentIncomeNet"`
+	NetInterestIncome                 common.Decimal `json:"netInterestIncome"`
+	InterestIncome                    common.Decimal `json:"interestIncome"`
+	InterestExpense                   common.Decimal `json:"interestExpense"`
+	NonInterestIncome                 common.Decimal `json:"nonInterestIncome"`
+	OtherNonOperatingIncome           common.Decimal `json:"otherNonOperatingIncome"`
+	Depreciation                      common.Decimal `json:"depreciation"`
+	DepreciationAndAmortization       common.Decimal `json:"depreciationAndAmortization"`
+	IncomeBeforeTax                   common.Decimal `json:"incomeBeforeTax"`
+	IncomeTaxExpense                  common.Decimal `json:"incomeTaxExpense"`
+	InterestAndDebtExpense            common.Decimal `json:"interestAndDebtExpense"`
+	NetIncomeFromContinuingOperations common.Decimal `json:"netIncomeFromContinuingOperations"`
+	ComprehensiveIncomeNetOfTax       common.Decimal `json:"comprehensiveIncomeNetOfTax"`
+	Ebit                              common.Decimal `json:"ebit"`
+	Ebitda                            common.Decimal `json:"ebitda"`
+	NetIncome                         common.Decimal `json:"netIncome"`
+}
+
+// Parse converts the raw string report values into typed ones
+func (r *IncomeStatementResponse) Parse() (*ParsedIncomeStatement, error) {
+	parsed := &ParsedIncomeStatement{Symbol: r.Symbol}
 
+	var err error
+	if parsed.AnnualReports, err = parseIncomeStatementReports(r.AnnualReports); err != nil {
+		return nil, err
+	}
+	if parsed.QuarterlyReports, err = parseIncomeStatementReports(r.QuarterlyReports); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+func parseIncomeStatementReports(reports []IncomeStatementReport) ([]ParsedIncomeStatementReport, error) {
+	parsed := make([]ParsedIncomeStatementReport, len(reports))
+	for i := range reports {
+		if err := parseFields(&reports[i], &parsed[i]); err != nil {
+			return nil, fmt.Errorf("report %s: %w", reports[i].FiscalDateEnding, err)
+		}
+	}
+	return parsed, nil
+}
+
+// GetIncomeStatement retrieves income statement data for a given symbol
+func GetIncomeStatement(ctx context.Context, client *common.Client, params IncomeStatementParams) (*IncomeStatementResponse, error) {
 	// Building query parameters
 	queryParams := map[string]string{
 		"function": "INCOME_STATEMENT",
 		"symbol":   params.Symbol,
-		"apikey":   cfg.AlphaVantageAPIKey,
-	}
-	// Make HTTP request
-	respBody, err := common.GetAPIRequest(cfg.AlphaVantageBaseURL, queryParams)
-	if err != nil {
-		return nil, err
 	}
-	defer respBody.Close()
 
-	// Parse the response
+	// Make HTTP request and parse the response
 	result := &IncomeStatementResponse{
 		Symbol: params.Symbol,
 	}
-	if err := json.NewDecoder(respBody).Decode(result); err != nil {
+	if err := client.GetJSON(ctx, queryParams, result); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,70 @@
+package fundamental
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"stock/common"
+)
+
+var (
+	decimalType = reflect.TypeOf(common.Decimal{})
+	timeType    = reflect.TypeOf(time.Time{})
+	timePtrType = reflect.TypeOf(&time.Time{})
+	stringType  = reflect.TypeOf("")
+	boolType    = reflect.TypeOf(false)
+)
+
+// parseFields fills the typed struct dst from the raw string struct src.
+// Fields are matched by name and converted according to the type in dst:
+// common.Decimal for numbers, time.Time or *time.Time for dates, bool for
+// YES/NO flags and string for text. Fields missing from src are left untouched.
+func parseFields(src, dst interface{}) error {
+	srcVal := reflect.ValueOf(src).Elem()
+	dstVal := reflect.ValueOf(dst).Elem()
+	dstType := dstVal.Type()
+
+	for i := 0; i < dstType.NumField(); i++ {
+		field := dstType.Field(i)
+		raw := srcVal.FieldByName(field.Name)
+		if !raw.IsValid() || raw.Type() != stringType {
+			continue
+		}
+		value := raw.String()
+
+		switch field.Type {
+		case stringType:
+			dstVal.Field(i).SetString(value)
+		case decimalType:
+			d, err := common.ParseDecimal(value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", field.Name, err)
+			}
+			dstVal.Field(i).Set(reflect.ValueOf(d))
+		case timeType, timePtrType:
+			t, err := common.ParseDate(value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", field.Name, err)
+			}
+			if field.Type == timePtrType {
+				dstVal.Field(i).Set(reflect.ValueOf(t))
+			} else if t != nil {
+				dstVal.Field(i).Set(reflect.ValueOf(*t))
+			}
+		case boolType:
+			switch strings.ToUpper(strings.TrimSpace(value)) {
+			case "YES", "TRUE":
+				dstVal.Field(i).SetBool(true)
+			case "NO", "FALSE", "", "NONE", "N/A":
+			default:
+				return fmt.Errorf("%s: invalid flag %q", field.Name, value)
+			}
+		default:
+			return fmt.Errorf("%s: unsupported parsed type %s", field.Name, field.Type)
+		}
+	}
+
+	return nil
+}
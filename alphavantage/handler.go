@@ -0,0 +1,41 @@
+package alphavantage
+
+import (
+	"context"
+	"strconv"
+
+	"stock/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler serves the v1 API endpoints using an Alpha Vantage client
+type Handler struct {
+	client *Client
+}
+
+// NewHandler creates a handler backed by the given client
+func NewHandler(client *Client) *Handler {
+	return &Handler{client: client}
+}
+
+// requestContext returns the context for upstream calls made while serving c,
+// recording whether they were answered from cache
+func requestContext(c *gin.Context) (context.Context, *common.CacheStatus) {
+	return common.WithCacheStatus(c.Request.Context())
+}
+
+// setCacheHeader reports the cache outcome in the X-Cache header and returns it
+func setCacheHeader(c *gin.Context, status *common.CacheStatus) string {
+	value := status.String()
+	if value != "" {
+		c.Header("X-Cache", value)
+	}
+	return value
+}
+
+// rawRequested reports whether the caller asked for the original Alpha Vantage strings
+func rawRequested(c *gin.Context) bool {
+	raw, err := strconv.ParseBool(c.DefaultQuery("raw", "false"))
+	return err == nil && raw
+}
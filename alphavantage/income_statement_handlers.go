@@ -1,7 +1,6 @@
 package alphavantage
 
 import (
-	"net/http"
 	"time"
 
 	"stock/alphavantage/fundamental"
@@ -12,22 +11,32 @@ import (
 // IncomeStatementResponse defines the response format for income statement data
 // @Description Income statement response data structure
 type IncomeStatementResponse struct {
-	Version   string                               `json:"version"`
-	Timestamp string                               `json:"timestamp"`
-	Symbol    string                               `json:"symbol"`
-	Data      *fundamental.IncomeStatementResponse `json:"data"`
+	Version   string      `json:"version"`
+	Timestamp string      `json:"timestamp"`
+	Symbol    string      `json:"symbol"`
+	Cache     string      `json:"cache,omitempty"`
+	Data      interface{} `json:"data"` // *fundamental.ParsedIncomeStatement, or *fundamental.IncomeStatementResponse when raw=true
 }
 
 // GetIncomeStatement handles requests for income statement data
 // @Summary Get income statement data for a specific symbol
 // @Description Returns the income statement data for the specified stock symbol
 // @Tags fundamental
-// @Produce json
+// @Produce json,text/csv,application/x-ndjson
 // @Param symbol path string true "Stock symbol (e.g., AAPL, MSFT)"
-// @Success 200 {object} IncomeStatementResponse "Successful operation"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Param raw query boolean false "Serve the original Alpha Vantage strings instead of typed values" default(false)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} IncomeStatementResponse{data=fundamental.ParsedIncomeStatement} "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
 // @Router /v1/fundamental/income-statement/{symbol} [get]
-func GetIncomeStatement(c *gin.Context) {
+func (h *Handler) GetIncomeStatement(c *gin.Context) {
 	symbol := c.Param("symbol")
 
 	// Create params for the fundamental library
@@ -35,22 +44,35 @@ func GetIncomeStatement(c *gin.Context) {
 		Symbol: symbol,
 	}
 
+	ctx, cache := requestContext(c)
+
 	// Get income statement data
-	data, err := fundamental.GetIncomeStatement(params)
+	data, err := h.client.GetIncomeStatement(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
+	// Serve typed values unless the caller asked for the original strings
+	var body interface{} = data
+	rows := statementRows(data.AnnualReports, data.QuarterlyReports)
+	if !rawRequested(c) {
+		parsed, err := data.Parse()
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		body, rows = parsed, statementRows(parsed.AnnualReports, parsed.QuarterlyReports)
+	}
+
 	// Create response with versioning
 	response := IncomeStatementResponse{
 		Version:   "1.0", // TODO: Replace with config value once GetConfig() is implemented
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		Symbol:    symbol,
-		Data:      data,
+		Cache:     setCacheHeader(c, cache),
+		Data:      body,
 	}
 
-	c.JSON(http.StatusOK, response)
+	respond(c, response, rows)
 }
@@ -0,0 +1,238 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"stock/alphavantage/timeseries"
+	"stock/common"
+)
+
+// Series types selecting the price an indicator is computed from
+const (
+	SeriesOpen  = "open"
+	SeriesHigh  = "high"
+	SeriesLow   = "low"
+	SeriesClose = "close"
+)
+
+// Params tunes an indicator, zero values select the indicator's defaults
+type Params struct {
+	Period       int     `json:"period,omitempty"`        // Look-back period in bars
+	SeriesType   string  `json:"series_type,omitempty"`   // open, high, low or close, close when empty
+	FastPeriod   int     `json:"fast_period,omitempty"`   // MACD fast EMA period, 12 by default
+	SlowPeriod   int     `json:"slow_period,omitempty"`   // MACD slow EMA period, 26 by default
+	SignalPeriod int     `json:"signal_period,omitempty"` // MACD signal and Stochastic %D period, 9 and 3 by default
+	StdDev       float64 `json:"stddev,omitempty"`        // Bollinger band width in standard deviations, 2 by default
+}
+
+// Point is the value of an indicator at the time of a bar
+type Point struct {
+	Time   time.Time          `json:"time"`
+	Values map[string]float64 `json:"values"`
+}
+
+// Series is an indicator computed over a bar series. Bars before the
+// indicator has enough history are left out, like Alpha Vantage does.
+type Series struct {
+	Name    string   `json:"name"`
+	Params  Params   `json:"params"`
+	Columns []string `json:"columns"` // Keys of each point's values in output order
+	Points  []Point  `json:"points"`
+}
+
+// Indicator describes a computable indicator
+type Indicator struct {
+	Name     string
+	Columns  []string
+	Intraday bool // Only meaningful on intraday bars
+
+	defaults Params
+	compute  func(bars []timeseries.Bar, p Params) [][]float64 // One slice per column, NaN while warming up
+}
+
+// registry holds the indicators by lower case name
+var registry = map[string]Indicator{}
+
+func register(indicator Indicator) {
+	registry[indicator.Name] = indicator
+}
+
+// Lookup returns the indicator with the given name in any case
+func Lookup(name string) (Indicator, bool) {
+	indicator, ok := registry[strings.ToLower(name)]
+	return indicator, ok
+}
+
+// Names lists the available indicators in alphabetical order
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Compute runs the indicator over bars in chronological order
+func (ind Indicator) Compute(bars []timeseries.Bar, p Params) (*Series, error) {
+	p = ind.withDefaults(p)
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", common.ErrInvalidParameter, ind.Name, err)
+	}
+
+	values := ind.compute(bars, p)
+	series := &Series{Name: ind.Name, Params: p, Columns: ind.Columns, Points: []Point{}}
+	for i, bar := range bars {
+		point := Point{Time: bar.Time, Values: make(map[string]float64, len(values))}
+		for j, column := range values {
+			if math.IsNaN(column[i]) {
+				break
+			}
+			point.Values[ind.Columns[j]] = column[i]
+		}
+		if len(point.Values) == len(values) {
+			series.Points = append(series.Points, point)
+		}
+	}
+	return series, nil
+}
+
+// withDefaults fills the parameters left zero with the indicator's defaults
+func (ind Indicator) withDefaults(p Params) Params {
+	if p.Period == 0 {
+		p.Period = ind.defaults.Period
+	}
+	if p.SeriesType == "" {
+		p.SeriesType = SeriesClose
+	}
+	if p.FastPeriod == 0 {
+		p.FastPeriod = ind.defaults.FastPeriod
+	}
+	if p.SlowPeriod == 0 {
+		p.SlowPeriod = ind.defaults.SlowPeriod
+	}
+	if p.SignalPeriod == 0 {
+		p.SignalPeriod = ind.defaults.SignalPeriod
+	}
+	if p.StdDev == 0 {
+		p.StdDev = ind.defaults.StdDev
+	}
+	return p
+}
+
+// validate rejects parameters no indicator can be computed with
+func (p Params) validate() error {
+	switch p.SeriesType {
+	case SeriesOpen, SeriesHigh, SeriesLow, SeriesClose:
+	default:
+		return fmt.Errorf("series_type must be %s, %s, %s or %s", SeriesOpen, SeriesHigh, SeriesLow, SeriesClose)
+	}
+	if p.Period < 0 || p.FastPeriod < 0 || p.SlowPeriod < 0 || p.SignalPeriod < 0 {
+		return fmt.Errorf("periods must be positive")
+	}
+	if p.FastPeriod > 0 && p.SlowPeriod > 0 && p.FastPeriod >= p.SlowPeriod {
+		return fmt.Errorf("fast period must be shorter than the slow period")
+	}
+	if p.StdDev < 0 || math.IsNaN(p.StdDev) || math.IsInf(p.StdDev, 0) {
+		return fmt.Errorf("standard deviations must be positive")
+	}
+	return nil
+}
+
+// prices returns the price of each bar selected by the series type
+func prices(bars []timeseries.Bar, seriesType string) []float64 {
+	values := make([]float64, len(bars))
+	for i, bar := range bars {
+		switch seriesType {
+		case SeriesOpen:
+			values[i] = bar.Open
+		case SeriesHigh:
+			values[i] = bar.High
+		case SeriesLow:
+			values[i] = bar.Low
+		default:
+			values[i] = bar.Close
+		}
+	}
+	return values
+}
+
+// nans returns a slice of n NaN values, the warm-up marker
+func nans(n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = math.NaN()
+	}
+	return values
+}
+
+// single adapts a one-column price indicator to the compute signature
+func single(fn func(values []float64, period int) []float64) func([]timeseries.Bar, Params) [][]float64 {
+	return func(bars []timeseries.Bar, p Params) [][]float64 {
+		return [][]float64{fn(prices(bars, p.SeriesType), p.Period)}
+	}
+}
+
+func init() {
+	for name, fn := range map[string]func([]float64, int) []float64{
+		"sma": SMA, "ema": EMA, "wma": WMA, "dema": DEMA, "tema": TEMA,
+	} {
+		register(Indicator{Name: name, Columns: []string{strings.ToUpper(name)}, defaults: Params{Period: 20}, compute: single(fn)})
+	}
+	register(Indicator{Name: "rsi", Columns: []string{"RSI"}, defaults: Params{Period: 14}, compute: single(RSI)})
+
+	register(Indicator{
+		Name:     "macd",
+		Columns:  []string{"MACD", "MACD_Signal", "MACD_Hist"},
+		defaults: Params{FastPeriod: 12, SlowPeriod: 26, SignalPeriod: 9},
+		compute: func(bars []timeseries.Bar, p Params) [][]float64 {
+			macd, signal, hist := MACD(prices(bars, p.SeriesType), p.FastPeriod, p.SlowPeriod, p.SignalPeriod)
+			return [][]float64{macd, signal, hist}
+		},
+	})
+	register(Indicator{
+		Name:     "bbands",
+		Columns:  []string{"Real Upper Band", "Real Middle Band", "Real Lower Band"},
+		defaults: Params{Period: 20, StdDev: 2},
+		compute: func(bars []timeseries.Bar, p Params) [][]float64 {
+			upper, middle, lower := BollingerBands(prices(bars, p.SeriesType), p.Period, p.StdDev)
+			return [][]float64{upper, middle, lower}
+		},
+	})
+	register(Indicator{
+		Name:     "atr",
+		Columns:  []string{"ATR"},
+		defaults: Params{Period: 14},
+		compute: func(bars []timeseries.Bar, p Params) [][]float64 {
+			return [][]float64{ATR(bars, p.Period)}
+		},
+	})
+	register(Indicator{
+		Name:     "stoch",
+		Columns:  []string{"FastK", "FastD"},
+		defaults: Params{Period: 14, SignalPeriod: 3},
+		compute: func(bars []timeseries.Bar, p Params) [][]float64 {
+			k, d := Stochastic(bars, p.Period, p.SignalPeriod)
+			return [][]float64{k, d}
+		},
+	})
+	register(Indicator{
+		Name:    "obv",
+		Columns: []string{"OBV"},
+		compute: func(bars []timeseries.Bar, p Params) [][]float64 {
+			return [][]float64{OBV(bars)}
+		},
+	})
+	register(Indicator{
+		Name:     "vwap",
+		Columns:  []string{"VWAP"},
+		Intraday: true,
+		compute: func(bars []timeseries.Bar, p Params) [][]float64 {
+			return [][]float64{VWAP(bars)}
+		},
+	})
+}
@@ -0,0 +1,259 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"stock/alphavantage/timeseries"
+)
+
+// stockChartsCloses is the 10-day moving average example of the StockCharts
+// ChartSchool, whose tables round to cents
+var stockChartsCloses = []float64{
+	22.27, 22.19, 22.08, 22.17, 22.18, 22.13, 22.23, 22.43, 22.24, 22.29,
+	22.15, 22.39, 22.38, 22.61, 23.36, 24.05, 23.75, 23.83, 23.95, 23.63,
+	23.82, 23.87, 23.65, 23.19, 23.10, 23.33, 22.68, 23.10, 22.40, 22.17,
+}
+
+// wilderCloses is the 14-day RSI example of the StockCharts ChartSchool
+var wilderCloses = []float64{
+	44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08,
+	45.89, 46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64,
+	46.21, 46.25, 45.71, 46.45, 45.78, 45.35, 44.03, 44.18, 44.22, 44.57,
+	43.42, 42.66, 43.13,
+}
+
+// linear returns 0, 1, ..., n-1
+func linear(n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	return values
+}
+
+// checkSeries compares values against want, which starts at the first
+// index past the warm-up; every earlier value must be NaN
+func checkSeries(t *testing.T, name string, got []float64, warmup int, want []float64, tolerance float64) {
+	t.Helper()
+	for i := 0; i < warmup && i < len(got); i++ {
+		if !math.IsNaN(got[i]) {
+			t.Errorf("%s[%d] = %g during warm-up, want NaN", name, i, got[i])
+		}
+	}
+	if warmup < len(got) && math.IsNaN(got[warmup]) {
+		t.Errorf("%s[%d] = NaN, want the first value", name, warmup)
+	}
+	for j, w := range want {
+		i := warmup + j
+		if i >= len(got) {
+			t.Errorf("%s has %d values, want %d", name, len(got), warmup+len(want))
+			return
+		}
+		if math.Abs(got[i]-w) > tolerance {
+			t.Errorf("%s[%d] = %.4f, want %.4f", name, i, got[i], w)
+		}
+	}
+}
+
+func TestMovingAverages(t *testing.T) {
+	tests := []struct {
+		name      string
+		got       []float64
+		warmup    int
+		want      []float64
+		tolerance float64
+	}{
+		{"SMA", SMA(stockChartsCloses, 10), 9, []float64{
+			22.22, 22.21, 22.23, 22.26, 22.30, 22.42, 22.61, 22.77, 22.91, 23.08, 23.21,
+			23.38, 23.52, 23.65, 23.71, 23.68, 23.61, 23.50, 23.43, 23.28, 23.13,
+		}, 0.011},
+		{"EMA", EMA(stockChartsCloses, 10), 9, []float64{
+			22.22, 22.21, 22.24, 22.27, 22.33, 22.52, 22.80, 22.97, 23.13, 23.28, 23.34,
+			23.43, 23.51, 23.54, 23.47, 23.40, 23.39, 23.26, 23.23, 23.08, 22.92,
+		}, 0.011},
+		{"WMA", WMA([]float64{1, 2, 3, 4, 5}, 3), 2, []float64{14.0 / 6, 20.0 / 6, 26.0 / 6}, 1e-9},
+		// An EMA lags a straight line by (period-1)/2, which DEMA and TEMA cancel exactly
+		{"EMA line", EMA(linear(40), 5), 4, []float64{2, 3, 4, 5}, 1e-9},
+		{"DEMA", DEMA(linear(40), 5), 8, []float64{8, 9, 10, 11}, 1e-9},
+		{"TEMA", TEMA(linear(40), 5), 12, []float64{12, 13, 14, 15}, 1e-9},
+	}
+	for _, tt := range tests {
+		checkSeries(t, tt.name, tt.got, tt.warmup, tt.want, tt.tolerance)
+	}
+
+	for name, out := range map[string][]float64{
+		"SMA": SMA(stockChartsCloses[:5], 10), "EMA": EMA(stockChartsCloses[:5], 10), "SMA period 0": SMA(stockChartsCloses, 0),
+	} {
+		checkSeries(t, name, out, len(out), nil, 0)
+	}
+}
+
+func TestRSI(t *testing.T) {
+	// TA-Lib values, StockCharts rounds each change and lands within 0.1 of them
+	want := []float64{
+		70.46, 66.25, 66.48, 69.35, 66.29, 57.92, 62.88, 63.21, 56.01, 62.34,
+		54.67, 50.39, 40.02, 41.49, 41.90, 45.50, 37.32, 33.09, 37.79,
+	}
+	checkSeries(t, "RSI", RSI(wilderCloses, 14), 14, want, 0.005)
+	checkSeries(t, "RSI rising", RSI(linear(5), 3), 3, []float64{100, 100}, 0)
+	checkSeries(t, "RSI flat", RSI([]float64{5, 5, 5, 5}, 3), 3, []float64{50}, 0)
+	checkSeries(t, "RSI short", RSI(linear(3), 3), 3, nil, 0)
+}
+
+func TestMACD(t *testing.T) {
+	// The 12 and 26 bar EMAs of a line lag it by 5.5 and 12.5 bars
+	macd, signal, hist := MACD(linear(40), 12, 26, 9)
+	checkSeries(t, "MACD", macd, 25, []float64{7, 7, 7}, 1e-9)
+	checkSeries(t, "MACD signal", signal, 33, []float64{7, 7}, 1e-9)
+	checkSeries(t, "MACD histogram", hist, 33, []float64{0, 0}, 1e-9)
+}
+
+func TestBollingerBands(t *testing.T) {
+	// The population standard deviation of this window is exactly 2
+	values := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	upper, middle, lower := BollingerBands(values, 8, 2)
+	checkSeries(t, "middle", middle, 7, []float64{5}, 1e-9)
+	checkSeries(t, "upper", upper, 7, []float64{9}, 1e-9)
+	checkSeries(t, "lower", lower, 7, []float64{1}, 1e-9)
+
+	upper, _, _ = BollingerBands(values[:3], 8, 2)
+	checkSeries(t, "short upper", upper, 3, nil, 0)
+}
+
+// bar builds a bar at day offset d of a fixed date
+func bar(d int, high, low, close, volume float64) timeseries.Bar {
+	return timeseries.Bar{
+		Time:   time.Date(2024, time.March, 4+d, 16, 0, 0, 0, time.UTC),
+		Open:   close,
+		High:   high,
+		Low:    low,
+		Close:  close,
+		Volume: volume,
+	}
+}
+
+func TestBarIndicators(t *testing.T) {
+	bars := []timeseries.Bar{
+		bar(0, 10, 8, 9, 100),
+		bar(1, 11, 9, 10, 200),  // True range 2
+		bar(2, 12, 10, 12, 300), // True range 2
+		bar(3, 15, 12, 13, 400), // True range 3, from the previous close
+		bar(4, 13, 9, 9, 500),   // True range 4
+		bar(5, 10, 8, 9, 600),   // True range 2, unchanged close
+	}
+
+	// First ATR is the mean of the first 3 true ranges, then Wilder smoothing
+	checkSeries(t, "ATR", ATR(bars, 3), 3, []float64{7.0 / 3, (14.0/3 + 4) / 3, ((14.0/3+4)/3*2 + 2) / 3}, 1e-9)
+	checkSeries(t, "ATR short", ATR(bars[:3], 3), 3, nil, 0)
+
+	k, d := Stochastic(bars, 3, 2)
+	// Window lows and highs: [8, 12], [9, 15], [9, 15], [8, 15]
+	checkSeries(t, "%K", k, 2, []float64{100, 100 * 4.0 / 6, 0, 100.0 / 7}, 1e-9)
+	checkSeries(t, "%D", d, 3, []float64{(100 + 400.0/6) / 2, 200.0 / 6, 50.0 / 7}, 1e-9)
+
+	checkSeries(t, "OBV", OBV(bars), 0, []float64{100, 300, 600, 1000, 500, 500}, 0)
+}
+
+func TestStochasticHugePeriods(t *testing.T) {
+	bars := []timeseries.Bar{bar(0, 10, 8, 9, 1), bar(1, 11, 9, 10, 1), bar(2, 12, 10, 12, 1)}
+
+	tests := []struct {
+		name            string
+		period, dPeriod int
+	}{
+		{"huge %D period", 3, math.MaxInt},
+		{"huge %K period", math.MaxInt, 3},
+	}
+	for _, tt := range tests {
+		k, d := Stochastic(bars, tt.period, tt.dPeriod)
+		if len(k) != len(bars) || len(d) != len(bars) {
+			t.Errorf("%s: %d %%K and %d %%D values, want %d", tt.name, len(k), len(d), len(bars))
+		}
+		checkSeries(t, tt.name+" %D", d, len(bars), nil, 0)
+	}
+}
+
+func TestVWAPResetsEachSession(t *testing.T) {
+	day := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	at := func(offset time.Duration, price, volume float64) timeseries.Bar {
+		return timeseries.Bar{Time: day.Add(offset), High: price, Low: price, Close: price, Volume: volume}
+	}
+	bars := []timeseries.Bar{
+		at(10*time.Hour, 10, 100),
+		at(11*time.Hour, 13, 200),
+		at(34*time.Hour, 20, 0), // Next session, no volume yet
+		at(35*time.Hour, 22, 50),
+	}
+
+	got := VWAP(bars)
+	want := []float64{10, 12, math.NaN(), 22}
+	for i := range want {
+		if math.IsNaN(want[i]) != math.IsNaN(got[i]) || (!math.IsNaN(want[i]) && math.Abs(got[i]-want[i]) > 1e-9) {
+			t.Errorf("VWAP[%d] = %g, want %g", i, got[i], want[i])
+		}
+	}
+}
+
+func TestComputeDropsWarmup(t *testing.T) {
+	bars := make([]timeseries.Bar, len(stockChartsCloses))
+	for i, c := range stockChartsCloses {
+		bars[i] = bar(i, c, c, c, 1)
+	}
+
+	tests := []struct {
+		name   string
+		params Params
+		first  int // Index of the first bar with a point
+	}{
+		{"sma", Params{Period: 10}, 9},
+		{"ema", Params{Period: 10}, 9},
+		{"dema", Params{Period: 5}, 8},
+		{"tema", Params{Period: 5}, 12},
+		{"rsi", Params{Period: 14}, 14},
+		{"bbands", Params{Period: 5}, 4},
+		{"atr", Params{Period: 14}, 14},
+		{"stoch", Params{Period: 5, SignalPeriod: 3}, 6},
+		{"macd", Params{FastPeriod: 3, SlowPeriod: 6, SignalPeriod: 4}, 8},
+		{"obv", Params{}, 0},
+	}
+	for _, tt := range tests {
+		indicator, ok := Lookup(tt.name)
+		if !ok {
+			t.Fatalf("Lookup(%q) found nothing", tt.name)
+		}
+		series, err := indicator.Compute(bars, tt.params)
+		if err != nil {
+			t.Fatalf("%s: Compute() error = %v", tt.name, err)
+		}
+		if got, want := len(series.Points), len(bars)-tt.first; got != want {
+			t.Errorf("%s: %d points, want %d", tt.name, got, want)
+			continue
+		}
+		if first := series.Points[0]; !first.Time.Equal(bars[tt.first].Time) || len(first.Values) != len(indicator.Columns) {
+			t.Errorf("%s: first point %+v, want bar %d with every column", tt.name, first, tt.first)
+		}
+	}
+}
+
+func TestComputeValidatesParams(t *testing.T) {
+	sma, _ := Lookup("SMA")
+	macd, _ := Lookup("macd")
+	bbands, _ := Lookup("bbands")
+	bars := []timeseries.Bar{bar(0, 1, 1, 1, 1)}
+
+	invalid := map[string]func() error{
+		"series type": func() error { _, err := sma.Compute(bars, Params{SeriesType: "volume"}); return err },
+		"period":      func() error { _, err := sma.Compute(bars, Params{Period: -1}); return err },
+		"fast period": func() error { _, err := macd.Compute(bars, Params{FastPeriod: 30, SlowPeriod: 10}); return err },
+		"stddev":      func() error { _, err := bbands.Compute(bars, Params{StdDev: -1}); return err },
+		"NaN stddev":  func() error { _, err := bbands.Compute(bars, Params{StdDev: math.NaN()}); return err },
+		"Inf stddev":  func() error { _, err := bbands.Compute(bars, Params{StdDev: math.Inf(1)}); return err },
+	}
+	for name, compute := range invalid {
+		if err := compute(); err == nil {
+			t.Errorf("invalid %s accepted", name)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package indicators
+
+import (
+	"math"
+)
+
+// SMA returns the simple moving average of values over period
+func SMA(values []float64, period int) []float64 {
+	out := nans(len(values))
+	if period < 1 {
+		return out
+	}
+
+	var sum float64
+	for i, v := range values {
+		sum += v
+		if i >= period {
+			sum -= values[i-period]
+		}
+		if i >= period-1 {
+			out[i] = sum / float64(period)
+		}
+	}
+	return out
+}
+
+// EMA returns the exponential moving average of values over period, seeded
+// with the simple average of the first period values. Leading NaN values are
+// skipped, so EMA can be applied to the output of another indicator.
+func EMA(values []float64, period int) []float64 {
+	out := nans(len(values))
+	if period < 1 {
+		return out
+	}
+
+	start := 0
+	for start < len(values) && math.IsNaN(values[start]) {
+		start++
+	}
+	if len(values)-start < period {
+		return out
+	}
+
+	alpha := 2 / float64(period+1)
+	var sum float64
+	for _, v := range values[start : start+period] {
+		sum += v
+	}
+	prev := sum / float64(period)
+	out[start+period-1] = prev
+	for i := start + period; i < len(values); i++ {
+		prev += alpha * (values[i] - prev)
+		out[i] = prev
+	}
+	return out
+}
+
+// WMA returns the linearly weighted moving average of values over period,
+// giving the latest value a weight of period and the oldest a weight of 1
+func WMA(values []float64, period int) []float64 {
+	out := nans(len(values))
+	if period < 1 {
+		return out
+	}
+
+	divisor := float64(period*(period+1)) / 2
+	for i := period - 1; i < len(values); i++ {
+		var sum float64
+		for j := 0; j < period; j++ {
+			sum += float64(period-j) * values[i-j]
+		}
+		out[i] = sum / divisor
+	}
+	return out
+}
+
+// DEMA returns the double exponential moving average 2*EMA - EMA(EMA)
+func DEMA(values []float64, period int) []float64 {
+	ema := EMA(values, period)
+	ema2 := EMA(ema, period)
+
+	out := nans(len(values))
+	for i := range out {
+		out[i] = 2*ema[i] - ema2[i]
+	}
+	return out
+}
+
+// TEMA returns the triple exponential moving average 3*EMA - 3*EMA(EMA) + EMA(EMA(EMA))
+func TEMA(values []float64, period int) []float64 {
+	ema := EMA(values, period)
+	ema2 := EMA(ema, period)
+	ema3 := EMA(ema2, period)
+
+	out := nans(len(values))
+	for i := range out {
+		out[i] = 3*ema[i] - 3*ema2[i] + ema3[i]
+	}
+	return out
+}
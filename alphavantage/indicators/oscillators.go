@@ -0,0 +1,102 @@
+package indicators
+
+import (
+	"math"
+
+	"stock/alphavantage/timeseries"
+)
+
+// RSI returns Wilder's relative strength index of values over period. The
+// first average gain and loss are simple averages of the first period changes.
+func RSI(values []float64, period int) []float64 {
+	out := nans(len(values))
+	if period < 1 || len(values) <= period {
+		return out
+	}
+
+	var gain, loss float64
+	for i := 1; i <= period; i++ {
+		change := values[i] - values[i-1]
+		if change > 0 {
+			gain += change
+		} else {
+			loss -= change
+		}
+	}
+	gain /= float64(period)
+	loss /= float64(period)
+	out[period] = rsi(gain, loss)
+
+	for i := period + 1; i < len(values); i++ {
+		change := values[i] - values[i-1]
+		gain = (gain*float64(period-1) + math.Max(change, 0)) / float64(period)
+		loss = (loss*float64(period-1) + math.Max(-change, 0)) / float64(period)
+		out[i] = rsi(gain, loss)
+	}
+	return out
+}
+
+// rsi converts an average gain and loss into the index
+func rsi(gain, loss float64) float64 {
+	if loss == 0 {
+		if gain == 0 {
+			return 50
+		}
+		return 100
+	}
+	return 100 - 100/(1+gain/loss)
+}
+
+// MACD returns the difference of the fast and slow EMAs of values, its
+// signal EMA and the histogram of their difference
+func MACD(values []float64, fast, slow, signal int) (macd, signalLine, histogram []float64) {
+	fastEMA := EMA(values, fast)
+	slowEMA := EMA(values, slow)
+
+	macd = nans(len(values))
+	for i := range macd {
+		macd[i] = fastEMA[i] - slowEMA[i]
+	}
+	signalLine = EMA(macd, signal)
+
+	histogram = nans(len(values))
+	for i := range histogram {
+		histogram[i] = macd[i] - signalLine[i]
+	}
+	return macd, signalLine, histogram
+}
+
+// Stochastic returns the stochastic oscillator %K of the close within the
+// high-low range of period bars, and %D, its simple average over dPeriod
+func Stochastic(bars []timeseries.Bar, period, dPeriod int) (k, d []float64) {
+	k = nans(len(bars))
+	if period < 1 || period > len(bars) {
+		return k, nans(len(bars))
+	}
+
+	for i := period - 1; i < len(bars); i++ {
+		high, low := bars[i].High, bars[i].Low
+		for _, bar := range bars[i-period+1 : i] {
+			high = math.Max(high, bar.High)
+			low = math.Min(low, bar.Low)
+		}
+		if high == low {
+			k[i] = 50
+		} else {
+			k[i] = 100 * (bars[i].Close - low) / (high - low)
+		}
+	}
+
+	d = nans(len(bars))
+	if dPeriod < 1 || dPeriod > len(bars) {
+		return k, d
+	}
+	for i := period + dPeriod - 2; i < len(bars); i++ {
+		var sum float64
+		for _, v := range k[i-dPeriod+1 : i+1] {
+			sum += v
+		}
+		d[i] = sum / float64(dPeriod)
+	}
+	return k, d
+}
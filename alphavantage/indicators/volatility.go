@@ -0,0 +1,52 @@
+package indicators
+
+import (
+	"math"
+
+	"stock/alphavantage/timeseries"
+)
+
+// BollingerBands returns the simple moving average of values over period and
+// the bands width population standard deviations above and below it
+func BollingerBands(values []float64, period int, width float64) (upper, middle, lower []float64) {
+	middle = SMA(values, period)
+	upper, lower = nans(len(values)), nans(len(values))
+
+	for i := period - 1; i >= 0 && i < len(values); i++ {
+		var variance float64
+		for _, v := range values[i-period+1 : i+1] {
+			variance += (v - middle[i]) * (v - middle[i])
+		}
+		deviation := math.Sqrt(variance / float64(period))
+		upper[i] = middle[i] + width*deviation
+		lower[i] = middle[i] - width*deviation
+	}
+	return upper, middle, lower
+}
+
+// ATR returns Wilder's average true range over period. The first value is the
+// simple average of the first period true ranges, which need a previous close.
+func ATR(bars []timeseries.Bar, period int) []float64 {
+	out := nans(len(bars))
+	if period < 1 || len(bars) <= period {
+		return out
+	}
+
+	trueRange := func(i int) float64 {
+		prevClose := bars[i-1].Close
+		return math.Max(bars[i].High-bars[i].Low, math.Max(math.Abs(bars[i].High-prevClose), math.Abs(bars[i].Low-prevClose)))
+	}
+
+	var atr float64
+	for i := 1; i <= period; i++ {
+		atr += trueRange(i)
+	}
+	atr /= float64(period)
+	out[period] = atr
+
+	for i := period + 1; i < len(bars); i++ {
+		atr = (atr*float64(period-1) + trueRange(i)) / float64(period)
+		out[i] = atr
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+package indicators
+
+import (
+	"stock/alphavantage/timeseries"
+)
+
+// OBV returns the on-balance volume, starting from the first bar's volume and
+// adding or subtracting each volume as the close rises or falls
+func OBV(bars []timeseries.Bar) []float64 {
+	out := make([]float64, len(bars))
+	for i, bar := range bars {
+		switch {
+		case i == 0:
+			out[i] = bar.Volume
+		case bar.Close > bars[i-1].Close:
+			out[i] = out[i-1] + bar.Volume
+		case bar.Close < bars[i-1].Close:
+			out[i] = out[i-1] - bar.Volume
+		default:
+			out[i] = out[i-1]
+		}
+	}
+	return out
+}
+
+// VWAP returns the volume weighted average of the typical price (high + low +
+// close) / 3, accumulated from the first bar of each session
+func VWAP(bars []timeseries.Bar) []float64 {
+	out := nans(len(bars))
+
+	var value, volume float64
+	for i, bar := range bars {
+		if i > 0 && !sameDay(bar, bars[i-1]) {
+			value, volume = 0, 0
+		}
+		value += (bar.High + bar.Low + bar.Close) / 3 * bar.Volume
+		volume += bar.Volume
+		if volume > 0 {
+			out[i] = value / volume
+		}
+	}
+	return out
+}
+
+// sameDay reports whether two bars fall on the same exchange date
+func sameDay(a, b timeseries.Bar) bool {
+	ay, am, ad := a.Time.Date()
+	by, bm, bd := b.Time.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,184 @@
+package alphavantage
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"stock/alphavantage/indicators"
+	"stock/alphavantage/timeseries"
+	"stock/common"
+	"stock/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IndicatorResponse defines the response format for technical indicators
+// @Description Technical indicator response data structure
+type IndicatorResponse struct {
+	Version   string             `json:"version"`
+	Timestamp string             `json:"timestamp"`
+	Symbol    string             `json:"symbol"`
+	Interval  string             `json:"interval"`
+	Cache     string             `json:"cache,omitempty"`
+	Data      *indicators.Series `json:"data"`
+}
+
+// indicatorFunctions maps the non-intraday intervals to their time series functions
+var indicatorFunctions = map[string]string{
+	"daily":   "TIME_SERIES_DAILY",
+	"weekly":  "TIME_SERIES_WEEKLY",
+	"monthly": "TIME_SERIES_MONTHLY",
+}
+
+// intradayIntervals are the intervals served by TIME_SERIES_INTRADAY
+var intradayIntervals = map[string]bool{"1min": true, "5min": true, "15min": true, "30min": true, "60min": true}
+
+// maxIndicatorPeriod bounds every look-back period of an indicator request
+const maxIndicatorPeriod = 1000
+
+// GetIndicator handles requests for technical indicators
+// @Summary Compute a technical indicator for a symbol
+// @Description Computes the indicator locally from the cached time series, so any number of indicators on one symbol cost a single upstream call. Bars before the indicator has enough history are left out. Available indicators: sma, ema, wma, dema, tema, rsi, macd, bbands, atr, stoch, obv and vwap (intraday only).
+// @Tags indicators
+// @Produce json,text/csv,application/x-ndjson
+// @Param symbol path string true "Stock symbol (e.g., AAPL, MSFT)"
+// @Param name path string true "Indicator name" Enums(sma, ema, wma, dema, tema, rsi, macd, bbands, atr, stoch, obv, vwap)
+// @Param interval query string false "Bar interval" Enums(1min, 5min, 15min, 30min, 60min, daily, weekly, monthly) default(daily)
+// @Param outputsize query string false "Size of the underlying series" Enums(compact, full) default(compact)
+// @Param period query int false "Look-back period in bars, at most 1000 (20 for moving averages and bbands, 14 for rsi, atr and stoch)"
+// @Param series_type query string false "Price the indicator is computed from" Enums(open, high, low, close) default(close)
+// @Param fast_period query int false "MACD fast EMA period" default(12)
+// @Param slow_period query int false "MACD slow EMA period" default(26)
+// @Param signal_period query int false "MACD signal period (default 9) or stoch %D period (default 3)"
+// @Param stddev query number false "Bollinger band width in standard deviations" default(2)
+// @Param limit query int false "Only return the latest points"
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} IndicatorResponse "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/indicators/{symbol}/{name} [get]
+func (h *Handler) GetIndicator(c *gin.Context) {
+	symbol := c.Param("symbol")
+	indicator, ok := indicators.Lookup(c.Param("name"))
+	if !ok {
+		respondError(c, fmt.Errorf("%w: unknown indicator %q, available: %s", common.ErrInvalidParameter, c.Param("name"), strings.Join(indicators.Names(), ", ")))
+		return
+	}
+
+	interval := c.DefaultQuery("interval", "daily")
+	params := timeseries.TimeSeriesParams{
+		Symbol:     symbol,
+		OutputSize: c.DefaultQuery("outputsize", "compact"),
+	}
+	switch {
+	case intradayIntervals[interval]:
+		params.Function = "TIME_SERIES_INTRADAY"
+		params.Interval = interval
+		params.Adjusted = true
+	case indicatorFunctions[interval] != "" && !indicator.Intraday:
+		params.Function = indicatorFunctions[interval]
+	case indicatorFunctions[interval] != "":
+		respondError(c, fmt.Errorf("%w: %s is only available for intraday intervals", common.ErrInvalidParameter, indicator.Name))
+		return
+	default:
+		respondError(c, fmt.Errorf("%w: unsupported interval %q", common.ErrInvalidParameter, interval))
+		return
+	}
+
+	indicatorParams, err := parseIndicatorParams(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	limit, err := parseLimit(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	ctx, cache := requestContext(c)
+
+	bars, err := h.client.GetTimeSeries(ctx, params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	series, err := indicator.Compute(bars, indicatorParams)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if limit > 0 && len(series.Points) > limit {
+		series.Points = series.Points[len(series.Points)-limit:]
+	}
+
+	response := IndicatorResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Symbol:    symbol,
+		Interval:  interval,
+		Cache:     setCacheHeader(c, cache),
+		Data:      series,
+	}
+
+	rows := make([]indicatorRow, len(series.Points))
+	for i, point := range series.Points {
+		rows[i] = indicatorRow{point: point, names: series.Columns}
+	}
+	respond(c, response, rows)
+}
+
+// parseIndicatorParams reads the optional indicator tuning parameters
+func parseIndicatorParams(c *gin.Context) (indicators.Params, error) {
+	params := indicators.Params{SeriesType: c.Query("series_type")}
+
+	for name, dst := range map[string]*int{
+		"period":        &params.Period,
+		"fast_period":   &params.FastPeriod,
+		"slow_period":   &params.SlowPeriod,
+		"signal_period": &params.SignalPeriod,
+	} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxIndicatorPeriod {
+			return params, fmt.Errorf("%w: %s must be an integer between 1 and %d", common.ErrInvalidParameter, name, maxIndicatorPeriod)
+		}
+		*dst = parsed
+	}
+
+	if value := c.Query("stddev"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+			return params, fmt.Errorf("%w: stddev must be a positive number", common.ErrInvalidParameter)
+		}
+		params.StdDev = parsed
+	}
+	return params, nil
+}
+
+// indicatorRow flattens a point into a time column followed by one column per indicator value
+type indicatorRow struct {
+	point indicators.Point
+	names []string
+}
+
+func (r indicatorRow) columns() record {
+	row := record{{name: "time", value: r.point.Time}}
+	for _, name := range r.names {
+		row = append(row, column{name: name, value: r.point.Values[name]})
+	}
+	return row
+}
@@ -0,0 +1,33 @@
+package alphavantage
+
+import (
+	"errors"
+	"testing"
+
+	"stock/common"
+)
+
+func TestParseIndicatorParamsBoundsPeriods(t *testing.T) {
+	valid := []string{"/?period=1", "/?period=1000", "/?fast_period=12&slow_period=26&signal_period=9"}
+	for _, target := range valid {
+		c, _ := testContext(target, "")
+		if _, err := parseIndicatorParams(c); err != nil {
+			t.Errorf("parseIndicatorParams(%s) error = %v", target, err)
+		}
+	}
+
+	invalid := []string{
+		"/?period=0",
+		"/?period=1001",
+		"/?fast_period=9223372036854775807",
+		"/?slow_period=5000",
+		"/?signal_period=9223372036854775807",
+		"/?period=ten",
+	}
+	for _, target := range invalid {
+		c, _ := testContext(target, "")
+		if _, err := parseIndicatorParams(c); !errors.Is(err, common.ErrInvalidParameter) {
+			t.Errorf("parseIndicatorParams(%s) error = %v, want ErrInvalidParameter", target, err)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package market
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"stock/common"
+)
+
+// maxSessionSearch bounds how many days NextSession and PreviousSession look ahead or back
+const maxSessionSearch = 366
+
+// Holiday is a day the exchange is closed for the whole day
+type Holiday struct {
+	Date time.Time `json:"date"`
+	Name string    `json:"name"`
+}
+
+// Session is the regular trading session of one day
+type Session struct {
+	Date       time.Time `json:"date"` // Midnight in the calendar's time zone
+	Open       time.Time `json:"open"`
+	Close      time.Time `json:"close"`
+	EarlyClose bool      `json:"early_close"`
+}
+
+// clock is a time of day
+type clock struct {
+	hour, minute int
+}
+
+// on returns the clock time on the given day in loc
+func (c clock) on(year int, month time.Month, day int, loc *time.Location) time.Time {
+	return time.Date(year, month, day, c.hour, c.minute, 0, 0, loc)
+}
+
+// String formats the clock as HH:MM
+func (c clock) String() string {
+	return fmt.Sprintf("%02d:%02d", c.hour, c.minute)
+}
+
+// parseClock parses an HH:MM time of day
+func parseClock(value string) (clock, error) {
+	t, err := time.Parse(clockLayout, value)
+	if err != nil {
+		return clock{}, fmt.Errorf("invalid time of day %q", value)
+	}
+	return clock{hour: t.Hour(), minute: t.Minute()}, nil
+}
+
+// calendarYear holds the closures of one year, keyed by YYYY-MM-DD
+type calendarYear struct {
+	holidays    map[string]string
+	earlyCloses map[string]bool
+}
+
+// Calendar answers which days an exchange trades and when its sessions run.
+// Dates passed to its methods are read from their own year, month and day,
+// so both UTC dates and exchange-local timestamps work.
+type Calendar struct {
+	Name     string
+	Region   string // MARKET_STATUS region the calendar applies to
+	Location *time.Location
+
+	open, close, earlyClose clock
+	weekend                 [7]bool
+
+	// rules returns rule-based holidays and early closes of a year, explicit
+	// dates are layered on top of them
+	rules       func(year int) ([]Holiday, []time.Time)
+	holidays    map[string]string
+	earlyCloses map[string]bool
+
+	mu    sync.Mutex
+	years map[int]*calendarYear
+}
+
+// year returns the closures of a year, computing them on first use
+func (c *Calendar) year(y int) *calendarYear {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.years[y]; ok {
+		return cached
+	}
+
+	year := &calendarYear{holidays: map[string]string{}, earlyCloses: map[string]bool{}}
+	if c.rules != nil {
+		holidays, earlyCloses := c.rules(y)
+		for _, h := range holidays {
+			year.holidays[h.Date.Format(common.DateLayout)] = h.Name
+		}
+		for _, d := range earlyCloses {
+			year.earlyCloses[d.Format(common.DateLayout)] = true
+		}
+	}
+	prefix := fmt.Sprintf("%04d-", y)
+	for key, name := range c.holidays {
+		if strings.HasPrefix(key, prefix) {
+			year.holidays[key] = name
+		}
+	}
+	for key := range c.earlyCloses {
+		if strings.HasPrefix(key, prefix) {
+			year.earlyCloses[key] = true
+		}
+	}
+
+	if c.years == nil {
+		c.years = make(map[int]*calendarYear)
+	}
+	c.years[y] = year
+	return year
+}
+
+// Holiday returns the name of the holiday on date, if the exchange is closed for one
+func (c *Calendar) Holiday(date time.Time) (string, bool) {
+	name, ok := c.year(date.Year()).holidays[date.Format(common.DateLayout)]
+	return name, ok
+}
+
+// Holidays lists the holidays of a year that fall on weekdays the exchange would otherwise trade
+func (c *Calendar) Holidays(year int) []Holiday {
+	var holidays []Holiday
+	for day := time.Date(year, time.January, 1, 0, 0, 0, 0, c.Location); day.Year() == year; day = day.AddDate(0, 0, 1) {
+		if name, ok := c.Holiday(day); ok && !c.weekend[day.Weekday()] {
+			holidays = append(holidays, Holiday{Date: day, Name: name})
+		}
+	}
+	return holidays
+}
+
+// Hours returns the regular open and close of a session as HH:MM in the calendar's time zone
+func (c *Calendar) Hours() (open, close string) {
+	return c.open.String(), c.close.String()
+}
+
+// IsTradingDay reports whether the exchange holds a session on date
+func (c *Calendar) IsTradingDay(date time.Time) bool {
+	if c.weekend[date.Weekday()] {
+		return false
+	}
+	_, holiday := c.Holiday(date)
+	return !holiday
+}
+
+// Session returns the session held on date, false when the exchange is closed
+func (c *Calendar) Session(date time.Time) (Session, bool) {
+	if !c.IsTradingDay(date) {
+		return Session{}, false
+	}
+
+	y, m, d := date.Date()
+	session := Session{
+		Date:  time.Date(y, m, d, 0, 0, 0, 0, c.Location),
+		Open:  c.open.on(y, m, d, c.Location),
+		Close: c.close.on(y, m, d, c.Location),
+	}
+	if c.year(y).earlyCloses[date.Format(common.DateLayout)] {
+		session.Close = c.earlyClose.on(y, m, d, c.Location)
+		session.EarlyClose = true
+	}
+	return session, true
+}
+
+// NextSession returns the first session on a day after date, or the zero
+// Session when none is found within a year
+func (c *Calendar) NextSession(date time.Time) Session {
+	y, m, d := date.Date()
+	for i := 1; i <= maxSessionSearch; i++ {
+		if session, ok := c.Session(time.Date(y, m, d+i, 0, 0, 0, 0, c.Location)); ok {
+			return session
+		}
+	}
+	return Session{}
+}
+
+// PreviousSession returns the last session on a day before date, or the zero
+// Session when none is found within a year
+func (c *Calendar) PreviousSession(date time.Time) Session {
+	y, m, d := date.Date()
+	for i := 1; i <= maxSessionSearch; i++ {
+		if session, ok := c.Session(time.Date(y, m, d-i, 0, 0, 0, 0, c.Location)); ok {
+			return session
+		}
+	}
+	return Session{}
+}
+
+// SessionsBetween returns the sessions from one date to another, both inclusive
+func (c *Calendar) SessionsBetween(from, to time.Time) []Session {
+	var sessions []Session
+	fy, fm, fd := from.Date()
+	ty, tm, td := to.Date()
+	last := time.Date(ty, tm, td, 0, 0, 0, 0, c.Location)
+	for day := time.Date(fy, fm, fd, 0, 0, 0, 0, c.Location); !day.After(last); day = day.AddDate(0, 0, 1) {
+		if session, ok := c.Session(day); ok {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+// NextOpen returns the first session open after the instant now
+func (c *Calendar) NextOpen(now time.Time) time.Time {
+	local := now.In(c.Location)
+	if session, ok := c.Session(local); ok && session.Open.After(now) {
+		return session.Open
+	}
+	return c.NextSession(local).Open
+}
+
+// NextClose returns the first session close after the instant now, early closes included
+func (c *Calendar) NextClose(now time.Time) time.Time {
+	local := now.In(c.Location)
+	if session, ok := c.Session(local); ok && session.Close.After(now) {
+		return session.Close
+	}
+	return c.NextSession(local).Close
+}
+
+// CalendarFile is the JSON format calendars of other exchanges are loaded from:
+//
+//	{
+//	  "name": "LSE",
+//	  "region": "United Kingdom",
+//	  "timezone": "Europe/London",
+//	  "open": "08:00",
+//	  "close": "16:30",
+//	  "early_close": "12:30",
+//	  "weekend": ["Saturday", "Sunday"],
+//	  "holidays": [{"date": "2025-12-25", "name": "Christmas Day"}],
+//	  "early_closes": ["2025-12-24"]
+//	}
+type CalendarFile struct {
+	Name       string   `json:"name"`
+	Region     string   `json:"region"`
+	TimeZone   string   `json:"timezone"`
+	Open       string   `json:"open"`
+	Close      string   `json:"close"`
+	EarlyClose string   `json:"early_close"` // Defaults to the regular close
+	Weekend    []string `json:"weekend"`     // Defaults to Saturday and Sunday
+	Holidays   []struct {
+		Date string `json:"date"`
+		Name string `json:"name"`
+	} `json:"holidays"`
+	EarlyCloses []string `json:"early_closes"`
+}
+
+// ParseCalendar builds a calendar from the JSON CalendarFile format
+func ParseCalendar(data []byte) (*Calendar, error) {
+	var file CalendarFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	// LoadLocation maps an empty name to UTC, which is never meant here
+	if file.TimeZone == "" {
+		return nil, fmt.Errorf("calendar %s: time zone is required", file.Name)
+	}
+	loc, err := time.LoadLocation(file.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("calendar %s: time zone %q: %w", file.Name, file.TimeZone, err)
+	}
+
+	cal := &Calendar{
+		Name:        file.Name,
+		Region:      file.Region,
+		Location:    loc,
+		holidays:    make(map[string]string, len(file.Holidays)),
+		earlyCloses: make(map[string]bool, len(file.EarlyCloses)),
+	}
+	if cal.open, err = parseClock(file.Open); err != nil {
+		return nil, fmt.Errorf("calendar %s: open: %w", file.Name, err)
+	}
+	if cal.close, err = parseClock(file.Close); err != nil {
+		return nil, fmt.Errorf("calendar %s: close: %w", file.Name, err)
+	}
+	cal.earlyClose = cal.close
+	if file.EarlyClose != "" {
+		if cal.earlyClose, err = parseClock(file.EarlyClose); err != nil {
+			return nil, fmt.Errorf("calendar %s: early_close: %w", file.Name, err)
+		}
+	}
+
+	weekend := file.Weekend
+	if weekend == nil {
+		weekend = []string{"Saturday", "Sunday"}
+	}
+	for _, name := range weekend {
+		day, ok := weekdays[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("calendar %s: invalid weekend day %q", file.Name, name)
+		}
+		cal.weekend[day] = true
+	}
+
+	for _, h := range file.Holidays {
+		if _, err := time.Parse(common.DateLayout, h.Date); err != nil {
+			return nil, fmt.Errorf("calendar %s: invalid holiday date %q", file.Name, h.Date)
+		}
+		cal.holidays[h.Date] = h.Name
+	}
+	for _, d := range file.EarlyCloses {
+		if _, err := time.Parse(common.DateLayout, d); err != nil {
+			return nil, fmt.Errorf("calendar %s: invalid early close date %q", file.Name, d)
+		}
+		cal.earlyCloses[d] = true
+	}
+
+	return cal, nil
+}
+
+// LoadCalendar reads a calendar in the JSON CalendarFile format from path
+func LoadCalendar(path string) (*Calendar, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCalendar(data)
+}
+
+// weekdays maps lower case day names to weekdays
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+var (
+	calendarsMu sync.RWMutex
+	calendars   = map[string]*Calendar{NYSE.Region: NYSE}
+	regions     = []string{NYSE.Region} // Regions of calendars in registration order
+)
+
+// RegisterCalendar makes cal the calendar of its region, replacing any
+// previous one in its place
+func RegisterCalendar(cal *Calendar) {
+	calendarsMu.Lock()
+	defer calendarsMu.Unlock()
+	if _, ok := calendars[cal.Region]; !ok {
+		regions = append(regions, cal.Region)
+	}
+	calendars[cal.Region] = cal
+}
+
+// CalendarFor returns the calendar registered for a MARKET_STATUS region
+func CalendarFor(region string) (*Calendar, bool) {
+	calendarsMu.RLock()
+	defer calendarsMu.RUnlock()
+	cal, ok := calendars[region]
+	return cal, ok
+}
+
+// timeZoneAliases maps legacy zone names used by Alpha Vantage to canonical ones
+var timeZoneAliases = map[string]string{
+	"US/Eastern": "America/New_York",
+}
+
+// CalendarForTimeZone returns a registered calendar keeping hours in the
+// named time zone. When calendars share the zone the first registered one
+// wins, so NYSE answers for New York.
+func CalendarForTimeZone(name string) (*Calendar, bool) {
+	if alias, ok := timeZoneAliases[name]; ok {
+		name = alias
+	}
+
+	calendarsMu.RLock()
+	defer calendarsMu.RUnlock()
+	for _, region := range regions {
+		if cal := calendars[region]; cal.Location.String() == name {
+			return cal, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,214 @@
+package market
+
+import (
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	d, err := time.ParseInLocation("2006-01-02", s, newYork)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func at(s string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04", s, newYork)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestNYSEHolidays(t *testing.T) {
+	tests := []struct {
+		date    string
+		holiday string // Empty when the exchange trades
+	}{
+		{"2024-01-01", "New Year's Day"},
+		{"2023-01-02", "New Year's Day"}, // Sunday observed on Monday
+		{"2021-12-31", ""},               // Saturday New Year's Day is not moved into the old year
+		{"2024-01-15", "Martin Luther King Jr. Day"},
+		{"2024-02-19", "Washington's Birthday"},
+		{"2024-03-29", "Good Friday"},
+		{"2025-04-18", "Good Friday"},
+		{"2024-05-27", "Memorial Day"},
+		{"2021-06-18", ""}, // Juneteenth is observed from 2022
+		{"2022-06-20", "Juneteenth National Independence Day"},
+		{"2024-06-19", "Juneteenth National Independence Day"},
+		{"2020-07-03", "Independence Day"}, // Saturday observed on Friday
+		{"2024-07-04", "Independence Day"},
+		{"2024-09-02", "Labor Day"},
+		{"2024-11-28", "Thanksgiving Day"},
+		{"2022-12-26", "Christmas Day"},
+		{"2025-01-09", "National Day of Mourning for Jimmy Carter"},
+		{"2024-11-29", ""},
+	}
+	for _, tt := range tests {
+		name, ok := NYSE.Holiday(date(tt.date))
+		if ok != (tt.holiday != "") || name != tt.holiday {
+			t.Errorf("Holiday(%s) = %q, %v, want %q", tt.date, name, ok, tt.holiday)
+		}
+		if want := tt.holiday == ""; NYSE.IsTradingDay(date(tt.date)) != want {
+			t.Errorf("IsTradingDay(%s) = %v, want %v", tt.date, !want, want)
+		}
+	}
+
+	if got := len(NYSE.Holidays(2024)); got != 10 {
+		t.Errorf("Holidays(2024) lists %d days, want 10", got)
+	}
+}
+
+func TestNYSESessions(t *testing.T) {
+	tests := []struct {
+		date       string
+		close      string
+		earlyClose bool
+	}{
+		{"2024-11-27", "16:00", false},
+		{"2024-11-29", "13:00", true},
+		{"2024-07-03", "13:00", true},
+		{"2024-12-24", "13:00", true},
+		{"2022-12-23", "16:00", false}, // Christmas Eve on a Saturday has no early close
+		{"2020-07-02", "16:00", false},
+	}
+	for _, tt := range tests {
+		session, ok := NYSE.Session(date(tt.date))
+		if !ok {
+			t.Errorf("Session(%s) closed, want a session", tt.date)
+			continue
+		}
+		if want := at(tt.date + " 09:30"); !session.Open.Equal(want) {
+			t.Errorf("Session(%s).Open = %s, want %s", tt.date, session.Open, want)
+		}
+		if want := at(tt.date + " " + tt.close); !session.Close.Equal(want) || session.EarlyClose != tt.earlyClose {
+			t.Errorf("Session(%s).Close = %s (early %v), want %s", tt.date, session.Close, session.EarlyClose, want)
+		}
+	}
+
+	for _, closed := range []string{"2024-11-28", "2024-11-30", "2024-12-01"} {
+		if _, ok := NYSE.Session(date(closed)); ok {
+			t.Errorf("Session(%s) open, want closed", closed)
+		}
+	}
+
+	// A UTC date reads as the same calendar day in New York
+	if _, ok := NYSE.Session(time.Date(2024, time.November, 28, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error("Session() of Thanksgiving as a UTC date is open")
+	}
+}
+
+func TestNYSENextOpenAndClose(t *testing.T) {
+	tests := []struct {
+		now, nextOpen, nextClose string
+	}{
+		{"2024-11-27 08:00", "2024-11-27 09:30", "2024-11-27 16:00"},
+		{"2024-11-27 12:00", "2024-11-29 09:30", "2024-11-27 16:00"},
+		{"2024-11-28 10:00", "2024-11-29 09:30", "2024-11-29 13:00"},
+		{"2024-11-29 14:00", "2024-12-02 09:30", "2024-12-02 16:00"},
+		{"2024-12-31 17:00", "2025-01-02 09:30", "2025-01-02 16:00"},
+	}
+	for _, tt := range tests {
+		now := at(tt.now)
+		if got := NYSE.NextOpen(now); !got.Equal(at(tt.nextOpen)) {
+			t.Errorf("NextOpen(%s) = %s, want %s", tt.now, got, tt.nextOpen)
+		}
+		if got := NYSE.NextClose(now.UTC()); !got.Equal(at(tt.nextClose)) {
+			t.Errorf("NextClose(%s) = %s, want %s", tt.now, got, tt.nextClose)
+		}
+	}
+
+	if got := NYSE.PreviousSession(date("2024-12-02")).Date; !got.Equal(date("2024-11-29")) {
+		t.Errorf("PreviousSession(2024-12-02) = %s, want 2024-11-29", got)
+	}
+	if got := len(NYSE.SessionsBetween(date("2024-11-25"), date("2024-12-01"))); got != 4 {
+		t.Errorf("SessionsBetween() over Thanksgiving week = %d sessions, want 4", got)
+	}
+}
+
+func TestParseCalendar(t *testing.T) {
+	cal, err := ParseCalendar([]byte(`{
+		"name": "LSE",
+		"region": "United Kingdom",
+		"timezone": "Europe/London",
+		"open": "08:00",
+		"close": "16:30",
+		"early_close": "12:30",
+		"holidays": [{"date": "2025-12-25", "name": "Christmas Day"}],
+		"early_closes": ["2025-12-24"]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	london, _ := time.LoadLocation("Europe/London")
+	if name, ok := cal.Holiday(time.Date(2025, time.December, 25, 0, 0, 0, 0, london)); !ok || name != "Christmas Day" {
+		t.Errorf("Holiday(2025-12-25) = %q, %v", name, ok)
+	}
+	if cal.IsTradingDay(time.Date(2025, time.December, 27, 0, 0, 0, 0, london)) {
+		t.Error("Saturday is a trading day, want the default weekend")
+	}
+	session, ok := cal.Session(time.Date(2025, time.December, 24, 0, 0, 0, 0, london))
+	if !ok || !session.EarlyClose || session.Close.Hour() != 12 || session.Close.Minute() != 30 {
+		t.Errorf("Session(2025-12-24) = %+v, want a 12:30 early close", session)
+	}
+	if session, _ := cal.Session(time.Date(2025, time.December, 23, 0, 0, 0, 0, london)); session.Open.Hour() != 8 || session.Close.Hour() != 16 {
+		t.Errorf("Session(2025-12-23) = %+v, want 08:00 to 16:30", session)
+	}
+
+	invalid := []string{
+		`{"name": "X", "timezone": "Nowhere/City", "open": "08:00", "close": "16:00"}`,
+		`{"name": "X", "open": "08:00", "close": "16:00"}`,
+		`{"name": "X", "timezone": "", "open": "08:00", "close": "16:00"}`,
+		`{"name": "X", "timezone": "UTC", "open": "8am", "close": "16:00"}`,
+		`{"name": "X", "timezone": "UTC", "open": "08:00", "close": "16:00", "weekend": ["Caturday"]}`,
+		`{"name": "X", "timezone": "UTC", "open": "08:00", "close": "16:00", "holidays": [{"date": "25/12/2025"}]}`,
+		`not json`,
+	}
+	for _, data := range invalid {
+		if _, err := ParseCalendar([]byte(data)); err == nil {
+			t.Errorf("ParseCalendar(%s) succeeded, want an error", data)
+		}
+	}
+}
+
+func TestCalendarLookup(t *testing.T) {
+	if cal, ok := CalendarFor("United States"); !ok || cal != NYSE {
+		t.Error("CalendarFor(United States) is not NYSE")
+	}
+	if cal, ok := CalendarForTimeZone("US/Eastern"); !ok || cal != NYSE {
+		t.Error("CalendarForTimeZone(US/Eastern) is not NYSE")
+	}
+	if _, ok := CalendarForTimeZone("Asia/Tokyo"); ok {
+		t.Error("CalendarForTimeZone(Asia/Tokyo) found a calendar, want none registered")
+	}
+}
+
+func TestCalendarForTimeZoneFirstRegistered(t *testing.T) {
+	saved, savedRegions := calendars, regions
+	t.Cleanup(func() { calendars, regions = saved, savedRegions })
+	calendars = map[string]*Calendar{NYSE.Region: NYSE}
+	regions = []string{NYSE.Region}
+
+	// Calendars sharing a zone with NYSE never take New York from it
+	for _, region := range []string{"Z Exchange", "A Exchange", "M Exchange"} {
+		RegisterCalendar(&Calendar{Name: region, Region: region, Location: newYork})
+	}
+	for i := 0; i < 20; i++ {
+		if cal, ok := CalendarForTimeZone("America/New_York"); !ok || cal != NYSE {
+			t.Fatalf("CalendarForTimeZone(America/New_York) = %v, want NYSE", cal)
+		}
+	}
+
+	// Replacing a region keeps its place
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	first := &Calendar{Name: "TSE", Region: "Japan", Location: tokyo}
+	RegisterCalendar(first)
+	RegisterCalendar(&Calendar{Name: "OSE", Region: "Osaka", Location: tokyo})
+	replaced := &Calendar{Name: "TSE 2", Region: "Japan", Location: tokyo}
+	RegisterCalendar(replaced)
+	if cal, ok := CalendarForTimeZone("Asia/Tokyo"); !ok || cal != replaced {
+		t.Errorf("CalendarForTimeZone(Asia/Tokyo) = %v, want the replaced Japan calendar", cal)
+	}
+}
@@ -1,5 +1,14 @@
 package market
 
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"stock/common"
+)
+
 /*
 	 {
         "market_type": "Equity",
@@ -11,3 +20,161 @@ package market
         "notes": ""
     },
 */
+
+// clockLayout is the layout of local_open and local_close
+const clockLayout = "15:04"
+
+// regionTimeZones maps MARKET_STATUS regions to the time zone their exchanges keep hours in
+var regionTimeZones = map[string]string{
+	"United States":  "America/New_York",
+	"Canada":         "America/Toronto",
+	"United Kingdom": "Europe/London",
+	"Germany":        "Europe/Berlin",
+	"France":         "Europe/Paris",
+	"Spain":          "Europe/Madrid",
+	"Portugal":       "Europe/Lisbon",
+	"Japan":          "Asia/Tokyo",
+	"India":          "Asia/Kolkata",
+	"Mainland China": "Asia/Shanghai",
+	"Hong Kong":      "Asia/Hong_Kong",
+	"Brazil":         "America/Sao_Paulo",
+	"Mexico":         "America/Mexico_City",
+	"South Africa":   "Africa/Johannesburg",
+}
+
+// MarketStatusResponse defines the structure of a MARKET_STATUS response
+type MarketStatusResponse struct {
+	Endpoint string   `json:"endpoint"`
+	Markets  []Market `json:"markets"`
+}
+
+// Market is the current state of one region's market
+type Market struct {
+	MarketType       string `json:"market_type"`
+	Region           string `json:"region"`
+	PrimaryExchanges string `json:"primary_exchanges"`
+	LocalOpen        string `json:"local_open"`
+	LocalClose       string `json:"local_close"`
+	CurrentStatus    string `json:"current_status"`
+	Notes            string `json:"notes"`
+}
+
+// MarketState is a market with its next session boundaries. Regions with a
+// registered calendar use its sessions, other regions with a mapped time zone
+// use the upstream hours on weekdays.
+type MarketState struct {
+	Market
+	TimeZone        string     `json:"time_zone,omitempty"`
+	NextOpen        *time.Time `json:"next_open,omitempty"`
+	NextClose       *time.Time `json:"next_close,omitempty"`
+	OpensInSeconds  *int64     `json:"opens_in_seconds,omitempty"`  // Set while closed
+	ClosesInSeconds *int64     `json:"closes_in_seconds,omitempty"` // Set while open
+}
+
+// IsOpen reports whether upstream reports the market as open
+func (m Market) IsOpen() bool {
+	return strings.EqualFold(m.CurrentStatus, "open")
+}
+
+// State derives the next session boundaries of the market as of now
+func (m Market) State(now time.Time) MarketState {
+	state := MarketState{Market: m}
+
+	if cal, ok := CalendarFor(m.Region); ok {
+		nextOpen, nextClose := cal.NextOpen(now), cal.NextClose(now)
+		state.TimeZone = cal.Location.String()
+		state.setBoundaries(now, nextOpen, nextClose)
+		return state
+	}
+
+	name, ok := regionTimeZones[m.Region]
+	if !ok {
+		return state
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return state
+	}
+	state.TimeZone = name
+
+	nextOpen, err := nextBoundary(now, m.LocalOpen, loc)
+	if err != nil {
+		return state
+	}
+	nextClose, err := nextBoundary(now, m.LocalClose, loc)
+	if err != nil {
+		return state
+	}
+	state.setBoundaries(now, nextOpen, nextClose)
+	return state
+}
+
+// setBoundaries records the next open and close and the time left until the relevant one
+func (s *MarketState) setBoundaries(now, nextOpen, nextClose time.Time) {
+	if nextOpen.IsZero() || nextClose.IsZero() {
+		return
+	}
+	s.NextOpen, s.NextClose = &nextOpen, &nextClose
+
+	if s.IsOpen() {
+		seconds := int64(nextClose.Sub(now).Seconds())
+		s.ClosesInSeconds = &seconds
+	} else {
+		seconds := int64(nextOpen.Sub(now).Seconds())
+		s.OpensInSeconds = &seconds
+	}
+}
+
+// nextBoundary returns the first weekday occurrence of clock after now in loc
+func nextBoundary(now time.Time, clock string, loc *time.Location) (time.Time, error) {
+	at, err := time.Parse(clockLayout, clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid market hour %q", clock)
+	}
+
+	local := now.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), at.Hour(), at.Minute(), 0, 0, loc)
+	for !next.After(now) || next.Weekday() == time.Saturday || next.Weekday() == time.Sunday {
+		next = time.Date(next.Year(), next.Month(), next.Day()+1, at.Hour(), at.Minute(), 0, 0, loc)
+	}
+	return next, nil
+}
+
+// States derives the state of every market, optionally limited to regions
+// (case-insensitive). An empty regions list keeps all markets.
+func (r *MarketStatusResponse) States(now time.Time, regions ...string) []MarketState {
+	states := make([]MarketState, 0, len(r.Markets))
+	for _, m := range r.Markets {
+		if len(regions) > 0 && !containsFold(regions, m.Region) {
+			continue
+		}
+		states = append(states, m.State(now))
+	}
+	return states
+}
+
+// containsFold reports whether values contains value ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMarketStatus fetches the current open or closed state of global markets
+func GetMarketStatus(ctx context.Context, client *common.Client) (*MarketStatusResponse, error) {
+	// Building query parameters
+	queryParams := map[string]string{
+		"function": "MARKET_STATUS",
+	}
+
+	// Make HTTP request and parse the response
+	result := &MarketStatusResponse{}
+	if err := client.GetJSON(ctx, queryParams, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
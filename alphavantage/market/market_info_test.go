@@ -0,0 +1,134 @@
+package market
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMarketIsOpen(t *testing.T) {
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{"open", true},
+		{"Open", true},
+		{"closed", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := (Market{CurrentStatus: tt.status}).IsOpen(); got != tt.want {
+			t.Errorf("IsOpen(%q) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestMarketStateCalendar(t *testing.T) {
+	tests := []struct {
+		name                string
+		now                 string
+		status              string
+		nextOpen, nextClose string
+		opensIn, closesIn   int64 // Seconds, 0 when not set
+	}{
+		{"open", "2024-11-27 12:00", "open", "2024-11-29 09:30", "2024-11-27 16:00", 0, 4 * 3600},
+		{"before the open", "2024-11-27 08:00", "closed", "2024-11-27 09:30", "2024-11-27 16:00", 90 * 60, 0},
+		{"weekend", "2024-11-30 12:00", "closed", "2024-12-02 09:30", "2024-12-02 16:00", (45*60 + 30) * 60, 0},
+		{"holiday before an early close", "2024-11-28 10:00", "closed", "2024-11-29 09:30", "2024-11-29 13:00", (23*60 + 30) * 60, 0},
+		{"during an early close", "2024-11-29 12:00", "open", "2024-12-02 09:30", "2024-11-29 13:00", 0, 3600},
+		{"after an early close", "2024-11-29 14:00", "closed", "2024-12-02 09:30", "2024-12-02 16:00", (67*60 + 30) * 60, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := Market{Region: "United States", CurrentStatus: tt.status}.State(at(tt.now))
+			if state.TimeZone != "America/New_York" {
+				t.Errorf("TimeZone = %q, want America/New_York", state.TimeZone)
+			}
+			if state.NextOpen == nil || !state.NextOpen.Equal(at(tt.nextOpen)) {
+				t.Errorf("NextOpen = %v, want %s", state.NextOpen, tt.nextOpen)
+			}
+			if state.NextClose == nil || !state.NextClose.Equal(at(tt.nextClose)) {
+				t.Errorf("NextClose = %v, want %s", state.NextClose, tt.nextClose)
+			}
+			checkSeconds(t, "OpensInSeconds", state.OpensInSeconds, tt.opensIn)
+			checkSeconds(t, "ClosesInSeconds", state.ClosesInSeconds, tt.closesIn)
+		})
+	}
+}
+
+func TestMarketStateUpstreamHours(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	japan := Market{Region: "Japan", LocalOpen: "09:00", LocalClose: "15:00", CurrentStatus: "closed"}
+
+	// Friday after the close, the next session is on Monday
+	now := time.Date(2024, 11, 29, 16, 0, 0, 0, tokyo)
+	state := japan.State(now)
+	if state.TimeZone != "Asia/Tokyo" {
+		t.Errorf("TimeZone = %q, want Asia/Tokyo", state.TimeZone)
+	}
+	if want := time.Date(2024, 12, 2, 9, 0, 0, 0, tokyo); state.NextOpen == nil || !state.NextOpen.Equal(want) {
+		t.Errorf("NextOpen = %v, want %s", state.NextOpen, want)
+	}
+	if want := time.Date(2024, 12, 2, 15, 0, 0, 0, tokyo); state.NextClose == nil || !state.NextClose.Equal(want) {
+		t.Errorf("NextClose = %v, want %s", state.NextClose, want)
+	}
+	checkSeconds(t, "OpensInSeconds", state.OpensInSeconds, (2*24+17)*3600)
+	checkSeconds(t, "ClosesInSeconds", state.ClosesInSeconds, 0)
+
+	// Open on a weekday, closing the same afternoon
+	japan.CurrentStatus = "open"
+	state = japan.State(time.Date(2024, 11, 28, 10, 0, 0, 0, tokyo))
+	if want := time.Date(2024, 11, 28, 15, 0, 0, 0, tokyo); state.NextClose == nil || !state.NextClose.Equal(want) {
+		t.Errorf("NextClose = %v, want %s", state.NextClose, want)
+	}
+	checkSeconds(t, "ClosesInSeconds", state.ClosesInSeconds, 5*3600)
+	checkSeconds(t, "OpensInSeconds", state.OpensInSeconds, 0)
+}
+
+func TestMarketStateUnknown(t *testing.T) {
+	now := at("2024-11-27 12:00")
+
+	// Regions without a calendar or time zone keep only the upstream fields
+	state := Market{Region: "Atlantis", LocalOpen: "09:00", LocalClose: "15:00"}.State(now)
+	if state.TimeZone != "" || state.NextOpen != nil || state.NextClose != nil || state.OpensInSeconds != nil {
+		t.Errorf("State(Atlantis) = %+v, want no boundaries", state)
+	}
+
+	// Unparseable hours keep the time zone but no boundaries
+	state = Market{Region: "Japan", LocalOpen: "9am", LocalClose: "15:00"}.State(now)
+	if state.TimeZone != "Asia/Tokyo" || state.NextOpen != nil || state.NextClose != nil {
+		t.Errorf("State(Japan, 9am) = %+v, want the time zone without boundaries", state)
+	}
+}
+
+func TestMarketStatusStates(t *testing.T) {
+	resp := &MarketStatusResponse{Markets: []Market{
+		{Region: "United States"},
+		{Region: "Japan", LocalOpen: "09:00", LocalClose: "15:00"},
+		{Region: "Germany", LocalOpen: "08:00", LocalClose: "16:30"},
+	}}
+	now := at("2024-11-27 12:00")
+
+	if got := len(resp.States(now)); got != 3 {
+		t.Errorf("States() = %d markets, want 3", got)
+	}
+	states := resp.States(now, " japan", "GERMANY")
+	if len(states) != 2 || states[0].Region != "Japan" || states[1].Region != "Germany" {
+		t.Errorf("States(japan, GERMANY) = %+v, want Japan and Germany", states)
+	}
+}
+
+// checkSeconds compares an optional countdown, want 0 means unset
+func checkSeconds(t *testing.T, name string, got *int64, want int64) {
+	t.Helper()
+	switch {
+	case want == 0 && got != nil:
+		t.Errorf("%s = %d, want unset", name, *got)
+	case want != 0 && got == nil:
+		t.Errorf("%s unset, want %d", name, want)
+	case want != 0 && *got != want:
+		t.Errorf("%s = %d, want %d", name, *got, want)
+	}
+}
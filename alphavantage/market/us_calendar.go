@@ -0,0 +1,122 @@
+package market
+
+import (
+	"time"
+)
+
+// newYork is the time zone of the US equity exchanges
+var newYork, _ = time.LoadLocation("America/New_York")
+
+// NYSE is the calendar shared by the NYSE and NASDAQ equity markets: a 09:30
+// to 16:00 session, 13:00 early closes and the holidays of NYSE Rule 7.2
+var NYSE = &Calendar{
+	Name:       "NYSE",
+	Region:     "United States",
+	Location:   newYork,
+	open:       clock{9, 30},
+	close:      clock{16, 0},
+	earlyClose: clock{13, 0},
+	weekend:    [7]bool{time.Sunday: true, time.Saturday: true},
+	rules:      usEquityRules,
+	holidays: map[string]string{
+		// Unscheduled closures
+		"2001-09-11": "September 11 attacks",
+		"2001-09-12": "September 11 attacks",
+		"2001-09-13": "September 11 attacks",
+		"2001-09-14": "September 11 attacks",
+		"2004-06-11": "National Day of Mourning for Ronald Reagan",
+		"2007-01-02": "National Day of Mourning for Gerald Ford",
+		"2012-10-29": "Hurricane Sandy",
+		"2012-10-30": "Hurricane Sandy",
+		"2018-12-05": "National Day of Mourning for George H. W. Bush",
+		"2025-01-09": "National Day of Mourning for Jimmy Carter",
+	},
+}
+
+// usEquityRules returns the rule-based NYSE holidays and early closes of a year
+func usEquityRules(year int) ([]Holiday, []time.Time) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, newYork)
+	}
+
+	var holidays []Holiday
+	add := func(date time.Time, name string) {
+		holidays = append(holidays, Holiday{Date: date, Name: name})
+	}
+
+	// A Saturday New Year's Day is not observed on the Friday before, since
+	// that Friday closes the previous year
+	if newYear := day(time.January, 1); newYear.Weekday() == time.Sunday {
+		add(newYear.AddDate(0, 0, 1), "New Year's Day")
+	} else if newYear.Weekday() != time.Saturday {
+		add(newYear, "New Year's Day")
+	}
+	if year >= 1998 {
+		add(nthWeekday(year, time.January, time.Monday, 3), "Martin Luther King Jr. Day")
+	}
+	add(nthWeekday(year, time.February, time.Monday, 3), "Washington's Birthday")
+	add(easter(year).AddDate(0, 0, -2), "Good Friday")
+	add(lastWeekday(year, time.May, time.Monday), "Memorial Day")
+	if year >= 2022 {
+		add(observed(day(time.June, 19)), "Juneteenth National Independence Day")
+	}
+	add(observed(day(time.July, 4)), "Independence Day")
+	add(nthWeekday(year, time.September, time.Monday, 1), "Labor Day")
+	thanksgiving := nthWeekday(year, time.November, time.Thursday, 4)
+	add(thanksgiving, "Thanksgiving Day")
+	add(observed(day(time.December, 25)), "Christmas Day")
+
+	// Early closes fall on the trading days next to Independence Day,
+	// Thanksgiving and Christmas
+	var earlyCloses []time.Time
+	if weekday := day(time.July, 4).Weekday(); weekday >= time.Tuesday && weekday <= time.Friday {
+		earlyCloses = append(earlyCloses, day(time.July, 3))
+	}
+	earlyCloses = append(earlyCloses, thanksgiving.AddDate(0, 0, 1))
+	if weekday := day(time.December, 24).Weekday(); weekday >= time.Monday && weekday <= time.Thursday {
+		earlyCloses = append(earlyCloses, day(time.December, 24))
+	}
+
+	return holidays, earlyCloses
+}
+
+// observed moves a Saturday holiday to Friday and a Sunday holiday to Monday
+func observed(date time.Time) time.Time {
+	switch date.Weekday() {
+	case time.Saturday:
+		return date.AddDate(0, 0, -1)
+	case time.Sunday:
+		return date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+// nthWeekday returns the n-th given weekday of a month
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, newYork)
+	offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+	return first.AddDate(0, 0, offset+7*(n-1))
+}
+
+// lastWeekday returns the last given weekday of a month
+func lastWeekday(year int, month time.Month, weekday time.Weekday) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, newYork)
+	offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+	return last.AddDate(0, 0, -offset)
+}
+
+// easter returns Easter Sunday of a year using the anonymous Gregorian algorithm
+func easter(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, newYork)
+}
@@ -0,0 +1,60 @@
+package alphavantage
+
+import (
+	"strings"
+	"time"
+
+	"stock/alphavantage/market"
+	"stock/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MarketStatusResponse defines the response format for market status data
+// @Description Market status response data structure
+type MarketStatusResponse struct {
+	Version   string               `json:"version"`
+	Timestamp string               `json:"timestamp"`
+	Cache     string               `json:"cache,omitempty"`
+	Data      []market.MarketState `json:"data"`
+}
+
+// GetMarketStatus handles requests for the open or closed state of global markets
+// @Summary Get the current status of global markets
+// @Description Returns each region's market hours and status with the next open and close and the seconds until the market opens (while closed) or closes (while open)
+// @Tags market
+// @Produce json,text/csv,application/x-ndjson
+// @Param region query string false "Comma-separated regions to include (e.g., United States,Japan)"
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} MarketStatusResponse "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/market/status [get]
+func (h *Handler) GetMarketStatus(c *gin.Context) {
+	var regions []string
+	if region := c.Query("region"); region != "" {
+		regions = strings.Split(region, ",")
+	}
+
+	ctx, cache := requestContext(c)
+
+	data, err := h.client.GetMarketStatus(ctx)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	states := data.States(time.Now(), regions...)
+
+	response := MarketStatusResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Cache:     setCacheHeader(c, cache),
+		Data:      states,
+	}
+
+	respond(c, response, states)
+}
@@ -1,9 +1,8 @@
 package news
 
 import (
-	"encoding/json"
+	"context"
 	"stock/common"
-	"stock/config"
 	"strconv"
 )
 
@@ -50,14 +49,10 @@ type GetNewsAndSentimentResponse struct {
 	RelevanceScoreDefinition string     `json:"relevance_score_definition"`
 }
 
-func GetNewsAndSentiment(params GetNewsAndSentimentParams) (*GetNewsAndSentimentResponse, error) {
-	// Get API configuration
-	cfg := config.GetConfig()
-
+func GetNewsAndSentiment(ctx context.Context, client *common.Client, params GetNewsAndSentimentParams) (*GetNewsAndSentimentResponse, error) {
 	// Building query parameters
 	queryParams := map[string]string{
 		"function": "NEWS_SENTIMENT",
-		"apikey":   cfg.AlphaVantageAPIKey,
 	}
 
 	// Add optional parameters if they are provided
@@ -80,16 +75,9 @@ func GetNewsAndSentiment(params GetNewsAndSentimentParams) (*GetNewsAndSentiment
 		queryParams["limit"] = strconv.Itoa(params.Limit)
 	}
 
-	// Make HTTP request
-	respBody, err := common.GetAPIRequest(cfg.AlphaVantageBaseURL, queryParams)
-	if err != nil {
-		return nil, err
-	}
-	defer respBody.Close()
-
 	// Convert generic response to CompanyOverviewResponse
 	resp := &GetNewsAndSentimentResponse{}
-	if err := json.NewDecoder(respBody).Decode(resp); err != nil {
+	if err := client.GetJSON(ctx, queryParams, resp); err != nil {
 		return nil, err
 	}
 
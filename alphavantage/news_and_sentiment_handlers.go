@@ -1,7 +1,6 @@
 package alphavantage
 
 import (
-	"net/http"
 	"stock/alphavantage/news"
 	"strconv"
 	"time"
@@ -14,6 +13,7 @@ import (
 type NewsAndSentimentResponse struct {
 	Version   string                            `json:"version"`
 	Timestamp string                            `json:"timestamp"`
+	Cache     string                            `json:"cache,omitempty"`
 	Data      *news.GetNewsAndSentimentResponse `json:"data"`
 }
 
@@ -21,17 +21,24 @@ type NewsAndSentimentResponse struct {
 // @Summary Get news and sentiment data for specified parameters
 // @Description Returns news articles and sentiment analysis based on tickers, topics, and time range
 // @Tags news
-// @Produce json
+// @Produce json,text/csv,application/x-ndjson
 // @Param tickers query string false "Comma-separated list of stock symbols (e.g., AAPL,MSFT)"
 // @Param topics query string false "Comma-separated list of topics"
 // @Param time_from query string false "Start time in YYYYMMDDTHHMM format"
 // @Param time_to query string false "End time in YYYYMMDDTHHMM format"
 // @Param sort query string false "Sort order: LATEST, EARLIEST, or RELEVANCE"
 // @Param limit query int false "Number of results (default: 50, max: 1000)"
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
 // @Success 200 {object} NewsAndSentimentResponse "Successful operation"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
 // @Router /v1/news/sentiment [get]
-func GetNewsAndSentiment(c *gin.Context) {
+func (h *Handler) GetNewsAndSentiment(c *gin.Context) {
 	// Extract query parameters
 	params := news.GetNewsAndSentimentParams{
 		Tickers:  c.Query("tickers"),
@@ -49,12 +56,12 @@ func GetNewsAndSentiment(c *gin.Context) {
 		}
 	}
 
+	ctx, cache := requestContext(c)
+
 	// Get news and sentiment data
-	data, err := news.GetNewsAndSentiment(params)
+	data, err := h.client.GetNewsAndSentiment(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
 
@@ -62,8 +69,9 @@ func GetNewsAndSentiment(c *gin.Context) {
 	response := NewsAndSentimentResponse{
 		Version:   "1.0", // TODO: Replace with config value once GetConfig() is implemented
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Cache:     setCacheHeader(c, cache),
 		Data:      data,
 	}
 
-	c.JSON(http.StatusOK, response)
+	respond(c, response, data.Items)
 }
@@ -0,0 +1,78 @@
+package options
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPriceReference(t *testing.T) {
+	// Hull, Options, Futures and Other Derivatives, example 15.6
+	in := Inputs{Spot: 42, Strike: 40, Years: 0.5, Rate: 0.1, Volatility: 0.2, Call: true}
+	if got := BlackScholes.Price(in); math.Abs(got-4.76) > 0.005 {
+		t.Errorf("call price = %.4f, want 4.76", got)
+	}
+	in.Call = false
+	if got := BlackScholes.Price(in); math.Abs(got-0.81) > 0.005 {
+		t.Errorf("put price = %.4f, want 0.81", got)
+	}
+
+	if vol, err := BlackScholes.ImpliedVolatility(in, 0.8086); err != nil || math.Abs(vol-0.2) > 1e-3 {
+		t.Errorf("ImpliedVolatility(0.8086) = %v, %v, want 0.20", vol, err)
+	}
+}
+
+func TestImpliedVolatilityRoundTrip(t *testing.T) {
+	for _, model := range []Model{BlackScholes, Black76} {
+		for _, strike := range []float64{50, 80, 95, 100, 105, 120, 200} {
+			for _, vol := range []float64{0.05, 0.2, 0.6, 1.5} {
+				for _, years := range []float64{7.0 / 365, 0.25, 2} {
+					for _, call := range []bool{true, false} {
+						in := Inputs{Spot: 100, Strike: strike, Years: years, Rate: 0.04, Volatility: vol, Call: call}
+						price := model.Price(in)
+						// Prices this close to intrinsic value carry no volatility information
+						in.Volatility = ivLow
+						if price-model.Price(in) < 1e-6 {
+							continue
+						}
+
+						got, err := model.ImpliedVolatility(in, price)
+						if err != nil {
+							t.Errorf("%s %+v price %g: error = %v", model, in, price, err)
+							continue
+						}
+						in.Volatility = got
+						if diff := math.Abs(model.Price(in) - price); diff > 1e-6 {
+							t.Errorf("%s %+v: vol %g reprices %g off, want %g", model, in, got, diff, vol)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestImpliedVolatilityBounds(t *testing.T) {
+	call := Inputs{Spot: 100, Strike: 100, Years: 1, Rate: 0.05, Call: true}
+	put := call
+	put.Call = false
+	deepCall := Inputs{Spot: 100, Strike: 50, Years: 1, Rate: 0.05, Call: true}
+
+	tests := []struct {
+		name  string
+		in    Inputs
+		price float64
+	}{
+		{"zero price", call, 0},
+		{"call above spot", call, 100},
+		{"put above discounted strike", put, 96},
+		{"call below intrinsic value", deepCall, 50},
+		{"expired", Inputs{Spot: 100, Strike: 100, Call: true}, 5},
+		{"no spot", Inputs{Strike: 100, Years: 1, Call: true}, 5},
+	}
+	for _, tt := range tests {
+		if vol, err := BlackScholes.ImpliedVolatility(tt.in, tt.price); !errors.Is(err, ErrNoImpliedVolatility) {
+			t.Errorf("%s: ImpliedVolatility() = %v, %v, want ErrNoImpliedVolatility", tt.name, vol, err)
+		}
+	}
+}
@@ -0,0 +1,489 @@
+package options
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"stock/alphavantage/timeseries"
+	"stock/common"
+)
+
+// ErrContractNotFound is returned when a contract is not part of the chain
+var ErrContractNotFound = errors.New("option contract not found")
+
+// Option types
+const (
+	TypeCall = "call"
+	TypePut  = "put"
+)
+
+// Greeks sources
+const (
+	GreeksAuto     = "auto"     // Upstream Greeks, computed ones where upstream has none
+	GreeksUpstream = "upstream" // Upstream Greeks only
+	GreeksComputed = "computed" // Computed Greeks only
+)
+
+// compactDays is how far back a date may be for its spot price to be in the
+// compact daily series, which holds the latest 100 sessions
+const compactDays = 140
+
+// expiryHour is when US equity options stop trading on their expiration day
+const expiryHour = 16
+
+// marketLocation is the time zone option expiries are stated in
+var marketLocation, _ = time.LoadLocation("America/New_York")
+
+// HistoricalOptionsParams holds parameters for retrieving an options chain
+type HistoricalOptionsParams struct {
+	Symbol string
+	Date   string // YYYY-MM-DD, the previous session when empty
+}
+
+// HistoricalOptionsResponse defines the structure of HISTORICAL_OPTIONS
+type HistoricalOptionsResponse struct {
+	Endpoint string        `json:"endpoint"`
+	Message  string        `json:"message"`
+	Data     []RawContract `json:"data"`
+}
+
+// RawContract is a contract as Alpha Vantage reports it, every value a string
+type RawContract struct {
+	ContractID        string `json:"contractID"`
+	Symbol            string `json:"symbol"`
+	Expiration        string `json:"expiration"`
+	Strike            string `json:"strike"`
+	Type              string `json:"type"`
+	Last              string `json:"last"`
+	Mark              string `json:"mark"`
+	Bid               string `json:"bid"`
+	BidSize           string `json:"bid_size"`
+	Ask               string `json:"ask"`
+	AskSize           string `json:"ask_size"`
+	Volume            string `json:"volume"`
+	OpenInterest      string `json:"open_interest"`
+	Date              string `json:"date"`
+	ImpliedVolatility string `json:"implied_volatility"`
+	Delta             string `json:"delta"`
+	Gamma             string `json:"gamma"`
+	Theta             string `json:"theta"`
+	Vega              string `json:"vega"`
+	Rho               string `json:"rho"`
+}
+
+// Contract is the typed form of RawContract with values derived from the spot price
+type Contract struct {
+	ContractID        string         `json:"contract_id"`
+	Symbol            string         `json:"symbol"`
+	Expiration        time.Time      `json:"expiration"`
+	Strike            common.Decimal `json:"strike"`
+	Type              string         `json:"type"` // call or put
+	Last              common.Decimal `json:"last"`
+	Mark              common.Decimal `json:"mark"`
+	Bid               common.Decimal `json:"bid"`
+	BidSize           int64          `json:"bid_size"`
+	Ask               common.Decimal `json:"ask"`
+	AskSize           int64          `json:"ask_size"`
+	Volume            int64          `json:"volume"`
+	OpenInterest      int64          `json:"open_interest"`
+	Date              time.Time      `json:"date"`
+	ImpliedVolatility *float64       `json:"implied_volatility"`
+	DaysToExpiry      int            `json:"days_to_expiry"`
+	Moneyness         *float64       `json:"moneyness"` // Strike over spot
+	Greeks            Greeks         `json:"greeks"`
+	GreeksSource      string         `json:"greeks_source,omitempty"` // upstream or computed, empty when unknown
+
+	upstreamGreeks Greeks
+}
+
+// Call reports whether the contract is a call
+func (c *Contract) Call() bool {
+	return c.Type == TypeCall
+}
+
+// Contracts returns the typed contracts ordered by expiration, strike and type
+func (r *HistoricalOptionsResponse) Contracts() ([]Contract, error) {
+	contracts := make([]Contract, 0, len(r.Data))
+	for _, raw := range r.Data {
+		contract, err := raw.parse()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", raw.ContractID, err)
+		}
+		contracts = append(contracts, contract)
+	}
+
+	sort.SliceStable(contracts, func(i, j int) bool {
+		a, b := contracts[i], contracts[j]
+		if !a.Expiration.Equal(b.Expiration) {
+			return a.Expiration.Before(b.Expiration)
+		}
+		if cmp := a.Strike.Cmp(b.Strike); cmp != 0 {
+			return cmp < 0
+		}
+		return a.Type < b.Type
+	})
+	return contracts, nil
+}
+
+// parse converts the strings of a raw contract
+func (raw RawContract) parse() (Contract, error) {
+	contract := Contract{
+		ContractID: raw.ContractID,
+		Symbol:     raw.Symbol,
+		Type:       strings.ToLower(raw.Type),
+	}
+	if contract.Type != TypeCall && contract.Type != TypePut {
+		return contract, fmt.Errorf("invalid option type %q", raw.Type)
+	}
+
+	for _, date := range []struct {
+		value string
+		dst   *time.Time
+	}{{raw.Expiration, &contract.Expiration}, {raw.Date, &contract.Date}} {
+		t, err := common.ParseDate(date.value)
+		if err != nil {
+			return contract, err
+		}
+		if t != nil {
+			*date.dst = *t
+		}
+	}
+
+	var err error
+	for _, decimal := range []struct {
+		value string
+		dst   *common.Decimal
+	}{
+		{raw.Strike, &contract.Strike}, {raw.Last, &contract.Last}, {raw.Mark, &contract.Mark},
+		{raw.Bid, &contract.Bid}, {raw.Ask, &contract.Ask},
+	} {
+		if *decimal.dst, err = common.ParseDecimal(decimal.value); err != nil {
+			return contract, err
+		}
+	}
+
+	for _, count := range []struct {
+		value string
+		dst   *int64
+	}{
+		{raw.BidSize, &contract.BidSize}, {raw.AskSize, &contract.AskSize},
+		{raw.Volume, &contract.Volume}, {raw.OpenInterest, &contract.OpenInterest},
+	} {
+		if *count.dst, err = parseCount(count.value); err != nil {
+			return contract, err
+		}
+	}
+
+	if contract.ImpliedVolatility, err = parseFloat(raw.ImpliedVolatility); err != nil {
+		return contract, err
+	}
+	// Alpha Vantage reports 0 when it has no implied volatility
+	if iv := contract.ImpliedVolatility; iv != nil && *iv <= 0 {
+		contract.ImpliedVolatility = nil
+	}
+
+	greeks := &contract.upstreamGreeks
+	for _, greek := range []struct {
+		value string
+		dst   **float64
+	}{
+		{raw.Delta, &greeks.Delta}, {raw.Gamma, &greeks.Gamma}, {raw.Theta, &greeks.Theta},
+		{raw.Vega, &greeks.Vega}, {raw.Rho, &greeks.Rho},
+	} {
+		if *greek.dst, err = parseFloat(greek.value); err != nil {
+			return contract, err
+		}
+	}
+	return contract, nil
+}
+
+// parseCount parses a size or volume, placeholders counting as zero
+func parseCount(value string) (int64, error) {
+	d, err := common.ParseDecimal(value)
+	if err != nil || !d.Valid() {
+		return 0, err
+	}
+	f, _ := d.Float64()
+	return int64(f), nil
+}
+
+// parseFloat parses a number, nil for Alpha Vantage placeholders
+func parseFloat(value string) (*float64, error) {
+	d, err := common.ParseDecimal(value)
+	if err != nil || !d.Valid() {
+		return nil, err
+	}
+	f, _ := d.Float64()
+	return &f, nil
+}
+
+// Valuation is the market data contracts are valued with
+type Valuation struct {
+	Date   time.Time // Session the chain was recorded on
+	Spot   float64   // Underlying close of that session
+	Rate   float64   // Continuously compounded risk-free rate
+	Model  Model
+	Greeks string // auto, upstream or computed
+}
+
+// Years returns the time from the valuation session close to expiry in years
+func (v Valuation) Years(expiration time.Time) float64 {
+	close := func(t time.Time) time.Time {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, expiryHour, 0, 0, 0, marketLocation)
+	}
+	return close(expiration).Sub(close(v.Date)).Hours() / 24 / daysPerYear
+}
+
+// Value fills the days to expiry, moneyness and Greeks of a contract
+func (v Valuation) Value(c *Contract) {
+	c.DaysToExpiry = int(math.Round(c.Expiration.Sub(v.Date).Hours() / 24))
+
+	strike, _ := c.Strike.Float64()
+	if v.Spot > 0 {
+		c.Moneyness = ptr(strike / v.Spot)
+	}
+
+	c.Greeks, c.GreeksSource = Greeks{}, ""
+	if v.Greeks != GreeksComputed && !c.upstreamGreeks.IsZero() {
+		c.Greeks, c.GreeksSource = c.upstreamGreeks, GreeksUpstream
+		return
+	}
+	if v.Greeks == GreeksUpstream || c.ImpliedVolatility == nil {
+		return
+	}
+
+	greeks, ok := v.Model.Greeks(Inputs{
+		Spot:       v.Spot,
+		Strike:     strike,
+		Years:      v.Years(c.Expiration),
+		Rate:       v.Rate,
+		Volatility: *c.ImpliedVolatility,
+		Call:       c.Call(),
+	})
+	if ok {
+		c.Greeks, c.GreeksSource = greeks, GreeksComputed
+	}
+}
+
+// Chain is an options chain valued against the underlying's close
+type Chain struct {
+	Symbol       string     `json:"symbol"`
+	Date         string     `json:"date"`
+	Spot         *float64   `json:"spot"` // Underlying close on Date
+	RiskFreeRate float64    `json:"risk_free_rate"`
+	Model        Model      `json:"model"`
+	Contracts    []Contract `json:"contracts"`
+}
+
+// ChainParams holds parameters for retrieving a valued options chain
+type ChainParams struct {
+	Symbol string
+	Date   string     // YYYY-MM-DD, the previous session when empty
+	Rates  RateSource // Risk-free rate of the session, 0 when nil
+	Model  Model      // black_scholes when empty
+	Greeks string     // auto when empty
+}
+
+// GetHistoricalOptions fetches the options chain of a symbol on a date
+func GetHistoricalOptions(ctx context.Context, client *common.Client, params HistoricalOptionsParams) (*HistoricalOptionsResponse, error) {
+	// Building query parameters
+	queryParams := map[string]string{
+		"function": "HISTORICAL_OPTIONS",
+		"symbol":   params.Symbol,
+	}
+	if params.Date != "" {
+		queryParams["date"] = params.Date
+	}
+
+	// Make HTTP request and parse the response
+	result := &HistoricalOptionsResponse{}
+	if err := client.GetJSON(ctx, queryParams, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetChain fetches the options chain of a symbol on a date and values it
+// against the underlying's daily close of the same session
+func GetChain(ctx context.Context, client *common.Client, params ChainParams) (*Chain, error) {
+	if params.Model == "" {
+		params.Model = BlackScholes
+	}
+	if params.Greeks == "" {
+		params.Greeks = GreeksAuto
+	}
+	if params.Rates == nil {
+		params.Rates = FixedRate(0)
+	}
+
+	data, err := GetHistoricalOptions(ctx, client, HistoricalOptionsParams{Symbol: params.Symbol, Date: params.Date})
+	if err != nil {
+		return nil, err
+	}
+	contracts, err := data.Contracts()
+	if err != nil {
+		return nil, err
+	}
+
+	chain := &Chain{
+		Symbol:    params.Symbol,
+		Date:      params.Date,
+		Model:     params.Model,
+		Contracts: contracts,
+	}
+	if len(contracts) == 0 {
+		return chain, nil
+	}
+
+	valuation := Valuation{Date: contracts[0].Date, Model: params.Model, Greeks: params.Greeks}
+	chain.Date = valuation.Date.Format(common.DateLayout)
+	if valuation.Rate, err = params.Rates.Rate(ctx, client, valuation.Date); err != nil {
+		return nil, fmt.Errorf("risk-free rate: %w", err)
+	}
+	chain.RiskFreeRate = valuation.Rate
+	if valuation.Spot, err = spotPrice(ctx, client, params.Symbol, valuation.Date); err != nil {
+		return nil, fmt.Errorf("spot price: %w", err)
+	}
+	if valuation.Spot > 0 {
+		chain.Spot = ptr(valuation.Spot)
+	}
+
+	for i := range chain.Contracts {
+		valuation.Value(&chain.Contracts[i])
+	}
+	return chain, nil
+}
+
+// Contract returns the contract with the given ID
+func (c *Chain) Contract(id string) (*Contract, error) {
+	for i := range c.Contracts {
+		if strings.EqualFold(c.Contracts[i].ContractID, id) {
+			return &c.Contracts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s on %s", ErrContractNotFound, id, c.Date)
+}
+
+// spotPrice returns the close of the last daily bar on or before date, 0 when there is none
+func spotPrice(ctx context.Context, client *common.Client, symbol string, date time.Time) (float64, error) {
+	outputSize := "compact"
+	if time.Since(date) > compactDays*24*time.Hour {
+		outputSize = "full"
+	}
+
+	bars, err := timeseries.GetTimeSeries(ctx, client, timeseries.TimeSeriesParams{
+		Function:   "TIME_SERIES_DAILY",
+		Symbol:     symbol,
+		OutputSize: outputSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	last := date.Format(common.DateLayout)
+	var spot float64
+	for _, bar := range bars {
+		if bar.Time.Format(common.DateLayout) > last {
+			break
+		}
+		spot = bar.Close
+	}
+	return spot, nil
+}
+
+// Filter narrows a chain
+type Filter struct {
+	ExpiryFrom, ExpiryTo time.Time // Inclusive, zero when open
+	Type                 string    // call or put, both when empty
+	Moneyness            string    // itm, atm or otm, all when empty
+	MinMoneyness         float64   // Lowest strike over spot, 0 when open
+	MaxMoneyness         float64   // Highest strike over spot, 0 when open
+}
+
+// Moneyness classes
+const (
+	InTheMoney    = "itm"
+	AtTheMoney    = "atm"
+	OutOfTheMoney = "otm"
+)
+
+// atmBand is how far strike over spot may be from 1 for a contract to be at the money
+const atmBand = 0.025
+
+// ParseMoneyness parses itm, atm, otm or a strike over spot range such as 0.9-1.1 into the filter
+func (f *Filter) ParseMoneyness(value string) error {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "", "all":
+		return nil
+	case InTheMoney, AtTheMoney, OutOfTheMoney:
+		f.Moneyness = value
+		return nil
+	}
+
+	low, high, ok := strings.Cut(value, "-")
+	if !ok {
+		return fmt.Errorf("moneyness must be itm, atm, otm or a strike/spot range such as 0.9-1.1")
+	}
+	var err error
+	if f.MinMoneyness, err = strconv.ParseFloat(low, 64); err != nil || f.MinMoneyness < 0 {
+		return fmt.Errorf("invalid moneyness lower bound %q", low)
+	}
+	if f.MaxMoneyness, err = strconv.ParseFloat(high, 64); err != nil || f.MaxMoneyness < f.MinMoneyness {
+		return fmt.Errorf("invalid moneyness upper bound %q", high)
+	}
+	return nil
+}
+
+// Apply returns the contracts passing the filter. Moneyness filters drop
+// every contract when the spot price is unknown.
+func (f Filter) Apply(contracts []Contract) []Contract {
+	filtered := make([]Contract, 0, len(contracts))
+	for _, c := range contracts {
+		if f.matches(&c) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// matches reports whether a contract passes the filter
+func (f Filter) matches(c *Contract) bool {
+	if !f.ExpiryFrom.IsZero() && c.Expiration.Before(f.ExpiryFrom) {
+		return false
+	}
+	if !f.ExpiryTo.IsZero() && c.Expiration.After(f.ExpiryTo) {
+		return false
+	}
+	if f.Type != "" && c.Type != f.Type {
+		return false
+	}
+	if f.Moneyness == "" && f.MinMoneyness == 0 && f.MaxMoneyness == 0 {
+		return true
+	}
+	if c.Moneyness == nil {
+		return false
+	}
+
+	m := *c.Moneyness
+	if f.MaxMoneyness > 0 && (m < f.MinMoneyness || m > f.MaxMoneyness) {
+		return false
+	}
+	switch f.Moneyness {
+	case AtTheMoney:
+		return math.Abs(m-1) <= atmBand
+	case InTheMoney:
+		return (c.Call() && m < 1) || (!c.Call() && m > 1)
+	case OutOfTheMoney:
+		return (c.Call() && m > 1) || (!c.Call() && m < 1)
+	}
+	return true
+}
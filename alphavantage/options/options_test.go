@@ -0,0 +1,226 @@
+package options
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"stock/common"
+)
+
+// rawContract is a fully quoted IBM call
+func rawContract() RawContract {
+	return RawContract{
+		ContractID: "IBM250117C00100000", Symbol: "IBM", Expiration: "2025-01-17", Strike: "100.00", Type: "call",
+		Last: "5.10", Mark: "5.15", Bid: "5.05", BidSize: "12", Ask: "5.25", AskSize: "3", Volume: "140", OpenInterest: "2200",
+		Date: "2024-11-15", ImpliedVolatility: "0.25", Delta: "0.55", Gamma: "0.03", Theta: "-0.04", Vega: "0.18", Rho: "0.09",
+	}
+}
+
+func TestParseContract(t *testing.T) {
+	c, err := rawContract().parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Type != TypeCall || c.Strike.String() != "100.00" || c.BidSize != 12 || c.OpenInterest != 2200 {
+		t.Errorf("parse() = %+v", c)
+	}
+	if c.Expiration.Format(common.DateLayout) != "2025-01-17" || c.Date.Format(common.DateLayout) != "2024-11-15" {
+		t.Errorf("parse() dates = %s, %s", c.Expiration, c.Date)
+	}
+	if c.ImpliedVolatility == nil || *c.ImpliedVolatility != 0.25 || c.upstreamGreeks.Delta == nil || *c.upstreamGreeks.Delta != 0.55 {
+		t.Errorf("parse() volatility and Greeks = %s, %+v", show(c.ImpliedVolatility), c.upstreamGreeks)
+	}
+
+	// Placeholders leave values unknown, counts zero
+	raw := rawContract()
+	raw.Type = "PUT"
+	raw.Last, raw.Bid, raw.BidSize, raw.Volume = "None", "-", "", "n/a"
+	raw.Delta, raw.Gamma, raw.Theta, raw.Vega, raw.Rho = "", "None", "-", "null", "n/a"
+	if c, err = raw.parse(); err != nil {
+		t.Fatal(err)
+	}
+	if c.Type != TypePut || c.Last.Valid() || c.Bid.Valid() || c.BidSize != 0 || c.Volume != 0 || !c.upstreamGreeks.IsZero() {
+		t.Errorf("parse() with placeholders = %+v, greeks %+v", c, c.upstreamGreeks)
+	}
+
+	// Alpha Vantage reports 0 when it has no implied volatility
+	for _, iv := range []string{"0", "0.00000", "", "None"} {
+		raw := rawContract()
+		raw.ImpliedVolatility = iv
+		if c, err := raw.parse(); err != nil || c.ImpliedVolatility != nil {
+			t.Errorf("parse() implied volatility %q = %s, %v, want null", iv, show(c.ImpliedVolatility), err)
+		}
+	}
+
+	invalid := map[string]func(*RawContract){
+		"type":       func(r *RawContract) { r.Type = "straddle" },
+		"expiration": func(r *RawContract) { r.Expiration = "01/17/2025" },
+		"strike":     func(r *RawContract) { r.Strike = "one hundred" },
+		"volume":     func(r *RawContract) { r.Volume = "lots" },
+		"delta":      func(r *RawContract) { r.Delta = "high" },
+	}
+	for name, mutate := range invalid {
+		raw := rawContract()
+		mutate(&raw)
+		if _, err := raw.parse(); err == nil {
+			t.Errorf("parse() with an invalid %s succeeded, want an error", name)
+		}
+	}
+}
+
+func TestValuationGreeksSource(t *testing.T) {
+	date := time.Date(2024, time.November, 15, 0, 0, 0, 0, time.UTC)
+	withUpstream, err := rawContract().parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := rawContract()
+	raw.Delta, raw.Gamma, raw.Theta, raw.Vega, raw.Rho = "", "", "", "", ""
+	withoutUpstream, _ := raw.parse()
+	raw.ImpliedVolatility = "0"
+	withoutVolatility, _ := raw.parse()
+
+	tests := []struct {
+		name      string
+		greeks    string
+		contract  Contract
+		source    string
+		wantDelta float64 // 0 when no Greeks are expected
+	}{
+		{"auto prefers upstream", GreeksAuto, withUpstream, GreeksUpstream, 0.55},
+		{"auto computes what upstream lacks", GreeksAuto, withoutUpstream, GreeksComputed, -1},
+		{"upstream only", GreeksUpstream, withUpstream, GreeksUpstream, 0.55},
+		{"upstream only without upstream", GreeksUpstream, withoutUpstream, "", 0},
+		{"computed only", GreeksComputed, withUpstream, GreeksComputed, -1},
+		{"computed without a volatility", GreeksComputed, withoutVolatility, "", 0},
+		{"auto without either", GreeksAuto, withoutVolatility, "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := Valuation{Date: date, Spot: 100, Rate: 0.05, Model: BlackScholes, Greeks: tt.greeks}
+			c := tt.contract
+			v.Value(&c)
+
+			if c.DaysToExpiry != 63 || c.Moneyness == nil || *c.Moneyness != 1 {
+				t.Errorf("Value() days %d moneyness %s, want 63 and 1", c.DaysToExpiry, show(c.Moneyness))
+			}
+			if c.GreeksSource != tt.source {
+				t.Errorf("GreeksSource = %q, want %q", c.GreeksSource, tt.source)
+			}
+			switch {
+			case tt.wantDelta == 0:
+				if !c.Greeks.IsZero() {
+					t.Errorf("Greeks = %+v, want none", c.Greeks)
+				}
+			case tt.wantDelta < 0:
+				// Computed from the 25% volatility of a 63 day at the money call
+				want, _ := BlackScholes.Greeks(Inputs{Spot: 100, Strike: 100, Years: v.Years(c.Expiration), Rate: 0.05, Volatility: 0.25, Call: true})
+				if c.Greeks.Delta == nil || math.Abs(*c.Greeks.Delta-*want.Delta) > 1e-12 {
+					t.Errorf("computed delta = %s, want %.4f", show(c.Greeks.Delta), *want.Delta)
+				}
+			default:
+				if c.Greeks.Delta == nil || *c.Greeks.Delta != tt.wantDelta {
+					t.Errorf("delta = %s, want %g", show(c.Greeks.Delta), tt.wantDelta)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	contract := func(typ string, moneyness float64) Contract {
+		return Contract{Type: typ, Expiration: time.Date(2025, time.January, 17, 0, 0, 0, 0, time.UTC), Moneyness: ptr(moneyness)}
+	}
+	// Strike over spot of each contract, the same for calls and puts
+	moneyness := []float64{0.90, 0.98, 1.00, 1.02, 1.10}
+
+	tests := []struct {
+		filter Filter
+		calls  []float64
+		puts   []float64
+	}{
+		{Filter{}, moneyness, moneyness},
+		{Filter{Moneyness: InTheMoney}, []float64{0.90, 0.98}, []float64{1.02, 1.10}},
+		{Filter{Moneyness: AtTheMoney}, []float64{0.98, 1.00, 1.02}, []float64{0.98, 1.00, 1.02}},
+		{Filter{Moneyness: OutOfTheMoney}, []float64{1.02, 1.10}, []float64{0.90, 0.98}},
+		{Filter{MinMoneyness: 0.95, MaxMoneyness: 1.05}, []float64{0.98, 1.00, 1.02}, []float64{0.98, 1.00, 1.02}},
+		{Filter{MinMoneyness: 0.95, MaxMoneyness: 1.05, Moneyness: OutOfTheMoney}, []float64{1.02}, []float64{0.98}},
+		{Filter{Type: TypePut, Moneyness: InTheMoney}, nil, []float64{1.02, 1.10}},
+	}
+	for _, tt := range tests {
+		for _, side := range []struct {
+			typ  string
+			want []float64
+		}{{TypeCall, tt.calls}, {TypePut, tt.puts}} {
+			var got []float64
+			for _, m := range moneyness {
+				if c := contract(side.typ, m); tt.filter.matches(&c) {
+					got = append(got, m)
+				}
+			}
+			if !equalFloats(got, side.want) {
+				t.Errorf("%+v %ss = %v, want %v", tt.filter, side.typ, got, side.want)
+			}
+		}
+	}
+
+	// Moneyness filters drop contracts without a spot price
+	if (Filter{Moneyness: AtTheMoney}).matches(&Contract{Type: TypeCall}) {
+		t.Error("atm filter kept a contract without moneyness")
+	}
+	if !(Filter{}).matches(&Contract{Type: TypeCall}) {
+		t.Error("empty filter dropped a contract without moneyness")
+	}
+
+	// Expiry bounds are inclusive
+	c := contract(TypeCall, 1)
+	for _, tt := range []struct {
+		from, to string
+		want     bool
+	}{
+		{"2025-01-17", "2025-01-17", true},
+		{"2025-01-18", "", false},
+		{"", "2025-01-16", false},
+	} {
+		var f Filter
+		if tt.from != "" {
+			f.ExpiryFrom, _ = time.Parse(common.DateLayout, tt.from)
+		}
+		if tt.to != "" {
+			f.ExpiryTo, _ = time.Parse(common.DateLayout, tt.to)
+		}
+		if got := f.matches(&c); got != tt.want {
+			t.Errorf("expiry %s to %s matches = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestParseMoneyness(t *testing.T) {
+	var f Filter
+	if err := f.ParseMoneyness(" ITM "); err != nil || f.Moneyness != InTheMoney {
+		t.Errorf("ParseMoneyness(ITM) = %+v, %v", f, err)
+	}
+	f = Filter{}
+	if err := f.ParseMoneyness("0.9-1.1"); err != nil || f.MinMoneyness != 0.9 || f.MaxMoneyness != 1.1 {
+		t.Errorf("ParseMoneyness(0.9-1.1) = %+v, %v", f, err)
+	}
+	for _, value := range []string{"deep", "1.1-0.9", "-1", "a-b"} {
+		f = Filter{}
+		if err := f.ParseMoneyness(value); err == nil {
+			t.Errorf("ParseMoneyness(%q) succeeded, want an error", value)
+		}
+	}
+}
+
+func equalFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
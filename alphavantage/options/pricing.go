@@ -0,0 +1,131 @@
+package options
+
+import (
+	"math"
+)
+
+// Model is an option pricing model
+type Model string
+
+// Pricing models
+const (
+	BlackScholes Model = "black_scholes" // European options on the spot price
+	Black76      Model = "black76"       // European options on the forward price
+)
+
+// daysPerYear converts year fractions to calendar days for theta
+const daysPerYear = 365
+
+// Inputs are the market data an option is priced from
+type Inputs struct {
+	Spot       float64 // Underlying price, converted to a forward for Black-76
+	Strike     float64
+	Years      float64 // Time to expiry in years
+	Rate       float64 // Continuously compounded risk-free rate
+	Volatility float64 // Annualized implied volatility
+	Call       bool
+}
+
+// valid reports whether the inputs describe an option that can be priced
+func (in Inputs) valid() bool {
+	return in.Spot > 0 && in.Strike > 0 && in.Years > 0 && in.Volatility > 0
+}
+
+// Greeks are the sensitivities of an option price. Theta is per calendar
+// day, vega per volatility point and rho per percentage point of rate.
+type Greeks struct {
+	Delta *float64 `json:"delta"`
+	Gamma *float64 `json:"gamma"`
+	Theta *float64 `json:"theta"`
+	Vega  *float64 `json:"vega"`
+	Rho   *float64 `json:"rho"`
+}
+
+// IsZero reports whether no Greek is known
+func (g Greeks) IsZero() bool {
+	return g.Delta == nil && g.Gamma == nil && g.Theta == nil && g.Vega == nil && g.Rho == nil
+}
+
+// forward returns the forward price of the underlying at expiry
+func (in Inputs) forward() float64 {
+	return in.Spot * math.Exp(in.Rate*in.Years)
+}
+
+// d1d2 returns the d1 and d2 terms shared by both models
+func (in Inputs) d1d2() (float64, float64) {
+	sqrtT := math.Sqrt(in.Years)
+	d1 := (math.Log(in.forward()/in.Strike) + in.Volatility*in.Volatility*in.Years/2) / (in.Volatility * sqrtT)
+	return d1, d1 - in.Volatility*sqrtT
+}
+
+// Price returns the model price of the option, NaN when the inputs are invalid
+func (m Model) Price(in Inputs) float64 {
+	if !in.valid() {
+		return math.NaN()
+	}
+	d1, d2 := in.d1d2()
+	discount := math.Exp(-in.Rate * in.Years)
+	// Both models agree on the price once the spot is carried to the forward
+	if in.Call {
+		return discount * (in.forward()*normCDF(d1) - in.Strike*normCDF(d2))
+	}
+	return discount * (in.Strike*normCDF(-d2) - in.forward()*normCDF(-d1))
+}
+
+// Greeks returns the sensitivities of the option, false when the inputs are
+// invalid. Black-Scholes reports them against the spot, Black-76 against
+// the forward, which also leaves the forward unchanged when the rate moves.
+func (m Model) Greeks(in Inputs) (Greeks, bool) {
+	if !in.valid() {
+		return Greeks{}, false
+	}
+
+	d1, d2 := in.d1d2()
+	sqrtT := math.Sqrt(in.Years)
+	discount := math.Exp(-in.Rate * in.Years)
+	pdf := normPDF(d1)
+	sign := 1.0
+	if !in.Call {
+		sign = -1
+	}
+
+	var delta, gamma, theta, vega, rho float64
+	switch m {
+	case Black76:
+		f := in.forward()
+		price := m.Price(in)
+		delta = sign * discount * normCDF(sign*d1)
+		gamma = discount * pdf / (f * in.Volatility * sqrtT)
+		vega = discount * f * pdf * sqrtT
+		theta = -discount*f*pdf*in.Volatility/(2*sqrtT) + in.Rate*price
+		rho = -in.Years * price
+	default:
+		delta = sign * normCDF(sign*d1)
+		gamma = pdf / (in.Spot * in.Volatility * sqrtT)
+		vega = in.Spot * pdf * sqrtT
+		theta = -in.Spot*pdf*in.Volatility/(2*sqrtT) - sign*in.Rate*in.Strike*discount*normCDF(sign*d2)
+		rho = sign * in.Strike * in.Years * discount * normCDF(sign*d2)
+	}
+
+	return Greeks{
+		Delta: &delta,
+		Gamma: &gamma,
+		Theta: ptr(theta / daysPerYear),
+		Vega:  ptr(vega / 100),
+		Rho:   ptr(rho / 100),
+	}, true
+}
+
+// normCDF is the standard normal cumulative distribution function
+func normCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// normPDF is the standard normal probability density function
+func normPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
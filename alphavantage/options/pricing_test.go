@@ -0,0 +1,100 @@
+package options
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGreeksReference(t *testing.T) {
+	// Hull, Options, Futures and Other Derivatives, the 20 week at the money
+	// call of the delta hedging examples: delta 0.522, gamma 0.066, theta
+	// -4.31 per year, vega 12.1 and rho 8.91 per unit
+	in := Inputs{Spot: 49, Strike: 50, Years: 20.0 / 52, Rate: 0.05, Volatility: 0.2, Call: true}
+	g, ok := BlackScholes.Greeks(in)
+	if !ok {
+		t.Fatal("Greeks() rejected valid inputs")
+	}
+	tests := []struct {
+		name      string
+		got, want float64
+		tolerance float64
+	}{
+		{"delta", *g.Delta, 0.522, 5e-4},
+		{"gamma", *g.Gamma, 0.066, 5e-4},
+		{"theta", *g.Theta, -4.31 / daysPerYear, 5e-5},
+		{"vega", *g.Vega, 0.121, 5e-4},
+		{"rho", *g.Rho, 0.0891, 5e-4},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > tt.tolerance {
+			t.Errorf("call %s = %.5f, want %.5f", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// Put-call parity: put delta is call delta minus one, gamma and vega agree
+	in.Call = false
+	put, _ := BlackScholes.Greeks(in)
+	if math.Abs(*put.Delta-(*g.Delta-1)) > 1e-12 || *put.Gamma != *g.Gamma || *put.Vega != *g.Vega {
+		t.Errorf("put Greeks = delta %.5f gamma %.5f vega %.5f, want parity with the call", *put.Delta, *put.Gamma, *put.Vega)
+	}
+	if *put.Rho >= 0 {
+		t.Errorf("put rho = %.5f, want negative", *put.Rho)
+	}
+}
+
+// black76Price prices an option on the forward f, holding f fixed when the rate moves
+func black76Price(f, strike, years, rate, vol float64, call bool) float64 {
+	return Black76.Price(Inputs{Spot: f * math.Exp(-rate*years), Strike: strike, Years: years, Rate: rate, Volatility: vol, Call: call})
+}
+
+func TestGreeksBlack76(t *testing.T) {
+	const f, strike, years, rate, vol, h = 20.0, 21.0, 4.0 / 12, 0.09, 0.25, 1e-4
+
+	for _, call := range []bool{true, false} {
+		g, ok := Black76.Greeks(Inputs{Spot: f * math.Exp(-rate*years), Strike: strike, Years: years, Rate: rate, Volatility: vol, Call: call})
+		if !ok {
+			t.Fatal("Greeks() rejected valid inputs")
+		}
+		price := func(f, years, rate, vol float64) float64 { return black76Price(f, strike, years, rate, vol, call) }
+		p := price(f, years, rate, vol)
+
+		// Central differences against the forward, holding it fixed for theta and rho
+		tests := []struct {
+			name      string
+			got, want float64
+		}{
+			{"delta", *g.Delta, (price(f+h, years, rate, vol) - price(f-h, years, rate, vol)) / (2 * h)},
+			{"gamma", *g.Gamma, (price(f+h, years, rate, vol) - 2*p + price(f-h, years, rate, vol)) / (h * h)},
+			{"theta", *g.Theta, -(price(f, years+h, rate, vol) - price(f, years-h, rate, vol)) / (2 * h) / daysPerYear},
+			{"vega", *g.Vega, (price(f, years, rate, vol+h) - price(f, years, rate, vol-h)) / (2 * h) / 100},
+			{"rho", *g.Rho, (price(f, years, rate+h, vol) - price(f, years, rate-h, vol)) / (2 * h) / 100},
+		}
+		for _, tt := range tests {
+			if math.Abs(tt.got-tt.want) > 1e-4*math.Max(1, math.Abs(tt.want)) {
+				t.Errorf("call=%v %s = %.6f, want %.6f", call, tt.name, tt.got, tt.want)
+			}
+		}
+	}
+}
+
+func TestGreeksInvalidInputs(t *testing.T) {
+	valid := Inputs{Spot: 100, Strike: 100, Years: 0.5, Rate: 0.05, Volatility: 0.2, Call: true}
+	for name, in := range map[string]Inputs{
+		"no spot":       {Strike: 100, Years: 0.5, Volatility: 0.2},
+		"no strike":     {Spot: 100, Years: 0.5, Volatility: 0.2},
+		"expired":       {Spot: 100, Strike: 100, Volatility: 0.2},
+		"no volatility": {Spot: 100, Strike: 100, Years: 0.5},
+	} {
+		for _, model := range []Model{BlackScholes, Black76} {
+			if g, ok := model.Greeks(in); ok || !g.IsZero() {
+				t.Errorf("%s Greeks(%s) = %+v, %v, want none", model, name, g, ok)
+			}
+			if price := model.Price(in); !math.IsNaN(price) {
+				t.Errorf("%s Price(%s) = %g, want NaN", model, name, price)
+			}
+		}
+	}
+	if _, ok := BlackScholes.Greeks(valid); !ok {
+		t.Error("Greeks() rejected valid inputs")
+	}
+}
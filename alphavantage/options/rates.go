@@ -0,0 +1,86 @@
+package options
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"stock/common"
+)
+
+// RateSource provides the risk-free rate of a session
+type RateSource interface {
+	Rate(ctx context.Context, client *common.Client, date time.Time) (float64, error)
+}
+
+// FixedRate is a constant continuously compounded rate
+type FixedRate float64
+
+// Rate returns the fixed rate whatever the date
+func (r FixedRate) Rate(ctx context.Context, client *common.Client, date time.Time) (float64, error) {
+	return float64(r), nil
+}
+
+// TreasuryRate reads the daily US Treasury yield of a maturity from TREASURY_YIELD
+type TreasuryRate struct {
+	Maturity string // 3month, 2year, 5year, 7year, 10year or 30year
+}
+
+// TreasuryYieldResponse defines the structure of TREASURY_YIELD
+type TreasuryYieldResponse struct {
+	Name     string `json:"name"`
+	Interval string `json:"interval"`
+	Unit     string `json:"unit"`
+	Data     []struct {
+		Date  string `json:"date"`
+		Value string `json:"value"`
+	} `json:"data"`
+}
+
+// Rate returns the yield of the last session on or before date, converted
+// from a percentage to a continuously compounded rate. TREASURY_YIELD quotes
+// constant maturity yields on a bond-equivalent basis: notes and bonds
+// compound semiannually, the 3-month bill is a simple yield over its term.
+func (r TreasuryRate) Rate(ctx context.Context, client *common.Client, date time.Time) (float64, error) {
+	// Building query parameters
+	queryParams := map[string]string{
+		"function": "TREASURY_YIELD",
+		"interval": "daily",
+		"maturity": r.Maturity,
+	}
+
+	result := &TreasuryYieldResponse{}
+	if err := client.GetJSON(ctx, queryParams, result); err != nil {
+		return 0, err
+	}
+
+	sort.Slice(result.Data, func(i, j int) bool { return result.Data[i].Date > result.Data[j].Date })
+	last := date.Format(common.DateLayout)
+	for _, point := range result.Data {
+		if point.Date > last {
+			continue
+		}
+		// Holidays are reported as "."
+		value, err := common.ParseDecimal(point.Value)
+		if err != nil || !value.Valid() {
+			continue
+		}
+		percent, _ := value.Float64()
+		return continuousRate(r.Maturity, percent/100), nil
+	}
+	return 0, fmt.Errorf("no %s treasury yield on or before %s", r.Maturity, last)
+}
+
+// billTerm is the 3-month bill's term in years
+const billTerm = 0.25
+
+// continuousRate converts a bond-equivalent yield of the maturity to a
+// continuously compounded rate
+func continuousRate(maturity string, yield float64) float64 {
+	if maturity == "3month" {
+		return math.Log1p(yield*billTerm) / billTerm
+	}
+	return 2 * math.Log1p(yield/2)
+}
@@ -0,0 +1,59 @@
+package options
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"stock/common"
+	"stock/common/commontest"
+)
+
+func TestTreasuryRate(t *testing.T) {
+	client := commontest.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("function") != "TREASURY_YIELD" || r.URL.Query().Get("interval") != "daily" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		// Upstream lists the latest sessions first, holidays as "."
+		fmt.Fprint(w, `{"name": "Daily Treasury Yield", "interval": "daily", "unit": "percent", "data": [
+			{"date": "2024-07-05", "value": "4.28"},
+			{"date": "2024-07-04", "value": "."},
+			{"date": "2024-07-03", "value": "4.36"},
+			{"date": "2024-07-02", "value": "4.43"}
+		]}`)
+	})
+
+	tests := []struct {
+		maturity string
+		date     string
+		want     float64
+	}{
+		{"10year", "2024-07-05", 2 * math.Log1p(0.0428/2)},
+		{"10year", "2024-07-04", 2 * math.Log1p(0.0436/2)}, // The holiday falls back to the session before
+		{"10year", "2024-07-08", 2 * math.Log1p(0.0428/2)}, // After the latest session
+		{"3month", "2024-07-02", 4 * math.Log1p(0.0443/4)},
+	}
+	for _, tt := range tests {
+		date, _ := time.Parse(common.DateLayout, tt.date)
+		got, err := TreasuryRate{Maturity: tt.maturity}.Rate(context.Background(), client, date)
+		if err != nil {
+			t.Fatalf("%s on %s: Rate() error = %v", tt.maturity, tt.date, err)
+		}
+		if math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("%s on %s: Rate() = %.6f, want %.6f", tt.maturity, tt.date, got, tt.want)
+		}
+	}
+
+	// A semiannual 4.28% is slightly below its continuous equivalent
+	if got, _ := (TreasuryRate{Maturity: "10year"}).Rate(context.Background(), client, time.Date(2024, time.July, 5, 0, 0, 0, 0, time.UTC)); got >= 0.0428 || got < 0.0423 {
+		t.Errorf("10year rate = %.6f, want just below 0.0428", got)
+	}
+
+	before, _ := time.Parse(common.DateLayout, "2024-07-01")
+	if _, err := (TreasuryRate{Maturity: "10year"}).Rate(context.Background(), client, before); err == nil {
+		t.Error("Rate() before the first session returned no error")
+	}
+}
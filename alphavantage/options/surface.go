@@ -0,0 +1,342 @@
+package options
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"stock/common"
+)
+
+// Surface axes
+const (
+	AxisStrike = "strike"
+	AxisDelta  = "delta"
+)
+
+// DeltaGrid holds the call deltas of the delta axis. A 0.25 call delta is the
+// 25 delta call, 0.75 matches the 25 delta put.
+var DeltaGrid = []float64{0.10, 0.25, 0.50, 0.75, 0.90}
+
+// Quote is an out of the money contract with the volatility solved from its mid price
+type Quote struct {
+	ContractID        string  `json:"contract_id"`
+	Type              string  `json:"type"`
+	Strike            float64 `json:"strike"`
+	Moneyness         float64 `json:"moneyness"` // Strike over spot
+	Delta             float64 `json:"delta"`     // Call delta N(d1), whatever the type
+	Mid               float64 `json:"mid"`
+	ImpliedVolatility float64 `json:"implied_volatility"`
+}
+
+// Smile is the skew slice of one expiration, its quotes ordered by strike
+type Smile struct {
+	Expiration   string  `json:"expiration"`
+	DaysToExpiry int     `json:"days_to_expiry"`
+	Quotes       []Quote `json:"quotes"`
+
+	years float64
+}
+
+// TermPoint is the term structure slice at one expiration
+type TermPoint struct {
+	Expiration    string   `json:"expiration"`
+	DaysToExpiry  int      `json:"days_to_expiry"`
+	ATMVolatility *float64 `json:"atm_volatility"`    // Interpolated at a strike equal to spot
+	RiskReversal  *float64 `json:"risk_reversal_25d"` // 25 delta call minus 25 delta put volatility
+	Butterfly     *float64 `json:"butterfly_25d"`     // Average of the 25 delta wings minus the 50 delta volatility
+}
+
+// Surface is a grid of implied volatilities by expiration and strike or call delta
+type Surface struct {
+	Symbol        string       `json:"symbol"`
+	Date          string       `json:"date"`
+	Spot          float64      `json:"spot"`
+	RiskFreeRate  float64      `json:"risk_free_rate"`
+	Model         Model        `json:"model"`
+	Axis          string       `json:"axis"` // strike or delta
+	Expirations   []string     `json:"expirations"`
+	Columns       []float64    `json:"columns"`      // Strikes or call deltas
+	Volatility    [][]*float64 `json:"volatility"`   // [expiration][column], null when it cannot be interpolated
+	Interpolated  [][]bool     `json:"interpolated"` // Nodes without a quote of their own, every delta node
+	TermStructure []TermPoint  `json:"term_structure"`
+	Skew          []Smile      `json:"skew"`
+}
+
+// Node is one cell of the surface
+type Node struct {
+	Expiration        string   `json:"expiration"`
+	Strike            *float64 `json:"strike,omitempty"`
+	Delta             *float64 `json:"delta,omitempty"`
+	ImpliedVolatility *float64 `json:"implied_volatility"`
+	Interpolated      bool     `json:"interpolated"`
+}
+
+// Nodes flattens the grid into one node per expiration and column
+func (s *Surface) Nodes() []Node {
+	nodes := make([]Node, 0, len(s.Expirations)*len(s.Columns))
+	for i, expiration := range s.Expirations {
+		for j, column := range s.Columns {
+			node := Node{Expiration: expiration, ImpliedVolatility: s.Volatility[i][j], Interpolated: s.Interpolated[i][j]}
+			if s.Axis == AxisDelta {
+				node.Delta = ptr(column)
+			} else {
+				node.Strike = ptr(column)
+			}
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// SurfaceParams holds parameters for building a volatility surface
+type SurfaceParams struct {
+	ChainParams
+	Axis   string // strike when empty
+	Filter Filter // Expirations and strike over spot range to include, the type is ignored
+}
+
+// GetSurface fetches the options chain of a symbol on a date and builds its volatility surface
+func GetSurface(ctx context.Context, client *common.Client, params SurfaceParams) (*Surface, error) {
+	chain, err := GetChain(ctx, client, params.ChainParams)
+	if err != nil {
+		return nil, err
+	}
+	return BuildSurface(chain, params.Axis, params.Filter)
+}
+
+// BuildSurface solves the volatility of every out of the money contract of
+// the chain from its mid price and lays them out by expiration and axis.
+// Calls are used at and above spot, puts below it. Nodes without a quote
+// are interpolated linearly along the axis within each smile, then in
+// total variance across the neighbouring expirations. Nothing is
+// extrapolated.
+func BuildSurface(chain *Chain, axis string, filter Filter) (*Surface, error) {
+	if axis == "" {
+		axis = AxisStrike
+	}
+	if axis != AxisStrike && axis != AxisDelta {
+		return nil, fmt.Errorf("%w: axis must be %s or %s", common.ErrInvalidParameter, AxisStrike, AxisDelta)
+	}
+
+	surface := &Surface{
+		Symbol:       chain.Symbol,
+		Date:         chain.Date,
+		RiskFreeRate: chain.RiskFreeRate,
+		Model:        chain.Model,
+		Axis:         axis,
+	}
+	if chain.Spot == nil {
+		return surface, nil
+	}
+	surface.Spot = *chain.Spot
+
+	date, err := time.Parse(common.DateLayout, chain.Date)
+	if err != nil {
+		return nil, err
+	}
+	valuation := Valuation{Date: date, Spot: surface.Spot, Rate: chain.RiskFreeRate, Model: chain.Model}
+	filter.Type = ""
+	surface.Skew = smiles(valuation, filter.Apply(chain.Contracts))
+
+	for _, smile := range surface.Skew {
+		surface.Expirations = append(surface.Expirations, smile.Expiration)
+		surface.TermStructure = append(surface.TermStructure, termPoint(smile, surface.Spot))
+	}
+
+	if axis == AxisDelta {
+		surface.Columns = DeltaGrid
+	} else {
+		surface.Columns = strikes(surface.Skew)
+	}
+	surface.Volatility, surface.Interpolated = grid(surface.Skew, axis, surface.Columns)
+	return surface, nil
+}
+
+// smiles groups the solvable out of the money quotes by expiration
+func smiles(valuation Valuation, contracts []Contract) []Smile {
+	var result []Smile
+	for _, c := range contracts {
+		years := valuation.Years(c.Expiration)
+		strike, _ := c.Strike.Float64()
+		mid := midPrice(&c)
+		if years <= 0 || strike <= 0 || mid <= 0 || c.Call() != (strike >= valuation.Spot) {
+			continue
+		}
+
+		in := Inputs{Spot: valuation.Spot, Strike: strike, Years: years, Rate: valuation.Rate, Call: c.Call()}
+		vol, err := valuation.Model.ImpliedVolatility(in, mid)
+		if err != nil {
+			continue
+		}
+		in.Volatility = vol
+
+		expiration := c.Expiration.Format(common.DateLayout)
+		if len(result) == 0 || result[len(result)-1].Expiration != expiration {
+			result = append(result, Smile{
+				Expiration:   expiration,
+				DaysToExpiry: int(math.Round(c.Expiration.Sub(valuation.Date).Hours() / 24)),
+				years:        years,
+			})
+		}
+		smile := &result[len(result)-1]
+		smile.Quotes = append(smile.Quotes, Quote{
+			ContractID:        c.ContractID,
+			Type:              c.Type,
+			Strike:            strike,
+			Moneyness:         strike / valuation.Spot,
+			Delta:             callDelta(in),
+			Mid:               mid,
+			ImpliedVolatility: vol,
+		})
+	}
+	return result
+}
+
+// midPrice is the middle of the bid and ask, the mark when either side is missing
+func midPrice(c *Contract) float64 {
+	bid, _ := c.Bid.Float64()
+	ask, _ := c.Ask.Float64()
+	if bid > 0 && ask >= bid {
+		return (bid + ask) / 2
+	}
+	mark, _ := c.Mark.Float64()
+	return mark
+}
+
+// termPoint reads the at the money volatility and 25 delta skew of a smile
+func termPoint(smile Smile, spot float64) TermPoint {
+	point := TermPoint{Expiration: smile.Expiration, DaysToExpiry: smile.DaysToExpiry}
+	if vol, ok := smileAt(smile, AxisStrike, spot); ok {
+		point.ATMVolatility = ptr(vol)
+	}
+
+	call, okCall := smileAt(smile, AxisDelta, 0.25)
+	put, okPut := smileAt(smile, AxisDelta, 0.75)
+	if okCall && okPut {
+		point.RiskReversal = ptr(call - put)
+		if atm, ok := smileAt(smile, AxisDelta, 0.50); ok {
+			point.Butterfly = ptr((call+put)/2 - atm)
+		}
+	}
+	return point
+}
+
+// strikes returns every strike quoted at some expiration, ascending
+func strikes(skew []Smile) []float64 {
+	seen := map[float64]bool{}
+	var result []float64
+	for _, smile := range skew {
+		for _, q := range smile.Quotes {
+			if !seen[q.Strike] {
+				seen[q.Strike] = true
+				result = append(result, q.Strike)
+			}
+		}
+	}
+	sort.Float64s(result)
+	return result
+}
+
+// grid fills the surface nodes from the smiles, then across expirations
+func grid(skew []Smile, axis string, columns []float64) ([][]*float64, [][]bool) {
+	values := make([][]*float64, len(skew))
+	interpolated := make([][]bool, len(skew))
+	for i, smile := range skew {
+		values[i] = make([]*float64, len(columns))
+		interpolated[i] = make([]bool, len(columns))
+		for j, x := range columns {
+			if vol, ok := smileAt(smile, axis, x); ok {
+				values[i][j] = ptr(vol)
+				// Delta nodes always lie between quotes, only strikes can be quoted
+				interpolated[i][j] = axis == AxisDelta || !quoted(smile, x)
+			}
+		}
+	}
+
+	// Missing nodes take the total variance interpolated between the nearest
+	// expirations filled from their own smile, so total variance stays
+	// monotonic along the term structure when it is at the quotes
+	filled := make([][]bool, len(skew))
+	for i := range values {
+		filled[i] = make([]bool, len(columns))
+		for j := range values[i] {
+			filled[i][j] = values[i][j] != nil
+		}
+	}
+	for j := range columns {
+		for i := range skew {
+			if filled[i][j] {
+				continue
+			}
+			prev, next := -1, -1
+			for k := i - 1; k >= 0 && prev < 0; k-- {
+				if filled[k][j] {
+					prev = k
+				}
+			}
+			for k := i + 1; k < len(skew) && next < 0; k++ {
+				if filled[k][j] {
+					next = k
+				}
+			}
+			if prev < 0 || next < 0 {
+				continue
+			}
+
+			t0, t1, t := skew[prev].years, skew[next].years, skew[i].years
+			w0 := *values[prev][j] * *values[prev][j] * t0
+			w1 := *values[next][j] * *values[next][j] * t1
+			if w := w0 + (w1-w0)*(t-t0)/(t1-t0); w > 0 {
+				values[i][j] = ptr(math.Sqrt(w / t))
+				interpolated[i][j] = true
+			}
+		}
+	}
+	return values, interpolated
+}
+
+// quoted reports whether a smile has a quote at the strike
+func quoted(smile Smile, strike float64) bool {
+	for _, q := range smile.Quotes {
+		if q.Strike == strike {
+			return true
+		}
+	}
+	return false
+}
+
+// smileAt interpolates the volatility of a smile linearly at a strike or call delta
+func smileAt(smile Smile, axis string, x float64) (float64, bool) {
+	quotes := smile.Quotes
+	if axis == AxisDelta {
+		// Call delta falls as the strike rises
+		quotes = append([]Quote(nil), quotes...)
+		sort.Slice(quotes, func(i, j int) bool { return quotes[i].Delta < quotes[j].Delta })
+	}
+
+	xs := make([]float64, len(quotes))
+	ys := make([]float64, len(quotes))
+	for i, q := range quotes {
+		xs[i], ys[i] = q.Strike, q.ImpliedVolatility
+		if axis == AxisDelta {
+			xs[i] = q.Delta
+		}
+	}
+	return interpolate(xs, ys, x)
+}
+
+// interpolate evaluates the piecewise linear function through ascending xs at x
+func interpolate(xs, ys []float64, x float64) (float64, bool) {
+	i := sort.SearchFloat64s(xs, x)
+	switch {
+	case i < len(xs) && xs[i] == x:
+		return ys[i], true
+	case i == 0 || i == len(xs):
+		return 0, false
+	}
+	x0, x1 := xs[i-1], xs[i]
+	return ys[i-1] + (ys[i]-ys[i-1])*(x-x0)/(x1-x0), true
+}
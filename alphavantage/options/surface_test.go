@@ -0,0 +1,134 @@
+package options
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"stock/common"
+)
+
+// flatChain prices every strike of every expiry at one volatility, leaving out the skipped contracts
+func flatChain(vol float64, days []int, strikes []float64, skip map[string]bool) *Chain {
+	spot, rate := 100.0, 0.03
+	date := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	valuation := Valuation{Date: date, Spot: spot, Rate: rate, Model: BlackScholes}
+
+	chain := &Chain{Symbol: "TEST", Date: date.Format(common.DateLayout), Spot: &spot, RiskFreeRate: rate, Model: BlackScholes}
+	for _, d := range days {
+		expiration := date.AddDate(0, 0, d)
+		for _, strike := range strikes {
+			for _, typ := range []string{TypeCall, TypePut} {
+				id := fmt.Sprintf("%d-%g-%s", d, strike, typ)
+				if skip[id] {
+					continue
+				}
+				in := Inputs{Spot: spot, Strike: strike, Years: valuation.Years(expiration), Rate: rate, Volatility: vol, Call: typ == TypeCall}
+				chain.Contracts = append(chain.Contracts, Contract{
+					ContractID: id,
+					Expiration: expiration,
+					Strike:     common.MustParseDecimal(fmt.Sprint(strike)),
+					Type:       typ,
+					Mark:       common.MustParseDecimal(fmt.Sprintf("%.8f", BlackScholes.Price(in))),
+				})
+			}
+		}
+	}
+	return chain
+}
+
+// show formats an optional volatility for failure messages
+func show(v *float64) string {
+	if v == nil {
+		return "null"
+	}
+	return fmt.Sprint(*v)
+}
+
+func TestBuildSurfaceStrikeAxis(t *testing.T) {
+	chain := flatChain(0.25, []int{30, 60, 90}, []float64{90, 95, 100, 105, 110}, map[string]bool{
+		"30-105-call": true, // Interpolated within the smile
+		"60-110-call": true, // Interpolated across expirations
+		"90-90-put":   true, // Nothing below it to interpolate from
+	})
+
+	surface, err := BuildSurface(chain, "", Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if surface.Axis != AxisStrike || len(surface.Expirations) != 3 || len(surface.Columns) != 5 {
+		t.Fatalf("surface is %s by %v x %v", surface.Axis, surface.Expirations, surface.Columns)
+	}
+
+	interpolated := map[[2]int]bool{{0, 3}: true, {1, 4}: true}
+	for i := range surface.Expirations {
+		for j, strike := range surface.Columns {
+			vol := surface.Volatility[i][j]
+			if i == 2 && strike == 90 {
+				if vol != nil {
+					t.Errorf("%s at %g = %g, want null without neighbours", surface.Expirations[i], strike, *vol)
+				}
+				continue
+			}
+			if vol == nil || math.Abs(*vol-0.25) > 1e-4 {
+				t.Errorf("%s at %g = %s, want 0.25", surface.Expirations[i], strike, show(vol))
+			}
+			if surface.Interpolated[i][j] != interpolated[[2]int{i, j}] {
+				t.Errorf("%s at %g interpolated = %v", surface.Expirations[i], strike, surface.Interpolated[i][j])
+			}
+		}
+	}
+
+	for _, point := range surface.TermStructure {
+		if point.ATMVolatility == nil || math.Abs(*point.ATMVolatility-0.25) > 1e-4 {
+			t.Errorf("%s ATM volatility = %s, want 0.25", point.Expiration, show(point.ATMVolatility))
+		}
+	}
+	if got := len(surface.Nodes()); got != 15 {
+		t.Errorf("Nodes() = %d, want 15", got)
+	}
+}
+
+func TestBuildSurfaceDeltaAxis(t *testing.T) {
+	var strikes []float64
+	for k := 60.0; k <= 150; k += 5 {
+		strikes = append(strikes, k)
+	}
+	surface, err := BuildSurface(flatChain(0.3, []int{90}, strikes, nil), AxisDelta, Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for j, delta := range surface.Columns {
+		vol := surface.Volatility[0][j]
+		if vol == nil || math.Abs(*vol-0.3) > 1e-4 {
+			t.Errorf("delta %g = %s, want 0.30", delta, show(vol))
+		}
+		if !surface.Interpolated[0][j] {
+			t.Errorf("delta %g is not interpolated, want every delta node interpolated", delta)
+		}
+	}
+
+	// A flat smile has no skew
+	point := surface.TermStructure[0]
+	if point.RiskReversal == nil || math.Abs(*point.RiskReversal) > 1e-4 || point.Butterfly == nil || math.Abs(*point.Butterfly) > 1e-4 {
+		t.Errorf("flat smile term point = %+v, want zero risk reversal and butterfly", point)
+	}
+}
+
+func TestBuildSurfaceUsesOutOfTheMoneyQuotes(t *testing.T) {
+	surface, err := BuildSurface(flatChain(0.2, []int{30}, []float64{95, 100, 105}, nil), AxisStrike, Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range surface.Skew[0].Quotes {
+		if (q.Type == TypeCall) != (q.Strike >= surface.Spot) {
+			t.Errorf("smile quotes the in the money %s at %g", q.Type, q.Strike)
+		}
+	}
+
+	if _, err := BuildSurface(flatChain(0.2, []int{30}, []float64{100}, nil), "moneyness", Filter{}); err == nil {
+		t.Error("BuildSurface() accepted an unknown axis")
+	}
+}
@@ -0,0 +1,286 @@
+package alphavantage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"stock/alphavantage/options"
+	"stock/common"
+	"stock/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OptionsChainResponse defines the response format for options chains
+// @Description Options chain response data structure
+type OptionsChainResponse struct {
+	Version   string         `json:"version"`
+	Timestamp string         `json:"timestamp"`
+	Symbol    string         `json:"symbol"`
+	Cache     string         `json:"cache,omitempty"`
+	Data      *options.Chain `json:"data"`
+}
+
+// OptionContractResponse defines the response format for a single option contract
+// @Description Option contract response data structure
+type OptionContractResponse struct {
+	Version      string            `json:"version"`
+	Timestamp    string            `json:"timestamp"`
+	Symbol       string            `json:"symbol"`
+	Date         string            `json:"date"`
+	Spot         *float64          `json:"spot"`
+	RiskFreeRate float64           `json:"risk_free_rate"`
+	Model        options.Model     `json:"model"`
+	Cache        string            `json:"cache,omitempty"`
+	Data         *options.Contract `json:"data"`
+}
+
+// GetOptionsChain handles requests for historical options chains
+// @Summary Get the options chain of a symbol on a date
+// @Description Returns every contract from HISTORICAL_OPTIONS with its days to expiry, moneyness (strike over the underlying's close) and Greeks. Greeks missing upstream are computed from the implied volatility with Black-Scholes or Black-76. Theta is per calendar day, vega per volatility point and rho per percentage point.
+// @Tags options
+// @Produce json,text/csv,application/x-ndjson
+// @Param symbol path string true "Underlying symbol (e.g., IBM)"
+// @Param date query string false "Session date (YYYY-MM-DD), the previous session when omitted"
+// @Param expiry_from query string false "Earliest expiration to include (YYYY-MM-DD)"
+// @Param expiry_to query string false "Latest expiration to include (YYYY-MM-DD)"
+// @Param type query string false "Option type" Enums(call, put)
+// @Param moneyness query string false "itm, atm (strike within 2.5% of spot), otm or a strike/spot range such as 0.9-1.1"
+// @Param greeks query string false "Greeks source, auto computes the ones missing upstream" Enums(auto, upstream, computed) default(auto)
+// @Param model query string false "Pricing model for computed Greeks" Enums(black_scholes, black76) default(black_scholes)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} OptionsChainResponse "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/options/{symbol}/chain [get]
+func (h *Handler) GetOptionsChain(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	params, err := parseChainParams(c, symbol)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	filter, err := parseOptionsFilter(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	ctx, cache := requestContext(c)
+
+	chain, err := h.client.GetOptionsChain(ctx, params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	chain.Contracts = filter.Apply(chain.Contracts)
+
+	response := OptionsChainResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Symbol:    symbol,
+		Cache:     setCacheHeader(c, cache),
+		Data:      chain,
+	}
+
+	respond(c, response, chain.Contracts)
+}
+
+// GetOptionContract handles requests for a single option contract
+// @Summary Get one option contract on a date
+// @Description Returns a contract of the HISTORICAL_OPTIONS chain by its contract ID (e.g., IBM240119C00050000) with the same derived values as the chain endpoint
+// @Tags options
+// @Produce json,text/csv,application/x-ndjson
+// @Param symbol path string true "Underlying symbol (e.g., IBM)"
+// @Param contract path string true "Contract ID (e.g., IBM240119C00050000)"
+// @Param date query string false "Session date (YYYY-MM-DD), the previous session when omitted"
+// @Param greeks query string false "Greeks source, auto computes the ones missing upstream" Enums(auto, upstream, computed) default(auto)
+// @Param model query string false "Pricing model for computed Greeks" Enums(black_scholes, black76) default(black_scholes)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} OptionContractResponse "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol or contract"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/options/{symbol}/contracts/{contract} [get]
+func (h *Handler) GetOptionContract(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	params, err := parseChainParams(c, symbol)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	ctx, cache := requestContext(c)
+
+	chain, err := h.client.GetOptionsChain(ctx, params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	contract, err := chain.Contract(c.Param("contract"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response := OptionContractResponse{
+		Version:      config.GetConfig().DefaultAPIVersion,
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+		Symbol:       symbol,
+		Date:         chain.Date,
+		Spot:         chain.Spot,
+		RiskFreeRate: chain.RiskFreeRate,
+		Model:        chain.Model,
+		Cache:        setCacheHeader(c, cache),
+		Data:         contract,
+	}
+
+	respond(c, response, []*options.Contract{contract})
+}
+
+// parseChainParams reads the date, greeks and model parameters shared by the options endpoints
+func parseChainParams(c *gin.Context, symbol string) (options.ChainParams, error) {
+	params := options.ChainParams{
+		Symbol: symbol,
+		Date:   c.Query("date"),
+		Model:  options.Model(strings.ToLower(c.DefaultQuery("model", string(options.BlackScholes)))),
+		Greeks: strings.ToLower(c.DefaultQuery("greeks", options.GreeksAuto)),
+	}
+
+	if params.Date != "" {
+		if _, err := time.Parse(common.DateLayout, params.Date); err != nil {
+			return params, fmt.Errorf("%w: date must be YYYY-MM-DD", common.ErrInvalidParameter)
+		}
+	}
+	if params.Model != options.BlackScholes && params.Model != options.Black76 {
+		return params, fmt.Errorf("%w: model must be %s or %s", common.ErrInvalidParameter, options.BlackScholes, options.Black76)
+	}
+	switch params.Greeks {
+	case options.GreeksAuto, options.GreeksUpstream, options.GreeksComputed:
+	default:
+		return params, fmt.Errorf("%w: greeks must be %s, %s or %s", common.ErrInvalidParameter, options.GreeksAuto, options.GreeksUpstream, options.GreeksComputed)
+	}
+	return params, nil
+}
+
+// parseOptionsFilter reads the expiry, type and moneyness filters of the chain endpoint
+func parseOptionsFilter(c *gin.Context) (options.Filter, error) {
+	filter := options.Filter{Type: strings.ToLower(c.Query("type"))}
+	if filter.Type != "" && filter.Type != options.TypeCall && filter.Type != options.TypePut {
+		return filter, fmt.Errorf("%w: type must be %s or %s", common.ErrInvalidParameter, options.TypeCall, options.TypePut)
+	}
+
+	for name, dst := range map[string]*time.Time{"expiry_from": &filter.ExpiryFrom, "expiry_to": &filter.ExpiryTo} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(common.DateLayout, value)
+		if err != nil {
+			return filter, fmt.Errorf("%w: %s must be YYYY-MM-DD", common.ErrInvalidParameter, name)
+		}
+		*dst = t
+	}
+
+	if err := filter.ParseMoneyness(c.Query("moneyness")); err != nil {
+		return filter, fmt.Errorf("%w: %v", common.ErrInvalidParameter, err)
+	}
+	return filter, nil
+}
+
+// VolatilitySurfaceResponse defines the response format for implied volatility surfaces
+// @Description Implied volatility surface response data structure
+type VolatilitySurfaceResponse struct {
+	Version   string           `json:"version"`
+	Timestamp string           `json:"timestamp"`
+	Symbol    string           `json:"symbol"`
+	Cache     string           `json:"cache,omitempty"`
+	Data      *options.Surface `json:"data"`
+}
+
+// GetVolatilitySurface handles requests for implied volatility surfaces
+// @Summary Get the implied volatility surface of a symbol on a date
+// @Description Solves the implied volatility of every out of the money contract of the HISTORICAL_OPTIONS chain from its mid price (Newton-Raphson with a bisection fallback) and lays them out by expiration and strike or call delta. Missing nodes are interpolated linearly along the axis, then in total variance across expirations. The term structure lists the at the money volatility and 25 delta risk reversal and butterfly of each expiration, the skew lists the solved quotes.
+// @Tags options
+// @Produce json,text/csv,application/x-ndjson
+// @Param symbol path string true "Underlying symbol (e.g., IBM)"
+// @Param date query string false "Session date (YYYY-MM-DD), the previous session when omitted"
+// @Param axis query string false "Columns of the grid, strikes or call deltas" Enums(strike, delta) default(strike)
+// @Param expiry_from query string false "Earliest expiration to include (YYYY-MM-DD)"
+// @Param expiry_to query string false "Latest expiration to include (YYYY-MM-DD)"
+// @Param moneyness query string false "Strike/spot range to include" default(0.8-1.2)
+// @Param model query string false "Pricing model the volatilities are solved with" Enums(black_scholes, black76) default(black_scholes)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} VolatilitySurfaceResponse "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/options/{symbol}/surface [get]
+func (h *Handler) GetVolatilitySurface(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	chainParams, err := parseChainParams(c, symbol)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	// Greeks are not part of the surface, skip computing them
+	chainParams.Greeks = options.GreeksUpstream
+
+	params := options.SurfaceParams{
+		ChainParams: chainParams,
+		Axis:        strings.ToLower(c.DefaultQuery("axis", options.AxisStrike)),
+	}
+	if params.Axis != options.AxisStrike && params.Axis != options.AxisDelta {
+		respondError(c, fmt.Errorf("%w: axis must be %s or %s", common.ErrInvalidParameter, options.AxisStrike, options.AxisDelta))
+		return
+	}
+	if params.Filter, err = parseOptionsFilter(c); err != nil {
+		respondError(c, err)
+		return
+	}
+	if params.Filter.Type != "" || params.Filter.Moneyness != "" {
+		respondError(c, fmt.Errorf("%w: the surface only takes a strike/spot range as moneyness", common.ErrInvalidParameter))
+		return
+	}
+	if c.Query("moneyness") == "" {
+		params.Filter.MinMoneyness, params.Filter.MaxMoneyness = 0.8, 1.2
+	}
+
+	ctx, cache := requestContext(c)
+
+	surface, err := h.client.GetVolatilitySurface(ctx, params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response := VolatilitySurfaceResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Symbol:    symbol,
+		Cache:     setCacheHeader(c, cache),
+		Data:      surface,
+	}
+
+	respond(c, response, surface.Nodes())
+}
@@ -0,0 +1,328 @@
+package alphavantage
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"stock/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Output encodings, JSON envelopes are the default
+const (
+	outputJSON   = "json"
+	outputCSV    = "csv"
+	outputNDJSON = "ndjson"
+)
+
+// Media types of the row encodings
+const (
+	mimeCSV    = "text/csv"
+	mimeNDJSON = "application/x-ndjson"
+)
+
+// flushEvery is how many rows are written between flushes of a streamed response
+const flushEvery = 500
+
+// outputFormat returns the encoding requested by the format query parameter,
+// or negotiated from the Accept header when format is not given
+func outputFormat(c *gin.Context) (string, error) {
+	switch format := c.Query("format"); format {
+	case outputJSON, outputCSV, outputNDJSON:
+		return format, nil
+	case "":
+	default:
+		return "", fmt.Errorf("%w: format must be %s, %s or %s", common.ErrInvalidParameter, outputJSON, outputCSV, outputNDJSON)
+	}
+
+	switch c.NegotiateFormat(gin.MIMEJSON, mimeCSV, mimeNDJSON) {
+	case mimeCSV:
+		return outputCSV, nil
+	case mimeNDJSON:
+		return outputNDJSON, nil
+	}
+	return outputJSON, nil
+}
+
+// respond writes the envelope as JSON, or rows as CSV or NDJSON when one of
+// those was requested. rows is a slice with one element per output row.
+func respond(c *gin.Context, envelope interface{}, rows interface{}) {
+	format, err := outputFormat(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	switch format {
+	case outputCSV:
+		writeCSV(c, rows)
+	case outputNDJSON:
+		writeNDJSON(c, rows)
+	default:
+		c.JSON(http.StatusOK, envelope)
+	}
+}
+
+// writeCSV writes one line per row preceded by a header of the first row's
+// columns, or of the element type's columns when there are no rows. The
+// status is already sent when writing fails, so the error is only logged.
+func writeCSV(c *gin.Context, rows interface{}) {
+	c.Header("Content-Type", mimeCSV+"; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	writeHeader := func(row record) {
+		header := make([]string, len(row))
+		for j, col := range row {
+			header[j] = col.name
+		}
+		w.Write(header)
+	}
+
+	written := 0
+	eachRow(rows, func(i int, row record) {
+		if i == 0 {
+			writeHeader(row)
+		}
+		written++
+
+		cells := make([]string, len(row))
+		for j, col := range row {
+			if !col.empty {
+				cells[j] = csvCell(col.value)
+			}
+		}
+		w.Write(cells)
+
+		if (i+1)%flushEvery == 0 {
+			w.Flush()
+			c.Writer.Flush()
+		}
+	})
+	if written == 0 {
+		if row := zeroRow(rows); len(row) > 0 {
+			writeHeader(row)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		log.Printf("Warning: writing CSV: %v", err)
+	}
+}
+
+// writeNDJSON writes one JSON object per line, keeping the column order and
+// dropping empty omitempty fields like the JSON envelope does
+func writeNDJSON(c *gin.Context, rows interface{}) {
+	c.Header("Content-Type", mimeNDJSON)
+	c.Status(http.StatusOK)
+
+	var line []byte
+	eachRow(rows, func(i int, row record) {
+		line = append(line[:0], '{')
+		for _, col := range row {
+			if col.empty {
+				continue
+			}
+			if len(line) > 1 {
+				line = append(line, ',')
+			}
+			name, _ := json.Marshal(col.name)
+			value, err := json.Marshal(col.value)
+			if err != nil {
+				value = []byte("null")
+			}
+			line = append(line, name...)
+			line = append(line, ':')
+			line = append(line, value...)
+		}
+		line = append(line, '}', '\n')
+		c.Writer.Write(line)
+
+		if (i+1)%flushEvery == 0 {
+			c.Writer.Flush()
+		}
+	})
+	c.Writer.Flush()
+}
+
+// column is a named cell of a flattened row
+type column struct {
+	name  string
+	value interface{}
+	empty bool // Zero value of an omitempty field
+}
+
+// record is a flattened row with its columns in output order
+type record []column
+
+// flattener is implemented by rows that flatten themselves
+type flattener interface {
+	columns() record
+}
+
+// eachRow calls fn with every element of the rows slice, flattened
+func eachRow(rows interface{}, fn func(int, record)) {
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice {
+		return
+	}
+	for i := 0; i < v.Len(); i++ {
+		fn(i, flatten(v.Index(i).Interface()))
+	}
+}
+
+// zeroRow flattens the zero value of the rows slice's element type, naming
+// the columns of an empty slice. Rows whose columns depend on their contents
+// only name their fixed columns.
+func zeroRow(rows interface{}) record {
+	t := reflect.TypeOf(rows)
+	if t == nil || t.Kind() != reflect.Slice {
+		return nil
+	}
+	elem := t.Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return nil
+	}
+	return flatten(reflect.New(elem).Elem().Interface())
+}
+
+// flatten turns a struct into columns named by its json tags in field order.
+// Embedded structs are inlined like encoding/json does.
+func flatten(v interface{}) record {
+	if f, ok := v.(flattener); ok {
+		return f.columns()
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return record{{name: "value", value: v}}
+	}
+	return appendFields(nil, rv)
+}
+
+// appendFields appends the exported fields of a struct value to row
+func appendFields(row record, rv reflect.Value) record {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		// Fields of embedded structs are promoted even when the struct type is unexported
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			row = appendFields(row, rv.Field(i))
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		row = append(row, column{
+			name:  name,
+			value: rv.Field(i).Interface(),
+			empty: strings.Contains(opts, "omitempty") && rv.Field(i).IsZero(),
+		})
+	}
+	return row
+}
+
+// csvCell formats a value for a CSV cell, nulls are empty and nested values are JSON
+func csvCell(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case common.Decimal:
+		if !v.Valid() {
+			return ""
+		}
+		return v.String()
+	case time.Time:
+		return formatTime(v)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return formatTime(*v)
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32:
+		return fmt.Sprint(value)
+	case reflect.Invalid:
+		return ""
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		if rv.IsNil() {
+			return ""
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// formatTime writes dates as YYYY-MM-DD and timestamps as RFC 3339
+func formatTime(t time.Time) string {
+	if t.Location() == time.UTC && t.Equal(t.Truncate(24*time.Hour)) {
+		return t.Format(common.DateLayout)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// periodReport is a financial statement report tagged with its reporting period
+type periodReport struct {
+	period string
+	report interface{}
+}
+
+// columns puts the period ahead of the report's own columns
+func (r periodReport) columns() record {
+	row := record{{name: "period", value: r.period}}
+	if r.report == nil {
+		return row
+	}
+	return append(row, flatten(r.report)...)
+}
+
+// statementRows lists annual and quarterly reports as one row per report
+func statementRows(annual, quarterly interface{}) []periodReport {
+	var rows []periodReport
+	for _, reports := range []struct {
+		period string
+		list   interface{}
+	}{{"annual", annual}, {"quarterly", quarterly}} {
+		v := reflect.ValueOf(reports.list)
+		for i := 0; v.Kind() == reflect.Slice && i < v.Len(); i++ {
+			rows = append(rows, periodReport{period: reports.period, report: v.Index(i).Interface()})
+		}
+	}
+	return rows
+}
@@ -0,0 +1,295 @@
+package alphavantage
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"stock/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+// testContext returns a gin context for a GET of target with the given Accept header
+func testContext(target, accept string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	if accept != "" {
+		c.Request.Header.Set("Accept", accept)
+	}
+	return c, w
+}
+
+func TestOutputFormat(t *testing.T) {
+	tests := []struct {
+		target, accept string
+		want           string
+	}{
+		{"/", "", outputJSON},
+		{"/", "application/json", outputJSON},
+		{"/", "*/*", outputJSON},
+		{"/", "text/csv", outputCSV},
+		{"/", "application/x-ndjson", outputNDJSON},
+		{"/", "text/html, text/csv;q=0.9", outputCSV},
+		{"/", "application/x-ndjson, application/json", outputNDJSON}, // The first acceptable type wins
+		{"/", "image/png", outputJSON},
+		{"/?format=csv", "application/json", outputCSV},
+		{"/?format=ndjson", "text/csv", outputNDJSON},
+		{"/?format=json", "text/csv", outputJSON}, // An explicit format wins over the Accept header
+		{"/?format=json", "application/x-ndjson", outputJSON},
+		{"/?format=", "text/csv", outputCSV},
+	}
+	for _, tt := range tests {
+		c, _ := testContext(tt.target, tt.accept)
+		if got, err := outputFormat(c); err != nil || got != tt.want {
+			t.Errorf("outputFormat(%s, Accept %q) = %s, %v, want %s", tt.target, tt.accept, got, err, tt.want)
+		}
+	}
+
+	for _, target := range []string{"/?format=xml", "/?format=bars", "/?format=ics", "/?format=JSON"} {
+		c, _ := testContext(target, "text/csv")
+		if got, err := outputFormat(c); !errors.Is(err, common.ErrInvalidParameter) {
+			t.Errorf("outputFormat(%s) = %s, %v, want ErrInvalidParameter", target, got, err)
+		}
+	}
+}
+
+func TestRespondRejectsUnknownFormat(t *testing.T) {
+	c, w := testContext("/?format=xml", "")
+	respond(c, gin.H{"data": []renderPoint{}}, []renderPoint{})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestCalendarFormat(t *testing.T) {
+	tests := []struct {
+		target, accept string
+		want           string
+	}{
+		{"/", "", outputJSON},
+		{"/", "text/calendar", outputICS},
+		{"/?format=ics", "text/csv", outputICS},
+		{"/?format=json", "text/calendar", outputJSON},
+		{"/?format=csv", "text/calendar", outputCSV},
+	}
+	for _, tt := range tests {
+		c, _ := testContext(tt.target, tt.accept)
+		if got, err := calendarFormat(c); err != nil || got != tt.want {
+			t.Errorf("calendarFormat(%s, Accept %q) = %s, %v, want %s", tt.target, tt.accept, got, err, tt.want)
+		}
+	}
+
+	c, _ := testContext("/?format=xml", "text/calendar")
+	if _, err := calendarFormat(c); !errors.Is(err, common.ErrInvalidParameter) {
+		t.Errorf("calendarFormat(format=xml) error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+type renderInner struct {
+	Sector string `json:"sector"`
+	Weight float64
+}
+
+type renderRow struct {
+	renderInner
+	Symbol   string            `json:"symbol"`
+	Note     string            `json:"note,omitempty"`
+	Secret   string            `json:"-"`
+	Price    common.Decimal    `json:"price"`
+	Date     *time.Time        `json:"date"`
+	Tags     []string          `json:"tags,omitempty"`
+	Extra    map[string]string `json:"extra"`
+	internal int
+}
+
+func TestFlatten(t *testing.T) {
+	date := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	row := flatten(&renderRow{
+		renderInner: renderInner{Sector: "TECHNOLOGY", Weight: 0.5},
+		Symbol:      "IBM",
+		Secret:      "hidden",
+		Price:       common.MustParseDecimal("185.03"),
+		Date:        &date,
+		internal:    1,
+	})
+
+	var names []string
+	for _, col := range row {
+		names = append(names, col.name)
+	}
+	want := []string{"sector", "Weight", "symbol", "note", "price", "date", "tags", "extra"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("flatten() columns = %v, want %v", names, want)
+	}
+
+	empty := map[string]bool{}
+	for _, col := range row {
+		empty[col.name] = col.empty
+	}
+	if !empty["note"] || !empty["tags"] || empty["symbol"] || empty["extra"] {
+		t.Errorf("empty flags = %v, want only the unset omitempty fields note and tags", empty)
+	}
+
+	if got := flatten(3.5); len(got) != 1 || got[0].name != "value" || got[0].value != 3.5 {
+		t.Errorf("flatten(3.5) = %+v, want one value column", got)
+	}
+	if got := flatten((*renderRow)(nil)); got != nil {
+		t.Errorf("flatten(nil pointer) = %+v, want nil", got)
+	}
+}
+
+func TestCSVCell(t *testing.T) {
+	date := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	stamp := time.Date(2024, time.March, 1, 9, 30, 0, 0, time.FixedZone("EST", -5*3600))
+	var nilTime *time.Time
+	var nilSlice []string
+
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"string", "IBM", "IBM"},
+		{"float", 0.1, "0.1"},
+		{"large float", 1e21, "1000000000000000000000"},
+		{"int", 42, "42"},
+		{"bool", true, "true"},
+		{"decimal", common.MustParseDecimal("-12.50"), "-12.50"},
+		{"null decimal", common.Decimal{}, ""},
+		{"date", date, "2024-03-01"},
+		{"timestamp", stamp, "2024-03-01T09:30:00-05:00"},
+		{"date pointer", &date, "2024-03-01"},
+		{"nil time pointer", nilTime, ""},
+		{"nil", nil, ""},
+		{"nil slice", nilSlice, ""},
+		{"slice", []string{"a", "b"}, `["a","b"]`},
+		{"map", map[string]int{"a": 1}, `{"a":1}`},
+	}
+	for _, tt := range tests {
+		if got := csvCell(tt.value); got != tt.want {
+			t.Errorf("%s: csvCell(%v) = %q, want %q", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+type renderPoint struct {
+	Time  time.Time `json:"time"`
+	Close float64   `json:"close"`
+	Note  string    `json:"note,omitempty"`
+}
+
+func TestRespondCSV(t *testing.T) {
+	rows := []renderPoint{
+		{Time: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), Close: 185.03, Note: "a, \"quoted\" note"},
+		{Time: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), Close: 186},
+	}
+
+	c, w := testContext("/?format=csv", "")
+	respond(c, gin.H{"data": rows}, rows)
+
+	if got := w.Header().Get("Content-Type"); got != "text/csv; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	want := "time,close,note\n2024-03-01,185.03,\"a, \"\"quoted\"\" note\"\n2024-03-04,186,\n"
+	if got := w.Body.String(); got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestRespondCSVWithoutRows(t *testing.T) {
+	for _, rows := range []interface{}{[]renderPoint{}, []*renderPoint(nil)} {
+		c, w := testContext("/?format=csv", "")
+		respond(c, gin.H{}, rows)
+		if got := w.Body.String(); got != "time,close,note\n" {
+			t.Errorf("body for %T = %q, want only the header", rows, got)
+		}
+	}
+
+	c, w := testContext("/?format=csv", "")
+	respond(c, gin.H{}, statementRows(nil, nil))
+	if got := w.Body.String(); got != "period\n" {
+		t.Errorf("statement body = %q, want the period header", got)
+	}
+}
+
+// failingWriter is a response writer whose body writes fail, like a dropped connection
+type failingWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("connection reset by peer")
+}
+
+func TestRespondCSVLogsWriteErrors(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(failingWriter{httptest.NewRecorder()})
+	c.Request = httptest.NewRequest(http.MethodGet, "/?format=csv", nil)
+	rows := []renderPoint{{Time: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), Close: 185.03}}
+	respond(c, gin.H{"data": rows}, rows)
+
+	if !strings.Contains(logged.String(), "writing CSV: connection reset by peer") {
+		t.Errorf("log = %q, want the write error", logged.String())
+	}
+}
+
+func TestRespondNDJSON(t *testing.T) {
+	rows := []renderPoint{
+		{Time: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), Close: 185.03, Note: "first"},
+		{Time: time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC), Close: 186},
+	}
+
+	c, w := testContext("/", "application/x-ndjson")
+	respond(c, gin.H{"data": rows}, rows)
+
+	if got := w.Header().Get("Content-Type"); got != mimeNDJSON {
+		t.Errorf("Content-Type = %q, want %s", got, mimeNDJSON)
+	}
+	want := `{"time":"2024-03-01T00:00:00Z","close":185.03,"note":"first"}` + "\n" +
+		`{"time":"2024-03-04T00:00:00Z","close":186}` + "\n"
+	if got := w.Body.String(); got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+
+	c, w = testContext("/?format=ndjson", "")
+	respond(c, gin.H{}, []renderPoint{})
+	if got := w.Body.String(); got != "" {
+		t.Errorf("body without rows = %q, want empty", got)
+	}
+}
+
+func TestRespondJSON(t *testing.T) {
+	c, w := testContext("/", "")
+	respond(c, gin.H{"symbol": "IBM"}, []renderPoint{{Close: 1}})
+	if got := w.Body.String(); got != `{"symbol":"IBM"}` {
+		t.Errorf("body = %q, want the envelope", got)
+	}
+}
+
+func TestStatementRows(t *testing.T) {
+	type report struct {
+		FiscalDateEnding string `json:"fiscalDateEnding"`
+	}
+	rows := statementRows([]report{{"2023-12-31"}}, []report{{"2024-03-31"}, {"2023-12-31"}})
+	if len(rows) != 3 || rows[0].period != "annual" || rows[1].period != "quarterly" {
+		t.Fatalf("statementRows() = %+v, want one annual then two quarterly rows", rows)
+	}
+	cols := rows[1].columns()
+	if len(cols) != 2 || cols[0].name != "period" || cols[1].name != "fiscalDateEnding" || cols[1].value != "2024-03-31" {
+		t.Errorf("columns() = %+v, want period and fiscalDateEnding", cols)
+	}
+}
@@ -0,0 +1,318 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"stock/alphavantage/market"
+	"stock/common"
+)
+
+// ErrIndexNotReady is returned by local searches before the first refresh succeeded
+var ErrIndexNotReady = errors.New("symbol index not ready")
+
+// failedRefreshDelay is how soon a failed refresh may be retried
+const failedRefreshDelay = 10 * time.Minute
+
+// exchange is the trading calendar and currency of a LISTING_STATUS exchange
+type exchange struct {
+	calendar *market.Calendar
+	currency string
+}
+
+// exchanges maps the exchanges LISTING_STATUS reports to their calendars.
+// Matches on other exchanges are returned without region, hours or currency.
+var exchanges = map[string]exchange{
+	"NYSE":      {market.NYSE, "USD"},
+	"NASDAQ":    {market.NYSE, "USD"},
+	"NYSE ARCA": {market.NYSE, "USD"},
+	"NYSE MKT":  {market.NYSE, "USD"},
+	"BATS":      {market.NYSE, "USD"},
+}
+
+// entry is an indexed listing with its normalized search keys
+type entry struct {
+	listing Listing
+	symbol  string   // Upper case symbol
+	name    string   // Upper case name
+	words   []string // Upper case words of the name
+}
+
+// Index is an in-memory symbol index built from LISTING_STATUS, so lookups
+// do not spend upstream quota. Run rebuilds it on a ticker, and while it is
+// still empty the first search builds it instead of waiting for a tick.
+type Index struct {
+	client *common.Client
+	every  time.Duration // Refresh interval, never refreshed when 0
+
+	mu         sync.RWMutex
+	ctx        context.Context // Context of Run, bounds the refreshes searches start
+	entries    []entry
+	updated    time.Time
+	refreshing bool
+	retryAt    time.Time // Earliest search-started attempt after a failed refresh
+}
+
+// NewIndex creates an empty index refreshed from client every interval once
+// Run is started, it is not ready until the first refresh has completed
+func NewIndex(client *common.Client, every time.Duration) *Index {
+	return &Index{client: client, every: every}
+}
+
+// Ready reports whether the index holds listings
+func (ix *Index) Ready() bool {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.entries) > 0
+}
+
+// Updated returns when the index was last refreshed
+func (ix *Index) Updated() time.Time {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return ix.updated
+}
+
+// Replace swaps the indexed listings
+func (ix *Index) Replace(listings []Listing) {
+	entries := make([]entry, len(listings))
+	for i, listing := range listings {
+		name := strings.ToUpper(listing.Name)
+		entries[i] = entry{
+			listing: listing,
+			symbol:  strings.ToUpper(listing.Symbol),
+			name:    name,
+			words:   strings.Fields(name),
+		}
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.entries = entries
+	ix.updated = time.Now()
+}
+
+// Refresh rebuilds the index from the active listings
+func (ix *Index) Refresh(ctx context.Context) error {
+	if ix.client == nil {
+		return errors.New("symbol index has no client")
+	}
+	listings, err := GetListingStatus(ctx, ix.client, ListingStatusParams{})
+	if err != nil {
+		return err
+	}
+	if len(listings) == 0 {
+		return errors.New("listing status returned no symbols")
+	}
+
+	ix.Replace(listings)
+	return nil
+}
+
+// Run refreshes the index every interval until ctx is done. It returns at
+// once when refreshes are disabled.
+func (ix *Index) Run(ctx context.Context) {
+	if ix.client == nil || ix.every <= 0 {
+		return
+	}
+
+	ix.mu.Lock()
+	ix.ctx = ctx
+	ix.mu.Unlock()
+
+	ticker := time.NewTicker(ix.every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ix.refresh(ctx)
+		}
+	}
+}
+
+// refresh rebuilds the index unless a refresh is already running. A failure
+// holds back the refreshes searches start for failedRefreshDelay.
+func (ix *Index) refresh(ctx context.Context) {
+	ix.mu.Lock()
+	if ix.refreshing {
+		ix.mu.Unlock()
+		return
+	}
+	ix.refreshing = true
+	ix.mu.Unlock()
+
+	err := ix.Refresh(ctx)
+	if err != nil && ctx.Err() == nil {
+		log.Printf("Warning: symbol index refresh failed: %v", err)
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.refreshing = false
+	if err != nil {
+		ix.retryAt = time.Now().Add(failedRefreshDelay)
+	}
+}
+
+// maybeRefresh starts a background refresh while the index is empty, so the
+// first search does not wait for Run's first tick. It does nothing before Run
+// has started, after its context is done, while a refresh is running or when
+// the last one failed recently.
+func (ix *Index) maybeRefresh() {
+	ix.mu.RLock()
+	ctx := ix.ctx
+	skip := ctx == nil || len(ix.entries) > 0 || ix.refreshing || time.Now().Before(ix.retryAt)
+	ix.mu.RUnlock()
+	if skip || ctx.Err() != nil {
+		return
+	}
+
+	go ix.refresh(ctx)
+}
+
+// Search returns up to limit listings matching query, best first. Exact and
+// prefix matches on the symbol rank above name matches, and symbols within a
+// small edit distance of the query are included as fuzzy matches.
+func (ix *Index) Search(query string, limit int) ([]Match, error) {
+	ix.maybeRefresh()
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	if len(ix.entries) == 0 {
+		return nil, ErrIndexNotReady
+	}
+
+	query = strings.ToUpper(strings.TrimSpace(query))
+	if query == "" {
+		return nil, nil
+	}
+
+	type scored struct {
+		entry *entry
+		score float64
+	}
+	var found []scored
+	for i := range ix.entries {
+		if score := matchScore(&ix.entries[i], query); score > 0 {
+			found = append(found, scored{entry: &ix.entries[i], score: score})
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].score != found[j].score {
+			return found[i].score > found[j].score
+		}
+		return found[i].entry.symbol < found[j].entry.symbol
+	})
+	if limit > 0 && len(found) > limit {
+		found = found[:limit]
+	}
+
+	now := time.Now()
+	matches := make([]Match, len(found))
+	for i, f := range found {
+		matches[i] = Match{
+			Symbol:     f.entry.listing.Symbol,
+			Name:       f.entry.listing.Name,
+			Type:       assetType(f.entry.listing.AssetType),
+			MatchScore: math.Round(f.score*10000) / 10000,
+		}
+		if ex, ok := exchanges[strings.ToUpper(f.entry.listing.Exchange)]; ok {
+			matches[i].Region = ex.calendar.Region
+			matches[i].MarketOpen, matches[i].MarketClose = ex.calendar.Hours()
+			matches[i].Timezone = utcOffset(now.In(ex.calendar.Location))
+			matches[i].Currency = ex.currency
+		}
+	}
+	return matches, nil
+}
+
+// matchScore rates how well an entry matches an upper case query, zero means no match
+func matchScore(e *entry, query string) float64 {
+	switch {
+	case e.symbol == query:
+		return 1
+	case strings.HasPrefix(e.symbol, query):
+		// Shorter symbols are closer to the query
+		return 0.9 - 0.01*math.Min(float64(len(e.symbol)-len(query)), 10)
+	case strings.HasPrefix(e.name, query):
+		return 0.8
+	}
+
+	for _, word := range e.words {
+		if strings.HasPrefix(word, query) {
+			return 0.7
+		}
+	}
+	if len(query) >= 3 && strings.Contains(e.name, query) {
+		return 0.6
+	}
+
+	maxEdits := 1
+	if len(query) > 4 {
+		maxEdits = 2
+	}
+	if d := editDistance(e.symbol, query, maxEdits); d <= maxEdits {
+		return 0.5 - 0.1*float64(d)
+	}
+	return 0
+}
+
+// editDistance returns the Levenshtein distance between a and b, or max+1
+// as soon as it is known to exceed max
+func editDistance(a, b string, max int) int {
+	if diff := len(a) - len(b); diff > max || -diff > max {
+		return max + 1
+	}
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		best := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			best = min(best, curr[j])
+		}
+		if best > max {
+			return max + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// assetType maps LISTING_STATUS asset types to the SYMBOL_SEARCH naming
+func assetType(value string) string {
+	if strings.EqualFold(value, "Stock") {
+		return "Equity"
+	}
+	return value
+}
+
+// utcOffset formats the zone offset of t like SYMBOL_SEARCH does, e.g. "UTC-04"
+func utcOffset(t time.Time) string {
+	_, offset := t.Zone()
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	return fmt.Sprintf("UTC%s%02d", sign, offset/3600)
+}
@@ -0,0 +1,195 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"stock/common"
+	"stock/common/commontest"
+)
+
+const testListing = `symbol,name,exchange,assetType,ipoDate,delistingDate,status
+IBM,International Business Machines Corp,NYSE,Stock,1962-01-02,null,Active
+IBMX,IBM Example Fund,NYSE ARCA,ETF,2020-01-02,null,Active
+MSFT,Microsoft Corporation,NASDAQ,Stock,1986-03-13,null,Active
+ZZZZ,Elsewhere Listed Corp,OTHER,Stock,2001-01-02,null,Active
+`
+
+// listingClient serves testListing and counts the LISTING_STATUS calls
+func listingClient(t *testing.T, calls *atomic.Int64) *common.Client {
+	t.Helper()
+	return commontest.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		fmt.Fprint(w, testListing)
+	})
+}
+
+// runIndex runs ix until the test ends
+func runIndex(t *testing.T, ix *Index) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ix.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// waitReady polls until the index holds listings
+func waitReady(t *testing.T, ix *Index) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if ix.Ready() {
+			return
+		}
+	}
+	t.Fatal("index not ready within a second")
+}
+
+func TestIndexBuildsOnFirstSearch(t *testing.T) {
+	var calls atomic.Int64
+	ix := NewIndex(listingClient(t, &calls), time.Hour)
+	runIndex(t, ix)
+	time.Sleep(20 * time.Millisecond)
+	if calls.Load() != 0 {
+		t.Fatal("Run() fetched the listing before its first tick")
+	}
+
+	if _, err := ix.Search("IBM", 5); !errors.Is(err, ErrIndexNotReady) {
+		t.Fatalf("first Search() error = %v, want ErrIndexNotReady", err)
+	}
+	waitReady(t, ix)
+
+	matches, err := ix.Search("IBM", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 || matches[0].Symbol != "IBM" || matches[0].MatchScore != 1 || matches[1].Symbol != "IBMX" {
+		t.Fatalf("Search(IBM) = %+v, want IBM then IBMX", matches)
+	}
+	if m := matches[0]; m.Region != "United States" || m.MarketOpen != "09:30" || m.MarketClose != "16:00" || m.Currency != "USD" || m.Type != "Equity" {
+		t.Errorf("IBM match = %+v, want NYSE hours in USD", m)
+	}
+
+	// Exchanges without a known calendar leave the market fields empty
+	matches, _ = ix.Search("ZZZZ", 1)
+	if len(matches) != 1 || matches[0].Region != "" || matches[0].MarketOpen != "" || matches[0].Currency != "" || matches[0].Timezone != "" {
+		t.Errorf("Search(ZZZZ) = %+v, want no market fields", matches)
+	}
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("LISTING_STATUS calls = %d, want 1 while the index is fresh", got)
+	}
+}
+
+func TestIndexRefreshesOnSchedule(t *testing.T) {
+	var calls atomic.Int64
+	ix := NewIndex(listingClient(t, &calls), 10*time.Millisecond)
+	runIndex(t, ix)
+	waitReady(t, ix)
+
+	first := ix.Updated()
+	for deadline := time.Now().Add(time.Second); calls.Load() < 3 && time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+	}
+	if got := calls.Load(); got < 3 {
+		t.Fatalf("LISTING_STATUS calls = %d, want a refresh on every tick", got)
+	}
+	if !ix.Updated().After(first) {
+		t.Errorf("Updated() = %v, want later than the first refresh at %v", ix.Updated(), first)
+	}
+}
+
+func TestIndexRunStopsWithContext(t *testing.T) {
+	var calls atomic.Int64
+	ix := NewIndex(listingClient(t, &calls), 10*time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ix.Run(ctx)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run() still running after its context was cancelled")
+	}
+
+	// Searches no longer start refreshes once the context is done
+	if _, err := ix.Search("IBM", 5); !errors.Is(err, ErrIndexNotReady) {
+		t.Fatalf("Search() error = %v, want ErrIndexNotReady", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if got := calls.Load(); got != 0 {
+		t.Errorf("LISTING_STATUS calls = %d after Run() stopped, want 0", got)
+	}
+}
+
+func TestIndexNotBuiltWithoutRun(t *testing.T) {
+	var calls atomic.Int64
+	ix := NewIndex(listingClient(t, &calls), time.Hour)
+	if _, err := ix.Search("IBM", 5); !errors.Is(err, ErrIndexNotReady) {
+		t.Fatalf("Search() error = %v, want ErrIndexNotReady", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if got := calls.Load(); got != 0 {
+		t.Errorf("LISTING_STATUS calls = %d before Run(), want 0", got)
+	}
+}
+
+func TestIndexRefreshDisabled(t *testing.T) {
+	var calls atomic.Int64
+	ix := NewIndex(listingClient(t, &calls), 0)
+	runIndex(t, ix)
+	if _, err := ix.Search("IBM", 5); !errors.Is(err, ErrIndexNotReady) {
+		t.Fatalf("Search() error = %v, want ErrIndexNotReady", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if got := calls.Load(); got != 0 {
+		t.Errorf("LISTING_STATUS calls = %d with refreshes disabled, want 0", got)
+	}
+}
+
+func TestMatchScore(t *testing.T) {
+	ix := NewIndex(nil, 0)
+	ix.Replace([]Listing{
+		{Symbol: "AAPL", Name: "Apple Inc"},
+		{Symbol: "AAP", Name: "Advance Auto Parts Inc"},
+		{Symbol: "MSFT", Name: "Microsoft Corporation"},
+	})
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"aapl", []string{"AAPL", "AAP"}}, // Exact, then one edit away
+		{"AA", []string{"AAP", "AAPL"}},   // Prefixes, shorter first
+		{"micro", []string{"MSFT"}},       // Name prefix
+		{"corporation", []string{"MSFT"}}, // Name word
+		{"MSFY", []string{"MSFT"}},        // Typo
+		{"QQQQQQ", nil},                   // Nothing close
+	}
+	for _, tt := range tests {
+		matches, err := ix.Search(tt.query, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, m := range matches {
+			got = append(got, m.Symbol)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package search
+
+import (
+	"context"
+
+	"stock/common"
+)
+
+// ListingStatusParams holds parameters for retrieving listed symbols
+type ListingStatusParams struct {
+	Date  string // YYYY-MM-DD, latest trading day when empty
+	State string // active or delisted, active when empty
+}
+
+// Listing is a security from LISTING_STATUS
+type Listing struct {
+	Symbol        string `json:"symbol"`
+	Name          string `json:"name"`
+	Exchange      string `json:"exchange"`
+	AssetType     string `json:"assetType"`
+	IPODate       string `json:"ipoDate"`
+	DelistingDate string `json:"delistingDate"`
+	Status        string `json:"status"`
+}
+
+// GetListingStatus fetches every listed or delisted US security, which
+// Alpha Vantage only serves as CSV
+func GetListingStatus(ctx context.Context, client *common.Client, params ListingStatusParams) ([]Listing, error) {
+	// Building query parameters
+	queryParams := map[string]string{
+		"function": "LISTING_STATUS",
+	}
+	if params.Date != "" {
+		queryParams["date"] = params.Date
+	}
+	if params.State != "" {
+		queryParams["state"] = params.State
+	}
+
+	records, err := client.GetCSV(ctx, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	listings := make([]Listing, 0, len(records))
+	for _, record := range records {
+		if record["symbol"] == "" {
+			continue
+		}
+		listings = append(listings, Listing{
+			Symbol:        record["symbol"],
+			Name:          record["name"],
+			Exchange:      record["exchange"],
+			AssetType:     record["assettype"],
+			IPODate:       record["ipodate"],
+			DelistingDate: record["delistingdate"],
+			Status:        record["status"],
+		})
+	}
+	return listings, nil
+}
@@ -1,6 +1,85 @@
 package search
 
-/*
-	by keyword
-	by symbol
-*/
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"stock/common"
+)
+
+// SymbolSearchParams holds parameters for searching symbols upstream
+type SymbolSearchParams struct {
+	Keywords string
+}
+
+// Match is a symbol matching a search query
+type Match struct {
+	Symbol      string  `json:"symbol"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	Region      string  `json:"region"`
+	MarketOpen  string  `json:"marketOpen"`
+	MarketClose string  `json:"marketClose"`
+	Timezone    string  `json:"timezone"`
+	Currency    string  `json:"currency"`
+	MatchScore  float64 `json:"matchScore"` // 0 to 1, higher is closer
+}
+
+// SymbolSearchResponse defines the structure of a SYMBOL_SEARCH response
+type SymbolSearchResponse struct {
+	BestMatches []Match `json:"bestMatches"`
+}
+
+// UnmarshalJSON decodes an upstream match, whose keys are numbered like "1. symbol"
+func (m *Match) UnmarshalJSON(data []byte) error {
+	var fields map[string]string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	for key, value := range fields {
+		if _, rest, ok := strings.Cut(key, ". "); ok {
+			key = rest
+		}
+		switch strings.ToLower(key) {
+		case "symbol":
+			m.Symbol = value
+		case "name":
+			m.Name = value
+		case "type":
+			m.Type = value
+		case "region":
+			m.Region = value
+		case "marketopen":
+			m.MarketOpen = value
+		case "marketclose":
+			m.MarketClose = value
+		case "timezone":
+			m.Timezone = value
+		case "currency":
+			m.Currency = value
+		case "matchscore":
+			m.MatchScore, _ = strconv.ParseFloat(value, 64)
+		}
+	}
+	return nil
+}
+
+// SearchSymbols finds symbols matching keywords with SYMBOL_SEARCH
+func SearchSymbols(ctx context.Context, client *common.Client, params SymbolSearchParams) ([]Match, error) {
+	// Building query parameters
+	queryParams := map[string]string{
+		"function": "SYMBOL_SEARCH",
+		"keywords": params.Keywords,
+	}
+
+	// Make HTTP request and parse the response
+	result := &SymbolSearchResponse{}
+	if err := client.GetJSON(ctx, queryParams, result); err != nil {
+		return nil, err
+	}
+
+	return result.BestMatches, nil
+}
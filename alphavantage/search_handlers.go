@@ -0,0 +1,115 @@
+package alphavantage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"stock/alphavantage/search"
+	"stock/common"
+	"stock/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchResponse defines the response format for symbol search
+// @Description Symbol search response data structure
+type SearchResponse struct {
+	Version   string         `json:"version"`
+	Timestamp string         `json:"timestamp"`
+	Query     string         `json:"query"`
+	Source    string         `json:"source"` // local or upstream
+	Cache     string         `json:"cache,omitempty"`
+	Data      []search.Match `json:"data"`
+}
+
+// Search sources
+const (
+	searchSourceAuto     = "auto"     // Local index when ready, upstream otherwise
+	searchSourceLocal    = "local"    // Local index only
+	searchSourceUpstream = "upstream" // SYMBOL_SEARCH only
+)
+
+// defaultSearchLimit is the number of matches returned when no limit is given
+const defaultSearchLimit = 10
+
+// SearchSymbols handles symbol search requests
+// @Summary Search symbols by ticker or company name
+// @Description Returns matching symbols, best first. The local index is built from LISTING_STATUS and supports prefix and fuzzy lookups without spending upstream quota, upstream searches call SYMBOL_SEARCH.
+// @Tags search
+// @Produce json,text/csv,application/x-ndjson
+// @Param q query string true "Ticker or company name, or a prefix of either"
+// @Param source query string false "Where to search, auto uses the local index once it is ready" Enums(auto, local, upstream) default(auto)
+// @Param limit query int false "Maximum number of matches" default(10)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Success 200 {object} SearchResponse "Successful operation"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 503 {object} ErrorResponse "Local index not ready"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
+// @Router /v1/search [get]
+func (h *Handler) SearchSymbols(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		respondError(c, fmt.Errorf("%w: q is required", common.ErrInvalidParameter))
+		return
+	}
+
+	source := c.DefaultQuery("source", searchSourceAuto)
+	if source != searchSourceAuto && source != searchSourceLocal && source != searchSourceUpstream {
+		respondError(c, fmt.Errorf("%w: source must be %s, %s or %s", common.ErrInvalidParameter, searchSourceAuto, searchSourceLocal, searchSourceUpstream))
+		return
+	}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	ctx, cache := requestContext(c)
+
+	var matches []search.Match
+	if source != searchSourceUpstream {
+		matches, err = h.client.SearchIndex(query, limit)
+		if err == nil {
+			source = searchSourceLocal
+		} else if !errors.Is(err, search.ErrIndexNotReady) || source == searchSourceLocal {
+			respondError(c, err)
+			return
+		}
+	}
+	if source != searchSourceLocal {
+		source = searchSourceUpstream
+		if matches, err = h.client.SearchSymbols(ctx, search.SymbolSearchParams{Keywords: query}); err != nil {
+			respondError(c, err)
+			return
+		}
+		if len(matches) > limit {
+			matches = matches[:limit]
+		}
+	}
+
+	if matches == nil {
+		matches = []search.Match{}
+	}
+
+	response := SearchResponse{
+		Version:   config.GetConfig().DefaultAPIVersion,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Query:     query,
+		Source:    source,
+		Cache:     setCacheHeader(c, cache),
+		Data:      matches,
+	}
+
+	respond(c, response, matches)
+}
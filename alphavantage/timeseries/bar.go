@@ -0,0 +1,101 @@
+package timeseries
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// defaultTimeZone is assumed when the metadata does not name a time zone
+const defaultTimeZone = "US/Eastern"
+
+// timestampLayouts are the layouts Alpha Vantage uses for series keys
+var timestampLayouts = []string{"2006-01-02 15:04:05", "2006-01-02"}
+
+// Bar is a single OHLCV data point in the exchange time zone
+type Bar struct {
+	Time          time.Time `json:"time"`
+	Open          float64   `json:"open"`
+	High          float64   `json:"high"`
+	Low           float64   `json:"low"`
+	Close         float64   `json:"close"`
+	AdjustedClose float64   `json:"adjusted_close,omitempty"`
+	Volume        float64   `json:"volume"`
+}
+
+// Location returns the exchange time zone named in the metadata
+func (m TimeSeriesMetaData) Location() (*time.Location, error) {
+	name := m.TimeZone
+	if name == "" {
+		name = defaultTimeZone
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("time zone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// Bars returns the series as bars in chronological order
+func (r *TimeSeriesResponse) Bars() ([]Bar, error) {
+	loc, err := r.MetaData.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	bars := make([]Bar, 0, len(r.TimeSeries))
+	for timestamp, data := range r.TimeSeries {
+		bar, err := data.bar(timestamp, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", timestamp, err)
+		}
+		bars = append(bars, bar)
+	}
+
+	sort.Slice(bars, func(i, j int) bool {
+		return bars[i].Time.Before(bars[j].Time)
+	})
+	return bars, nil
+}
+
+// bar converts a raw data point keyed by timestamp into a Bar
+func (d TimeSeriesData) bar(timestamp string, loc *time.Location) (Bar, error) {
+	t, err := parseTimestamp(timestamp, loc)
+	if err != nil {
+		return Bar{}, err
+	}
+
+	bar := Bar{Time: t}
+	fields := []struct {
+		value string
+		dst   *float64
+	}{
+		{d.Open, &bar.Open},
+		{d.High, &bar.High},
+		{d.Low, &bar.Low},
+		{d.Close, &bar.Close},
+		{d.AdjustedClose, &bar.AdjustedClose},
+		{d.Volume, &bar.Volume},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+		if *f.dst, err = strconv.ParseFloat(f.value, 64); err != nil {
+			return Bar{}, err
+		}
+	}
+	return bar, nil
+}
+
+// parseTimestamp parses a series key in the exchange time zone
+func parseTimestamp(timestamp string, loc *time.Location) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.ParseInLocation(layout, timestamp, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", timestamp)
+}
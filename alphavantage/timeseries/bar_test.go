@@ -0,0 +1,96 @@
+package timeseries
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBarsNormalizesAndOrders(t *testing.T) {
+	resp := &TimeSeriesResponse{
+		MetaData: TimeSeriesMetaData{TimeZone: "US/Eastern"},
+		TimeSeries: map[string]TimeSeriesData{
+			"2024-03-01 10:00:00": {Open: "3", High: "3.5", Low: "2.5", Close: "3.25", Volume: "30"},
+			"2024-03-01 09:30:00": {Open: "1", High: "1.5", Low: "0.5", Close: "1.25", Volume: "10"},
+			"2024-02-29 15:55:00": {Open: "0", High: "0.5", Low: "0", Close: "0.25", Volume: "5"},
+			"2024-03-01 09:45:00": {Open: "2", High: "2.5", Low: "1.5", Close: "2.25", AdjustedClose: "2.2", Volume: "20"},
+		},
+	}
+
+	bars, err := resp.Bars()
+	if err != nil {
+		t.Fatalf("Bars() error = %v", err)
+	}
+
+	want := []Bar{
+		{Time: time.Date(2024, time.February, 29, 15, 55, 0, 0, newYork), Open: 0, High: 0.5, Low: 0, Close: 0.25, Volume: 5},
+		{Time: time.Date(2024, time.March, 1, 9, 30, 0, 0, newYork), Open: 1, High: 1.5, Low: 0.5, Close: 1.25, Volume: 10},
+		{Time: time.Date(2024, time.March, 1, 9, 45, 0, 0, newYork), Open: 2, High: 2.5, Low: 1.5, Close: 2.25, AdjustedClose: 2.2, Volume: 20},
+		{Time: time.Date(2024, time.March, 1, 10, 0, 0, 0, newYork), Open: 3, High: 3.5, Low: 2.5, Close: 3.25, Volume: 30},
+	}
+	if len(bars) != len(want) {
+		t.Fatalf("Bars() returned %d bars, want %d", len(bars), len(want))
+	}
+	for i := range want {
+		if !bars[i].Time.Equal(want[i].Time) {
+			t.Errorf("bar %d time = %s, want %s", i, bars[i].Time, want[i].Time)
+		}
+		got := bars[i]
+		got.Time = want[i].Time
+		if got != want[i] {
+			t.Errorf("bar %d = %+v, want %+v", i, bars[i], want[i])
+		}
+	}
+
+	// Bars carry the exchange offset, 09:30 New York is 14:30 UTC in winter
+	if got := bars[1].Time.UTC().Hour(); got != 14 {
+		t.Errorf("bar 1 UTC hour = %d, want 14", got)
+	}
+}
+
+func TestBarsTimeZones(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeZone string
+		wantUTC  time.Time
+		wantErr  bool
+	}{
+		{"default eastern", "", time.Date(2024, time.July, 1, 4, 0, 0, 0, time.UTC), false},
+		{"named eastern", "US/Eastern", time.Date(2024, time.July, 1, 4, 0, 0, 0, time.UTC), false},
+		{"utc", "UTC", time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC), false},
+		{"unknown", "Mars/Olympus", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &TimeSeriesResponse{
+				MetaData:   TimeSeriesMetaData{TimeZone: tt.timeZone},
+				TimeSeries: map[string]TimeSeriesData{"2024-07-01": {Open: "1", High: "1", Low: "1", Close: "1", Volume: "1"}},
+			}
+			bars, err := resp.Bars()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bars() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !bars[0].Time.Equal(tt.wantUTC) {
+				t.Errorf("bar time = %s, want %s", bars[0].Time.UTC(), tt.wantUTC)
+			}
+		})
+	}
+}
+
+func TestBarsRejectsMalformedPoints(t *testing.T) {
+	tests := []struct {
+		name      string
+		timestamp string
+		data      TimeSeriesData
+	}{
+		{"bad timestamp", "03/01/2024", TimeSeriesData{Open: "1", High: "1", Low: "1", Close: "1", Volume: "1"}},
+		{"bad price", "2024-03-01", TimeSeriesData{Open: "one", High: "1", Low: "1", Close: "1", Volume: "1"}},
+		{"bad volume", "2024-03-01", TimeSeriesData{Open: "1", High: "1", Low: "1", Close: "1", Volume: "lots"}},
+	}
+	for _, tt := range tests {
+		resp := &TimeSeriesResponse{TimeSeries: map[string]TimeSeriesData{tt.timestamp: tt.data}}
+		if _, err := resp.Bars(); err == nil {
+			t.Errorf("%s: Bars() error = nil, want an error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package timeseries
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"stock/common"
+)
+
+// seriesKey returns the key upstream JSON carries the series under for a function
+func seriesKey(function, interval string) string {
+	switch strings.TrimSuffix(function, "_ADJUSTED") {
+	case "TIME_SERIES_INTRADAY":
+		return fmt.Sprintf("Time Series (%s)", interval)
+	case "TIME_SERIES_WEEKLY":
+		if strings.HasSuffix(function, "_ADJUSTED") {
+			return "Weekly Adjusted Time Series"
+		}
+		return "Weekly Time Series"
+	case "TIME_SERIES_MONTHLY":
+		if strings.HasSuffix(function, "_ADJUSTED") {
+			return "Monthly Adjusted Time Series"
+		}
+		return "Monthly Time Series"
+	}
+	return "Time Series (Daily)"
+}
+
+// fromCSV builds the same response the JSON path produces from CSV records.
+// CSV bodies carry no metadata, so it is filled in from the request.
+func fromCSV(records []common.CSVRecord, params TimeSeriesParams) (*TimeSeriesResponse, error) {
+	result := &TimeSeriesResponse{
+		MetaData: TimeSeriesMetaData{
+			Symbol:     params.Symbol,
+			Interval:   params.Interval,
+			OutputSize: params.OutputSize,
+			TimeZone:   defaultTimeZone,
+		},
+		SeriesKey:  seriesKey(params.Function, params.Interval),
+		TimeSeries: make(map[string]TimeSeriesData, len(records)),
+	}
+
+	for _, record := range records {
+		timestamp := record["timestamp"]
+		if timestamp == "" {
+			return nil, errors.New("csv record without timestamp")
+		}
+		result.TimeSeries[timestamp] = TimeSeriesData{
+			Open:             record["open"],
+			High:             record["high"],
+			Low:              record["low"],
+			Close:            record["close"],
+			AdjustedClose:    record["adjusted_close"],
+			Volume:           record["volume"],
+			DividendAmount:   record["dividend_amount"],
+			SplitCoefficient: record["split_coefficient"],
+		}
+
+		// Timestamps share one layout, so they sort lexically
+		if timestamp > result.MetaData.LastRefreshed {
+			result.MetaData.LastRefreshed = timestamp
+		}
+	}
+
+	return result, nil
+}
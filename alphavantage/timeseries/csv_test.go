@@ -0,0 +1,139 @@
+package timeseries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"stock/common"
+	"stock/common/commontest"
+)
+
+func TestSeriesKey(t *testing.T) {
+	tests := []struct {
+		function, interval string
+		want               string
+	}{
+		{"TIME_SERIES_INTRADAY", "1min", "Time Series (1min)"},
+		{"TIME_SERIES_INTRADAY", "60min", "Time Series (60min)"},
+		{"TIME_SERIES_DAILY", "", "Time Series (Daily)"},
+		{"TIME_SERIES_DAILY_ADJUSTED", "", "Time Series (Daily)"},
+		{"TIME_SERIES_WEEKLY", "", "Weekly Time Series"},
+		{"TIME_SERIES_WEEKLY_ADJUSTED", "", "Weekly Adjusted Time Series"},
+		{"TIME_SERIES_MONTHLY", "", "Monthly Time Series"},
+		{"TIME_SERIES_MONTHLY_ADJUSTED", "", "Monthly Adjusted Time Series"},
+	}
+	for _, tt := range tests {
+		if got := seriesKey(tt.function, tt.interval); got != tt.want {
+			t.Errorf("seriesKey(%s, %q) = %q, want %q", tt.function, tt.interval, got, tt.want)
+		}
+	}
+}
+
+// csvBodies serves the same two daily adjusted bars as JSON and as CSV
+var csvBodies = map[string]string{
+	"json": `{
+		"Meta Data": {"1. Information": "Daily Time Series with Splits and Dividend Events", "2. Symbol": "IBM", "3. Last Refreshed": "2024-03-01", "4. Output Size": "Compact", "5. Time Zone": "US/Eastern"},
+		"Time Series (Daily)": {
+			"2024-03-01": {"1. open": "185.49", "2. high": "188.38", "3. low": "185.18", "4. close": "185.03", "5. adjusted close": "183.2", "6. volume": "4018354", "7. dividend amount": "0.0000", "8. split coefficient": "1.0"},
+			"2024-02-29": {"1. open": "184.7", "2. high": "186.1", "3. low": "183.2", "4. close": "185.5", "5. adjusted close": "183.66", "6. volume": "6458487", "7. dividend amount": "1.6600", "8. split coefficient": "1.0"}
+		}
+	}`,
+	"csv": "\xef\xbb\xbftimestamp,open,high,low,close,adjusted_close,volume,dividend_amount,split_coefficient\r\n" +
+		"2024-03-01,185.49,188.38,185.18,185.03,183.2,4018354,0.0000,1.0\r\n" +
+		"2024-02-29,184.7,186.1,183.2,185.5,183.66,6458487,1.6600,1.0\r\n",
+}
+
+func TestCSVMatchesJSON(t *testing.T) {
+	client := commontest.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		datatype := r.URL.Query().Get("datatype")
+		if datatype == "" {
+			datatype = "json"
+		}
+		fmt.Fprint(w, csvBodies[datatype])
+	})
+
+	params := TimeSeriesParams{Function: "TIME_SERIES_DAILY_ADJUSTED", Symbol: "IBM", OutputSize: "compact"}
+	fromJSON, err := GetRawTimeSeries(context.Background(), client, params)
+	if err != nil {
+		t.Fatalf("GetRawTimeSeries(json) error = %v", err)
+	}
+	params.DataType = "csv"
+	fromCSV, err := GetRawTimeSeries(context.Background(), client, params)
+	if err != nil {
+		t.Fatalf("GetRawTimeSeries(csv) error = %v", err)
+	}
+
+	if fromCSV.SeriesKey != fromJSON.SeriesKey {
+		t.Errorf("csv SeriesKey = %q, want %q", fromCSV.SeriesKey, fromJSON.SeriesKey)
+	}
+	if fromCSV.MetaData.Symbol != "IBM" || fromCSV.MetaData.LastRefreshed != "2024-03-01" || fromCSV.MetaData.TimeZone != "US/Eastern" {
+		t.Errorf("csv MetaData = %+v, want symbol, last refreshed and time zone filled in", fromCSV.MetaData)
+	}
+	for timestamp, want := range fromJSON.TimeSeries {
+		if got := fromCSV.TimeSeries[timestamp]; got != want {
+			t.Errorf("csv point %s = %+v, want %+v", timestamp, got, want)
+		}
+	}
+
+	jsonBars, err := fromJSON.Bars()
+	if err != nil {
+		t.Fatalf("json Bars() error = %v", err)
+	}
+	csvBars, err := fromCSV.Bars()
+	if err != nil {
+		t.Fatalf("csv Bars() error = %v", err)
+	}
+	if len(csvBars) != 2 || len(csvBars) != len(jsonBars) {
+		t.Fatalf("csv Bars() = %d bars, json %d, want 2", len(csvBars), len(jsonBars))
+	}
+	for i := range jsonBars {
+		got := csvBars[i]
+		if got.Time.Equal(jsonBars[i].Time) {
+			got.Time = jsonBars[i].Time
+		}
+		if got != jsonBars[i] {
+			t.Errorf("csv bar %d = %+v, want %+v", i, csvBars[i], jsonBars[i])
+		}
+	}
+}
+
+func TestFromCSV(t *testing.T) {
+	params := TimeSeriesParams{Function: "TIME_SERIES_INTRADAY", Symbol: "IBM", Interval: "5min", OutputSize: "full"}
+	records := []common.CSVRecord{
+		{"timestamp": "2024-03-01 09:35:00", "open": "2", "high": "2", "low": "2", "close": "2", "volume": "20"},
+		{"timestamp": "2024-03-01 09:40:00", "open": "3", "high": "3", "low": "3", "close": "3", "volume": "30"},
+		{"timestamp": "2024-03-01 09:30:00", "open": "1", "high": "1", "low": "1", "close": "1", "volume": "10"},
+	}
+
+	resp, err := fromCSV(records, params)
+	if err != nil {
+		t.Fatalf("fromCSV() error = %v", err)
+	}
+	want := TimeSeriesMetaData{Symbol: "IBM", LastRefreshed: "2024-03-01 09:40:00", Interval: "5min", OutputSize: "full", TimeZone: defaultTimeZone}
+	if resp.MetaData != want || resp.SeriesKey != "Time Series (5min)" {
+		t.Errorf("fromCSV() = %+v under %q, want %+v under Time Series (5min)", resp.MetaData, resp.SeriesKey, want)
+	}
+	if got := resp.TimeSeries["2024-03-01 09:35:00"]; got != (TimeSeriesData{Open: "2", High: "2", Low: "2", Close: "2", Volume: "20"}) {
+		t.Errorf("point 09:35 = %+v", got)
+	}
+
+	if _, err := fromCSV([]common.CSVRecord{{"open": "1"}}, params); err == nil {
+		t.Error("fromCSV() of a record without timestamp error = nil, want an error")
+	}
+	if resp, err := fromCSV(nil, params); err != nil || len(resp.TimeSeries) != 0 {
+		t.Errorf("fromCSV(nil) = %+v, %v, want an empty series", resp, err)
+	}
+}
+
+func TestCSVSoftError(t *testing.T) {
+	client := commontest.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Error Message": "Invalid API call. Please retry or visit the documentation."}`)
+	})
+	_, err := GetRawTimeSeries(context.Background(), client, TimeSeriesParams{Function: "TIME_SERIES_DAILY", Symbol: "NOPE", DataType: "csv"})
+	if !errors.Is(err, common.ErrInvalidSymbol) {
+		t.Fatalf("GetRawTimeSeries() error = %v, want ErrInvalidSymbol", err)
+	}
+}
@@ -0,0 +1,143 @@
+package timeseries
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// metaDataKey is the top-level key holding the series metadata
+const metaDataKey = "Meta Data"
+
+// label strips the "1. " style numbering from an upstream key
+func label(key string) string {
+	if _, rest, ok := strings.Cut(key, ". "); ok {
+		return strings.ToLower(rest)
+	}
+	return strings.ToLower(key)
+}
+
+// UnmarshalJSON decodes metadata by label since its numbering varies per function
+func (m *TimeSeriesMetaData) UnmarshalJSON(data []byte) error {
+	var fields map[string]string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	for key, value := range fields {
+		switch label(key) {
+		case "information":
+			m.Information = value
+		case "symbol":
+			m.Symbol = value
+		case "last refreshed":
+			m.LastRefreshed = value
+		case "interval":
+			m.Interval = value
+		case "output size":
+			m.OutputSize = value
+		case "time zone":
+			m.TimeZone = value
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes a data point by label, covering plain and adjusted layouts
+func (d *TimeSeriesData) UnmarshalJSON(data []byte) error {
+	var fields map[string]string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	for key, value := range fields {
+		switch label(key) {
+		case "open":
+			d.Open = value
+		case "high":
+			d.High = value
+		case "low":
+			d.Low = value
+		case "close":
+			d.Close = value
+		case "adjusted close":
+			d.AdjustedClose = value
+		case "volume":
+			d.Volume = value
+		case "dividend amount":
+			d.DividendAmount = value
+		case "split coefficient":
+			d.SplitCoefficient = value
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes a data point with the upstream numbering of its layout
+func (d TimeSeriesData) MarshalJSON() ([]byte, error) {
+	keys := []string{"1. open", "2. high", "3. low", "4. close", "5. volume"}
+	values := []string{d.Open, d.High, d.Low, d.Close, d.Volume}
+
+	if d.AdjustedClose != "" {
+		keys = []string{"1. open", "2. high", "3. low", "4. close", "5. adjusted close", "6. volume", "7. dividend amount"}
+		values = []string{d.Open, d.High, d.Low, d.Close, d.AdjustedClose, d.Volume, d.DividendAmount}
+		if d.SplitCoefficient != "" {
+			keys = append(keys, "8. split coefficient")
+			values = append(values, d.SplitCoefficient)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		v, _ := json.Marshal(values[i])
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON finds the series under whichever "... Time Series ..." key carries it
+func (r *TimeSeriesResponse) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if raw, ok := fields[metaDataKey]; ok {
+		if err := json.Unmarshal(raw, &r.MetaData); err != nil {
+			return fmt.Errorf("meta data: %w", err)
+		}
+	}
+
+	for key, raw := range fields {
+		if !strings.Contains(key, "Time Series") {
+			continue
+		}
+		r.SeriesKey = key
+		if err := json.Unmarshal(raw, &r.TimeSeries); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		break
+	}
+	return nil
+}
+
+// MarshalJSON encodes the response in the upstream shape, keeping the series
+// under the key it was read from
+func (r TimeSeriesResponse) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{
+		metaDataKey: r.MetaData,
+	}
+	if r.SeriesKey != "" {
+		fields[r.SeriesKey] = r.TimeSeries
+	}
+	return json.Marshal(fields)
+}
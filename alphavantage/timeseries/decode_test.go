@@ -0,0 +1,141 @@
+package timeseries
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTimeSeriesResponseUnmarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantKey  string
+		wantMeta TimeSeriesMetaData
+		wantData map[string]TimeSeriesData
+	}{
+		{
+			name: "intraday",
+			body: `{
+				"Meta Data": {"1. Information": "Intraday (5min) open, high, low, close prices and volume", "2. Symbol": "IBM", "3. Last Refreshed": "2024-03-01 19:55:00", "4. Interval": "5min", "5. Output Size": "Compact", "6. Time Zone": "US/Eastern"},
+				"Time Series (5min)": {"2024-03-01 19:55:00": {"1. open": "185.5000", "2. high": "185.6000", "3. low": "185.4000", "4. close": "185.5500", "5. volume": "120"}}
+			}`,
+			wantKey:  "Time Series (5min)",
+			wantMeta: TimeSeriesMetaData{Information: "Intraday (5min) open, high, low, close prices and volume", Symbol: "IBM", LastRefreshed: "2024-03-01 19:55:00", Interval: "5min", OutputSize: "Compact", TimeZone: "US/Eastern"},
+			wantData: map[string]TimeSeriesData{"2024-03-01 19:55:00": {Open: "185.5000", High: "185.6000", Low: "185.4000", Close: "185.5500", Volume: "120"}},
+		},
+		{
+			name: "intraday 60min",
+			body: `{
+				"Meta Data": {"1. Information": "Intraday (60min)", "2. Symbol": "IBM", "3. Last Refreshed": "2024-03-01 19:00:00", "4. Interval": "60min", "5. Output Size": "Full size", "6. Time Zone": "US/Eastern"},
+				"Time Series (60min)": {"2024-03-01 19:00:00": {"1. open": "1", "2. high": "2", "3. low": "0.5", "4. close": "1.5", "5. volume": "10"}}
+			}`,
+			wantKey:  "Time Series (60min)",
+			wantMeta: TimeSeriesMetaData{Information: "Intraday (60min)", Symbol: "IBM", LastRefreshed: "2024-03-01 19:00:00", Interval: "60min", OutputSize: "Full size", TimeZone: "US/Eastern"},
+			wantData: map[string]TimeSeriesData{"2024-03-01 19:00:00": {Open: "1", High: "2", Low: "0.5", Close: "1.5", Volume: "10"}},
+		},
+		{
+			name: "daily adjusted",
+			body: `{
+				"Meta Data": {"1. Information": "Daily Time Series with Splits and Dividend Events", "2. Symbol": "IBM", "3. Last Refreshed": "2024-03-01", "4. Output Size": "Compact", "5. Time Zone": "US/Eastern"},
+				"Time Series (Daily)": {"2024-03-01": {"1. open": "185.4900", "2. high": "188.3800", "3. low": "185.1800", "4. close": "185.0300", "5. adjusted close": "183.2", "6. volume": "4018354", "7. dividend amount": "0.0000", "8. split coefficient": "1.0"}}
+			}`,
+			wantKey:  "Time Series (Daily)",
+			wantMeta: TimeSeriesMetaData{Information: "Daily Time Series with Splits and Dividend Events", Symbol: "IBM", LastRefreshed: "2024-03-01", OutputSize: "Compact", TimeZone: "US/Eastern"},
+			wantData: map[string]TimeSeriesData{"2024-03-01": {Open: "185.4900", High: "188.3800", Low: "185.1800", Close: "185.0300", AdjustedClose: "183.2", Volume: "4018354", DividendAmount: "0.0000", SplitCoefficient: "1.0"}},
+		},
+		{
+			name: "weekly adjusted",
+			body: `{
+				"Meta Data": {"1. Information": "Weekly Adjusted Prices and Volumes", "2. Symbol": "IBM", "3. Last Refreshed": "2024-03-01", "4. Time Zone": "US/Eastern"},
+				"Weekly Adjusted Time Series": {"2024-03-01": {"1. open": "187", "2. high": "188", "3. low": "183", "4. close": "185", "5. adjusted close": "184", "6. volume": "16000000", "7. dividend amount": "1.6600"}}
+			}`,
+			wantKey:  "Weekly Adjusted Time Series",
+			wantMeta: TimeSeriesMetaData{Information: "Weekly Adjusted Prices and Volumes", Symbol: "IBM", LastRefreshed: "2024-03-01", TimeZone: "US/Eastern"},
+			wantData: map[string]TimeSeriesData{"2024-03-01": {Open: "187", High: "188", Low: "183", Close: "185", AdjustedClose: "184", Volume: "16000000", DividendAmount: "1.6600"}},
+		},
+		{
+			name: "monthly",
+			body: `{
+				"Meta Data": {"1. Information": "Monthly Prices (open, high, low, close) and Volumes", "2. Symbol": "IBM", "3. Last Refreshed": "2024-03-01", "4. Time Zone": "US/Eastern"},
+				"Monthly Time Series": {"2024-02-29": {"1. open": "183", "2. high": "189", "3. low": "178", "4. close": "185", "5. volume": "90000000"}}
+			}`,
+			wantKey:  "Monthly Time Series",
+			wantMeta: TimeSeriesMetaData{Information: "Monthly Prices (open, high, low, close) and Volumes", Symbol: "IBM", LastRefreshed: "2024-03-01", TimeZone: "US/Eastern"},
+			wantData: map[string]TimeSeriesData{"2024-02-29": {Open: "183", High: "189", Low: "178", Close: "185", Volume: "90000000"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp TimeSeriesResponse
+			if err := json.Unmarshal([]byte(tt.body), &resp); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if resp.SeriesKey != tt.wantKey {
+				t.Errorf("SeriesKey = %q, want %q", resp.SeriesKey, tt.wantKey)
+			}
+			if resp.MetaData != tt.wantMeta {
+				t.Errorf("MetaData = %+v, want %+v", resp.MetaData, tt.wantMeta)
+			}
+			if !reflect.DeepEqual(resp.TimeSeries, tt.wantData) {
+				t.Errorf("TimeSeries = %+v, want %+v", resp.TimeSeries, tt.wantData)
+			}
+
+			// Encoding keeps the upstream key and numbering, so it decodes to the same response
+			data, err := json.Marshal(resp)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if !strings.Contains(string(data), `"`+tt.wantKey+`"`) {
+				t.Errorf("Marshal() = %s, want the series under %q", data, tt.wantKey)
+			}
+			var again TimeSeriesResponse
+			if err := json.Unmarshal(data, &again); err != nil {
+				t.Fatalf("Unmarshal(Marshal()) error = %v", err)
+			}
+			if !reflect.DeepEqual(again, resp) {
+				t.Errorf("Unmarshal(Marshal()) = %+v, want %+v", again, resp)
+			}
+		})
+	}
+}
+
+func TestTimeSeriesDataMarshalLayouts(t *testing.T) {
+	tests := []struct {
+		name string
+		data TimeSeriesData
+		want string
+	}{
+		{"plain", TimeSeriesData{Open: "1", High: "2", Low: "0", Close: "1.5", Volume: "10"},
+			`{"1. open":"1","2. high":"2","3. low":"0","4. close":"1.5","5. volume":"10"}`},
+		{"weekly adjusted", TimeSeriesData{Open: "1", High: "2", Low: "0", Close: "1.5", AdjustedClose: "1.4", Volume: "10", DividendAmount: "0.1"},
+			`{"1. open":"1","2. high":"2","3. low":"0","4. close":"1.5","5. adjusted close":"1.4","6. volume":"10","7. dividend amount":"0.1"}`},
+		{"daily adjusted", TimeSeriesData{Open: "1", High: "2", Low: "0", Close: "1.5", AdjustedClose: "1.4", Volume: "10", DividendAmount: "0", SplitCoefficient: "2"},
+			`{"1. open":"1","2. high":"2","3. low":"0","4. close":"1.5","5. adjusted close":"1.4","6. volume":"10","7. dividend amount":"0","8. split coefficient":"2"}`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.data)
+		if err != nil {
+			t.Fatalf("%s: Marshal() error = %v", tt.name, err)
+		}
+		if string(data) != tt.want {
+			t.Errorf("%s: Marshal() = %s, want %s", tt.name, data, tt.want)
+		}
+	}
+}
+
+func TestTimeSeriesResponseWithoutSeries(t *testing.T) {
+	var resp TimeSeriesResponse
+	if err := json.Unmarshal([]byte(`{"Meta Data": {"2. Symbol": "IBM"}}`), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if resp.SeriesKey != "" || len(resp.TimeSeries) != 0 || resp.MetaData.Symbol != "IBM" {
+		t.Errorf("Unmarshal() = %+v, want only the metadata", resp)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Time Series (Daily)": {"2024-03-01": [1, 2]}}`), &resp); err == nil {
+		t.Error("Unmarshal() of a malformed data point error = nil, want an error")
+	}
+}
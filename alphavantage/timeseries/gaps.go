@@ -0,0 +1,30 @@
+package timeseries
+
+import (
+	"time"
+
+	"stock/alphavantage/market"
+	"stock/common"
+)
+
+// MissingSessions returns the trading days between the first and last bar
+// that have no bar at all. Bars must be in chronological order and be daily
+// or intraday, since weekly and monthly bars skip sessions by design.
+func MissingSessions(bars []Bar, cal *market.Calendar) []time.Time {
+	if len(bars) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(bars))
+	for _, bar := range bars {
+		seen[bar.Time.Format(common.DateLayout)] = true
+	}
+
+	var missing []time.Time
+	for _, session := range cal.SessionsBetween(bars[0].Time, bars[len(bars)-1].Time) {
+		if !seen[session.Date.Format(common.DateLayout)] {
+			missing = append(missing, session.Date)
+		}
+	}
+	return missing
+}
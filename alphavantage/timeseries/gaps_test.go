@@ -0,0 +1,54 @@
+package timeseries
+
+import (
+	"testing"
+	"time"
+
+	"stock/alphavantage/market"
+	"stock/common"
+)
+
+func TestMissingSessions(t *testing.T) {
+	// Days past the 30th roll over into December
+	day := func(d int, hour, minute int) Bar {
+		return Bar{Time: time.Date(2024, time.November, d, hour, minute, 0, 0, newYork)}
+	}
+
+	tests := []struct {
+		name string
+		bars []Bar
+		want []string
+	}{
+		{
+			// Thanksgiving on the 28th, an early close on the 29th and a weekend, the 27th has no bar
+			name: "daily",
+			bars: []Bar{day(25, 0, 0), day(26, 0, 0), day(29, 0, 0), day(32, 0, 0), day(33, 0, 0)},
+			want: []string{"2024-11-27"},
+		},
+		{
+			// The early close's last bar is at 12:55
+			name: "intraday",
+			bars: []Bar{day(26, 9, 30), day(26, 15, 55), day(29, 9, 30), day(29, 12, 55), day(32, 9, 30)},
+			want: []string{"2024-11-27"},
+		},
+		{
+			name: "no gaps",
+			bars: []Bar{day(26, 0, 0), day(27, 0, 0), day(29, 0, 0), day(32, 0, 0)},
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tt := range tests {
+		got := MissingSessions(tt.bars, market.NYSE)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: MissingSessions() = %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range tt.want {
+			if got[i].Format(common.DateLayout) != tt.want[i] {
+				t.Errorf("%s: MissingSessions()[%d] = %s, want %s", tt.name, i, got[i].Format(common.DateLayout), tt.want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package timeseries
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"stock/alphavantage/market"
+)
+
+// Period is a calendar period bars can be resampled to
+type Period int
+
+// Calendar periods, weeks start on Monday
+const (
+	PeriodNone Period = iota
+	PeriodDay
+	PeriodWeek
+	PeriodMonth
+	PeriodQuarter
+	PeriodYear
+)
+
+// periodNames maps the accepted resample values to calendar periods
+var periodNames = map[string]Period{
+	"day": PeriodDay, "1d": PeriodDay, "daily": PeriodDay,
+	"week": PeriodWeek, "1w": PeriodWeek, "weekly": PeriodWeek,
+	"month": PeriodMonth, "1mo": PeriodMonth, "monthly": PeriodMonth,
+	"quarter": PeriodQuarter, "1q": PeriodQuarter, "quarterly": PeriodQuarter,
+	"year": PeriodYear, "1y": PeriodYear, "yearly": PeriodYear,
+}
+
+// Timeframe is a resampling target, either a fixed intraday duration or a calendar period
+type Timeframe struct {
+	Duration time.Duration // Intraday bucket size, buckets are anchored at every session open and close
+	Period   Period
+}
+
+// ParseTimeframe parses values such as "10min", "2h", "4h", "week" or "quarter"
+func ParseTimeframe(value string) (Timeframe, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if period, ok := periodNames[value]; ok {
+		return Timeframe{Period: period}, nil
+	}
+
+	d, err := time.ParseDuration(strings.Replace(value, "min", "m", 1))
+	if err != nil || d <= 0 {
+		return Timeframe{}, fmt.Errorf("invalid timeframe %q, expected a duration such as 10min or 4h, or day, week, month, quarter or year", value)
+	}
+	if d >= 24*time.Hour {
+		return Timeframe{}, fmt.Errorf("timeframe %q spans sessions, use day, week, month, quarter or year", value)
+	}
+	return Timeframe{Duration: d}, nil
+}
+
+// Resample aggregates chronologically ordered bars into the timeframe using
+// the first open, highest high, lowest low, last close and summed volume.
+// Intraday buckets are anchored at the session open of cal, so hourly bars
+// of a 09:30 session start at 09:30, 10:30 and so on. The close starts a new
+// bucket too, cutting the last regular bucket short, so pre-market bars fall
+// into buckets counted back from the open and after-hours bars into buckets
+// counted on from the close. Days cal has no session for, or all days when
+// cal is nil, are bucketed on clock boundaries from midnight. Buckets never
+// span two days. Resampled bars are stamped with their bucket start, for
+// calendar periods the start of the day, Monday, month, quarter or year.
+func Resample(bars []Bar, tf Timeframe, cal *market.Calendar) []Bar {
+	var resampled []Bar
+	var day, current time.Time
+	var session *market.Session
+
+	for _, bar := range bars {
+		var bucket time.Time
+		if tf.Period != PeriodNone {
+			bucket = periodStart(bar.Time, tf.Period)
+		} else {
+			if start := dayStart(bar.Time); !start.Equal(day) {
+				day, session = start, sessionOf(bar.Time, cal)
+			}
+			bucket = intradayBucket(bar.Time, day, session, tf.Duration)
+		}
+
+		if len(resampled) == 0 || !bucket.Equal(current) {
+			current = bucket
+			next := bar
+			next.Time = bucket
+			resampled = append(resampled, next)
+			continue
+		}
+
+		agg := &resampled[len(resampled)-1]
+		if bar.High > agg.High {
+			agg.High = bar.High
+		}
+		if bar.Low < agg.Low {
+			agg.Low = bar.Low
+		}
+		agg.Close = bar.Close
+		agg.AdjustedClose = bar.AdjustedClose
+		agg.Volume += bar.Volume
+	}
+
+	return resampled
+}
+
+// sessionOf returns the session of t's day on cal in t's location, nil when
+// cal is nil or has no session that day
+func sessionOf(t time.Time, cal *market.Calendar) *market.Session {
+	if cal == nil {
+		return nil
+	}
+	session, ok := cal.Session(t)
+	if !ok {
+		return nil
+	}
+	session.Open, session.Close = session.Open.In(t.Location()), session.Close.In(t.Location())
+	return &session
+}
+
+// intradayBucket returns the start of the bucket of size d holding t. Before
+// the close buckets are counted from the session open in both directions,
+// from the close onwards they are counted from the close, and without a
+// session from midnight. Buckets never start before the start of t's day.
+func intradayBucket(t, day time.Time, session *market.Session, d time.Duration) time.Time {
+	anchor := day
+	if session != nil {
+		anchor = session.Open
+		if !t.Before(session.Close) {
+			anchor = session.Close
+		}
+	}
+
+	offset := t.Sub(anchor)
+	n := offset / d
+	if offset < 0 && offset%d != 0 {
+		n-- // Round towards the earlier bucket before the anchor
+	}
+	if bucket := anchor.Add(n * d); bucket.After(day) {
+		return bucket
+	}
+	return day
+}
+
+// dayStart returns midnight of t's day in t's location
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// periodStart returns the start of the calendar period containing t
+func periodStart(t time.Time, period Period) time.Time {
+	day := dayStart(t)
+	switch period {
+	case PeriodWeek:
+		offset := (int(day.Weekday()) + 6) % 7 // Days since Monday
+		return day.AddDate(0, 0, -offset)
+	case PeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	case PeriodQuarter:
+		month := time.Month((int(t.Month())-1)/3*3 + 1)
+		return time.Date(t.Year(), month, 1, 0, 0, 0, 0, t.Location())
+	case PeriodYear:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	}
+	return day
+}
@@ -0,0 +1,188 @@
+package timeseries
+
+import (
+	"testing"
+	"time"
+
+	"stock/alphavantage/market"
+)
+
+var newYork, _ = time.LoadLocation("America/New_York")
+
+// minuteBars returns one bar per step from start through end inclusive, closing at their index
+func minuteBars(start, end time.Time, step time.Duration) []Bar {
+	var bars []Bar
+	for t := start; !t.After(end); t = t.Add(step) {
+		n := float64(len(bars))
+		bars = append(bars, Bar{Time: t, Open: n, High: n, Low: n, Close: n, Volume: 1})
+	}
+	return bars
+}
+
+func stamps(bars []Bar) []string {
+	out := make([]string, len(bars))
+	for i, bar := range bars {
+		out[i] = bar.Time.Format("01-02 15:04")
+	}
+	return out
+}
+
+func TestResampleAnchorsAtSessionOpen(t *testing.T) {
+	// The first bar of the day is pre-market, 08:05
+	day := time.Date(2024, time.March, 1, 0, 0, 0, 0, newYork)
+	bars := minuteBars(day.Add(8*time.Hour+5*time.Minute), day.Add(11*time.Hour+55*time.Minute), 5*time.Minute)
+
+	got := Resample(bars, Timeframe{Duration: time.Hour}, market.NYSE)
+	want := []string{"03-01 07:30", "03-01 08:30", "03-01 09:30", "03-01 10:30", "03-01 11:30"}
+	if len(got) != len(want) {
+		t.Fatalf("Resample() = %v, want %v", stamps(got), want)
+	}
+	for i := range want {
+		if stamps(got)[i] != want[i] {
+			t.Fatalf("Resample() = %v, want %v", stamps(got), want)
+		}
+	}
+
+	// 08:05 to 08:25 are the five pre-market bars before 08:30
+	if first := got[0]; first.Volume != 5 || first.Open != 0 || first.Close != 4 {
+		t.Errorf("first bucket = %+v, want bars 0 to 4", first)
+	}
+	if regular := got[2]; regular.Volume != 12 || regular.Open != 17 || regular.High != 28 || regular.Close != 28 {
+		t.Errorf("09:30 bucket = %+v, want bars 17 to 28", regular)
+	}
+}
+
+func TestResampleCutsAtSessionClose(t *testing.T) {
+	// Extended hours bars every 30 minutes from 14:00 through 18:30
+	day := time.Date(2024, time.March, 1, 0, 0, 0, 0, newYork)
+	bars := minuteBars(day.Add(14*time.Hour), day.Add(18*time.Hour+30*time.Minute), 30*time.Minute)
+
+	tests := []struct {
+		name    string
+		d       time.Duration
+		want    []string
+		volumes []float64
+	}{
+		// 15:30 holds only the 15:30 bar, 16:00 and 16:30 are after hours
+		{"hourly", time.Hour, []string{"03-01 13:30", "03-01 14:30", "03-01 15:30", "03-01 16:00", "03-01 17:00", "03-01 18:00"}, []float64{1, 2, 1, 2, 2, 2}},
+		// 13:30 to 16:00 is cut short at the close instead of running to 17:30
+		{"four hours", 4 * time.Hour, []string{"03-01 13:30", "03-01 16:00"}, []float64{4, 6}},
+	}
+	for _, tt := range tests {
+		got := Resample(bars, Timeframe{Duration: tt.d}, market.NYSE)
+		if !equalStrings(stamps(got), tt.want) {
+			t.Errorf("%s: Resample() = %v, want %v", tt.name, stamps(got), tt.want)
+			continue
+		}
+		for i, bar := range got {
+			if bar.Volume != tt.volumes[i] {
+				t.Errorf("%s: bucket %s volume = %v, want %v", tt.name, stamps(got)[i], bar.Volume, tt.volumes[i])
+			}
+		}
+	}
+
+	// The last regular bar closes the regular bucket, the first after-hours bar opens the next
+	got := Resample(bars, Timeframe{Duration: 4 * time.Hour}, market.NYSE)
+	if got[0].Close != 3 || got[1].Open != 4 {
+		t.Errorf("buckets = %+v, want 13:30 closing on the 15:30 bar and 16:00 opening on the 16:00 bar", got)
+	}
+}
+
+func TestResampleEarlyClose(t *testing.T) {
+	// The day after Thanksgiving 2024 closes at 13:00
+	day := time.Date(2024, time.November, 29, 0, 0, 0, 0, newYork)
+	bars := minuteBars(day.Add(11*time.Hour), day.Add(14*time.Hour+45*time.Minute), 15*time.Minute)
+
+	got := Resample(bars, Timeframe{Duration: time.Hour}, market.NYSE)
+	want := []string{"11-29 10:30", "11-29 11:30", "11-29 12:30", "11-29 13:00", "11-29 14:00"}
+	if !equalStrings(stamps(got), want) {
+		t.Fatalf("Resample() = %v, want %v", stamps(got), want)
+	}
+	// 12:30 and 12:45 before the close, 13:00 to 13:45 after it
+	if got[2].Volume != 2 || got[3].Volume != 4 {
+		t.Errorf("12:30 and 13:00 buckets hold %v and %v bars, want 2 and 4", got[2].Volume, got[3].Volume)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestResampleClockBoundaries(t *testing.T) {
+	// Saturday has no session, nil calendars never have one
+	saturday := time.Date(2024, time.March, 2, 9, 40, 0, 0, newYork)
+	bars := minuteBars(saturday, saturday.Add(50*time.Minute), 10*time.Minute)
+
+	for _, cal := range []*market.Calendar{market.NYSE, nil} {
+		got := stamps(Resample(bars, Timeframe{Duration: 30 * time.Minute}, cal))
+		if len(got) != 3 || got[0] != "03-02 09:30" || got[1] != "03-02 10:00" || got[2] != "03-02 10:30" {
+			t.Errorf("Resample() = %v, want 09:30, 10:00 and 10:30", got)
+		}
+	}
+}
+
+func TestResampleNeverSpansDays(t *testing.T) {
+	thursday := time.Date(2024, time.February, 29, 19, 0, 0, 0, newYork)
+	bars := append(
+		minuteBars(thursday, thursday.Add(55*time.Minute), 5*time.Minute),
+		minuteBars(thursday.Add(9*time.Hour), thursday.Add(9*time.Hour+25*time.Minute), 5*time.Minute)...,
+	)
+
+	// 12 hour buckets from the 16:00 close would take in Friday's pre-market bars
+	got := stamps(Resample(bars, Timeframe{Duration: 12 * time.Hour}, market.NYSE))
+	if len(got) != 2 || got[0] != "02-29 16:00" || got[1] != "03-01 00:00" {
+		t.Errorf("Resample() = %v, want one bucket per day", got)
+	}
+}
+
+func TestResamplePeriods(t *testing.T) {
+	var bars []Bar
+	for d := 0; d < 70; d++ {
+		bars = append(bars, Bar{Time: time.Date(2024, time.March, 1+d, 0, 0, 0, 0, newYork), High: 1, Low: 1, Volume: 1})
+	}
+
+	tests := []struct {
+		period Period
+		count  int
+		first  string
+	}{
+		{PeriodWeek, 11, "02-26 00:00"}, // Friday March 1 belongs to the week of Monday February 26
+		{PeriodMonth, 3, "03-01 00:00"},
+		{PeriodQuarter, 2, "01-01 00:00"},
+		{PeriodYear, 1, "01-01 00:00"},
+	}
+	for _, tt := range tests {
+		got := stamps(Resample(bars, Timeframe{Period: tt.period}, nil))
+		if len(got) != tt.count || got[0] != tt.first {
+			t.Errorf("period %d: %d buckets from %s, want %d from %s", tt.period, len(got), got[0], tt.count, tt.first)
+		}
+	}
+}
+
+func TestParseTimeframe(t *testing.T) {
+	valid := map[string]Timeframe{
+		"10min":   {Duration: 10 * time.Minute},
+		"4h":      {Duration: 4 * time.Hour},
+		"Week":    {Period: PeriodWeek},
+		"1q":      {Period: PeriodQuarter},
+		" daily ": {Period: PeriodDay},
+	}
+	for value, want := range valid {
+		if got, err := ParseTimeframe(value); err != nil || got != want {
+			t.Errorf("ParseTimeframe(%q) = %+v, %v, want %+v", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "0min", "-1h", "24h", "fortnight"} {
+		if _, err := ParseTimeframe(value); err == nil {
+			t.Errorf("ParseTimeframe(%q) succeeded", value)
+		}
+	}
+}
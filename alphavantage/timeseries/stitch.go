@@ -0,0 +1,141 @@
+package timeseries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"stock/common"
+)
+
+// monthLayout is the layout of the intraday month parameter
+const monthLayout = "2006-01"
+
+// Limits for stitching intraday history
+const (
+	maxStitchMonths   = 300 // 25 years of monthly requests
+	stitchConcurrency = 4   // Monthly requests in flight at once, the rate limiter still applies
+)
+
+// MissingMonth is a month whose intraday bars could not be fetched
+type MissingMonth struct {
+	Month string `json:"month"`
+	Error string `json:"error"`
+}
+
+// StitchedSeries is an intraday series assembled from one request per month
+type StitchedSeries struct {
+	Bars          []Bar
+	MissingMonths []MissingMonth
+}
+
+// Months returns every YYYY-MM month from from through to inclusive
+func Months(from, to string) ([]string, error) {
+	start, err := time.Parse(monthLayout, from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month %q, expected YYYY-MM", from)
+	}
+	end, err := time.Parse(monthLayout, to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month %q, expected YYYY-MM", to)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("month range %s to %s is reversed", from, to)
+	}
+
+	var months []string
+	for m := start; !m.After(end); m = m.AddDate(0, 1, 0) {
+		months = append(months, m.Format(monthLayout))
+		if len(months) > maxStitchMonths {
+			return nil, fmt.Errorf("month range %s to %s exceeds %d months", from, to, maxStitchMonths)
+		}
+	}
+	return months, nil
+}
+
+// GetIntradayHistory fetches TIME_SERIES_INTRADAY once per month from from
+// through to (YYYY-MM) and merges the results into one sorted series without
+// duplicates. Months that fail are reported instead of failing the whole
+// series, unless every month fails. Once a call is rate limited the months
+// still waiting are not fetched.
+func GetIntradayHistory(ctx context.Context, client *common.Client, params TimeSeriesParams, from, to string) (*StitchedSeries, error) {
+	months, err := Months(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", common.ErrInvalidParameter, err)
+	}
+
+	params.Function = "TIME_SERIES_INTRADAY"
+	params.OutputSize = "full"
+
+	type result struct {
+		bars []Bar
+		err  error
+	}
+	results := make([]result, len(months))
+
+	var mu sync.Mutex
+	var limited error
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, stitchConcurrency)
+	for i, month := range months {
+		wg.Add(1)
+		go func(i int, month string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			mu.Lock()
+			skip := limited
+			mu.Unlock()
+			if skip != nil {
+				results[i].err = fmt.Errorf("not fetched: %w", skip)
+				return
+			}
+
+			monthParams := params
+			monthParams.Month = month
+			results[i].bars, results[i].err = GetTimeSeries(ctx, client, monthParams)
+			if errors.Is(results[i].err, common.ErrRateLimited) {
+				mu.Lock()
+				if limited == nil {
+					limited = results[i].err
+				}
+				mu.Unlock()
+			}
+		}(i, month)
+	}
+	wg.Wait()
+
+	stitched := &StitchedSeries{}
+	seen := make(map[int64]bool)
+	var errs []error
+	for i, res := range results {
+		if res.err != nil {
+			errs = append(errs, res.err)
+			stitched.MissingMonths = append(stitched.MissingMonths, MissingMonth{
+				Month: months[i],
+				Error: res.err.Error(),
+			})
+			continue
+		}
+		for _, bar := range res.bars {
+			if key := bar.Time.UnixNano(); !seen[key] {
+				seen[key] = true
+				stitched.Bars = append(stitched.Bars, bar)
+			}
+		}
+	}
+
+	if len(errs) == len(months) {
+		return nil, fmt.Errorf("no month could be fetched: %w", errs[0])
+	}
+
+	sort.Slice(stitched.Bars, func(i, j int) bool {
+		return stitched.Bars[i].Time.Before(stitched.Bars[j].Time)
+	})
+	return stitched, nil
+}
@@ -0,0 +1,137 @@
+package timeseries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"stock/common"
+	"stock/common/commontest"
+)
+
+func TestMonths(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     []string
+		wantErr  bool
+	}{
+		{"2024-01", "2024-01", []string{"2024-01"}, false},
+		{"2023-11", "2024-02", []string{"2023-11", "2023-12", "2024-01", "2024-02"}, false},
+		{"2000-01", "2024-12", nil, false}, // Exactly maxStitchMonths
+		{"1999-12", "2024-12", nil, true},  // One month too many
+		{"2024-03", "2024-01", nil, true},
+		{"2024-13", "2024-12", nil, true},
+		{"2024-01-01", "2024-02", nil, true},
+		{"2024-01", "", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := Months(tt.from, tt.to)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Months(%s, %s) error = %v, wantErr %v", tt.from, tt.to, err, tt.wantErr)
+			continue
+		}
+		if tt.want != nil && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Months(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+		if tt.from == "2000-01" && len(got) != maxStitchMonths {
+			t.Errorf("Months(%s, %s) returned %d months, want %d", tt.from, tt.to, len(got), maxStitchMonths)
+		}
+	}
+}
+
+// intradayBody returns an intraday response holding one bar per timestamp
+func intradayBody(timestamps ...string) string {
+	points := make([]string, len(timestamps))
+	for i, timestamp := range timestamps {
+		points[i] = fmt.Sprintf(`%q: {"1. open": "1", "2. high": "1", "3. low": "1", "4. close": "1", "5. volume": "%d"}`, timestamp, i+1)
+	}
+	return `{"Meta Data": {"2. Symbol": "IBM", "6. Time Zone": "US/Eastern"}, "Time Series (60min)": {` + strings.Join(points, ",") + `}}`
+}
+
+func TestGetIntradayHistoryMergesMonths(t *testing.T) {
+	bodies := map[string]string{
+		// Served newest first, like upstream, and overlapping at the month boundary
+		"2024-01": intradayBody("2024-01-31 16:00:00", "2024-01-31 15:00:00", "2024-01-02 10:00:00"),
+		"2024-02": intradayBody("2024-02-01 10:00:00", "2024-01-31 16:00:00"),
+		"2024-04": intradayBody("2024-04-01 10:00:00"),
+	}
+	client := commontest.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("function") != "TIME_SERIES_INTRADAY" || query.Get("outputsize") != "full" {
+			t.Errorf("query = %v, want full intraday", query)
+		}
+		body, ok := bodies[query.Get("month")]
+		if !ok {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, body)
+	})
+
+	series, err := GetIntradayHistory(context.Background(), client, TimeSeriesParams{Symbol: "IBM", Interval: "60min"}, "2024-01", "2024-04")
+	if err != nil {
+		t.Fatalf("GetIntradayHistory() error = %v", err)
+	}
+
+	want := []string{"01-02 10:00", "01-31 15:00", "01-31 16:00", "02-01 10:00", "04-01 10:00"}
+	if got := stamps(series.Bars); !reflect.DeepEqual(got, want) {
+		t.Errorf("Bars = %v, want %v", got, want)
+	}
+	if len(series.MissingMonths) != 1 || series.MissingMonths[0].Month != "2024-03" || series.MissingMonths[0].Error == "" {
+		t.Errorf("MissingMonths = %+v, want 2024-03 with its error", series.MissingMonths)
+	}
+}
+
+func TestGetIntradayHistoryFailsWhenEveryMonthFails(t *testing.T) {
+	client := commontest.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Error Message": "Invalid API call. Please retry or visit the documentation."}`)
+	})
+
+	_, err := GetIntradayHistory(context.Background(), client, TimeSeriesParams{Symbol: "NOPE", Interval: "60min"}, "2024-01", "2024-03")
+	if !errors.Is(err, common.ErrInvalidSymbol) {
+		t.Fatalf("GetIntradayHistory() error = %v, want ErrInvalidSymbol", err)
+	}
+
+	_, err = GetIntradayHistory(context.Background(), client, TimeSeriesParams{Symbol: "IBM", Interval: "60min"}, "2024-03", "2024-01")
+	if !errors.Is(err, common.ErrInvalidParameter) {
+		t.Fatalf("GetIntradayHistory() of a reversed range error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestGetIntradayHistoryStopsAfterRateLimit(t *testing.T) {
+	var calls atomic.Int64
+	client := commontest.NewClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			fmt.Fprint(w, intradayBody(r.URL.Query().Get("month")+"-02 10:00:00"))
+			return
+		}
+		fmt.Fprint(w, `{"Information": "You have reached the rate limit of 25 requests per day."}`)
+	})
+
+	series, err := GetIntradayHistory(context.Background(), client, TimeSeriesParams{Symbol: "IBM", Interval: "60min"}, "2019-01", "2024-06")
+	if err != nil {
+		t.Fatalf("GetIntradayHistory() error = %v", err)
+	}
+
+	// Months already in flight when the limit is hit still finish, no others start
+	if got := calls.Load(); got > stitchConcurrency+1 {
+		t.Errorf("upstream calls = %d, want at most %d", got, stitchConcurrency+1)
+	}
+	if len(series.Bars) != 1 || len(series.MissingMonths) != 65 {
+		t.Fatalf("got %d bars and %d missing months, want 1 and 65", len(series.Bars), len(series.MissingMonths))
+	}
+	skipped := 0
+	for _, missing := range series.MissingMonths {
+		if strings.HasPrefix(missing.Error, "not fetched: ") {
+			skipped++
+		}
+	}
+	if want := 66 - int(calls.Load()); skipped != want {
+		t.Errorf("%d months reported as not fetched, want %d", skipped, want)
+	}
+}
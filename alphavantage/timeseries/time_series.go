@@ -1,9 +1,10 @@
 package timeseries
 
 import (
-	"encoding/json"
+	"context"
+	"strings"
+
 	"stock/common"
-	"stock/config"
 )
 
 /*
@@ -18,14 +19,14 @@ import (
 	by API / CSV
 	---------------
 	interval (minutes)
-	daily
+	daily, daily adjusted
 	weekly, weekly adjusted
 	monthly, monthly adjusted
 */
 
 // TimeSeriesParams holds parameters for retrieving time series data
 type TimeSeriesParams struct {
-	Function      string // TIME_SERIES_INTRADAY, TIME_SERIES_DAILY, TIME_SERIES_WEEKLY, TIME_SERIES_MONTHLY
+	Function      string // TIME_SERIES_INTRADAY, TIME_SERIES_DAILY, TIME_SERIES_WEEKLY, TIME_SERIES_MONTHLY and their _ADJUSTED variants
 	Symbol        string
 	Interval      string // Required for INTRADAY: 1min, 5min, 15min, 30min, 60min
 	OutputSize    string // compact or full
@@ -35,44 +36,54 @@ type TimeSeriesParams struct {
 	Month         string // For intraday historical data in YYYY-MM format
 }
 
-// TimeSeriesMetaData represents the Meta Data field in the API response
+// TimeSeriesMetaData represents the Meta Data field in the API response.
+// Upstream numbering differs per function, so fields are decoded by label.
 type TimeSeriesMetaData struct {
 	Information   string `json:"1. Information"`
 	Symbol        string `json:"2. Symbol"`
 	LastRefreshed string `json:"3. Last Refreshed"`
-	Interval      string `json:"4. Interval"`
-	OutputSize    string `json:"5. Output Size"`
+	Interval      string `json:"4. Interval,omitempty"`
+	OutputSize    string `json:"5. Output Size,omitempty"`
 	TimeZone      string `json:"6. Time Zone"`
 }
 
-// TimeSeriesData represents the data point structure for each timestamp
+// TimeSeriesData represents the data point structure for each timestamp.
+// The adjusted fields are only set by the *_ADJUSTED functions.
 type TimeSeriesData struct {
-	Open   string `json:"1. open"`
-	High   string `json:"2. high"`
-	Low    string `json:"3. low"`
-	Close  string `json:"4. close"`
-	Volume string `json:"5. volume"`
+	Open             string
+	High             string
+	Low              string
+	Close            string
+	AdjustedClose    string
+	Volume           string
+	DividendAmount   string
+	SplitCoefficient string
 }
 
-// TimeSeriesResponse represents the complete API response structure
+// TimeSeriesResponse represents the complete API response structure. Whichever
+// upstream key carried the data points ("Time Series (15min)", "Weekly Adjusted
+// Time Series", ...) they are exposed as one normalized series.
 type TimeSeriesResponse struct {
-	MetaData    TimeSeriesMetaData        `json:"Meta Data"`
-	TimeSeries  map[string]TimeSeriesData `json:"Time Series (5min),omitempty"`
-	DailyData   map[string]TimeSeriesData `json:"Time Series (Daily),omitempty"`
-	WeeklyData  map[string]TimeSeriesData `json:"Weekly Time Series,omitempty"`
-	MonthlyData map[string]TimeSeriesData `json:"Monthly Time Series,omitempty"`
+	MetaData   TimeSeriesMetaData
+	SeriesKey  string                    // Upstream key the series was read from
+	TimeSeries map[string]TimeSeriesData // Data points keyed by timestamp
 }
 
-// GetTimeSeries fetches time series data from Alpha Vantage API
-func GetTimeSeries(params TimeSeriesParams) (*TimeSeriesResponse, error) {
-	// Get API configuration
-	cfg := config.GetConfig()
+// GetTimeSeries fetches time series data from Alpha Vantage API as bars in chronological order
+func GetTimeSeries(ctx context.Context, client *common.Client, params TimeSeriesParams) ([]Bar, error) {
+	resp, err := GetRawTimeSeries(ctx, client, params)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Bars()
+}
 
+// GetRawTimeSeries fetches time series data from Alpha Vantage API in the upstream shape
+func GetRawTimeSeries(ctx context.Context, client *common.Client, params TimeSeriesParams) (*TimeSeriesResponse, error) {
 	// Building query parameters
 	queryParams := map[string]string{
 		"function": params.Function,
 		"symbol":   params.Symbol,
-		"apikey":   cfg.AlphaVantageAPIKey,
 	}
 
 	// Add optional parameters if provided
@@ -105,15 +116,18 @@ func GetTimeSeries(params TimeSeriesParams) (*TimeSeriesResponse, error) {
 		}
 	}
 
-	// Make HTTP request and parse response
-	respBody, err := common.GetAPIRequest(cfg.AlphaVantageBaseURL, queryParams)
-	if err != nil {
-		return nil, err
+	// CSV bodies are normalized into the same structure as JSON ones
+	if strings.EqualFold(params.DataType, "csv") {
+		records, err := client.GetCSV(ctx, queryParams)
+		if err != nil {
+			return nil, err
+		}
+		return fromCSV(records, params)
 	}
-	defer respBody.Close()
 
+	// Make HTTP request and parse response
 	result := &TimeSeriesResponse{}
-	if err := json.NewDecoder(respBody).Decode(result); err != nil {
+	if err := client.GetJSON(ctx, queryParams, result); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,130 @@
+package timeseries
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Window selects a page of bars by time range
+type Window struct {
+	From   time.Time // Inclusive lower bound, zero for unbounded
+	To     time.Time // Inclusive upper bound, zero for unbounded
+	Limit  int       // Maximum bars per page, zero for all
+	Cursor string    // Cursor returned with the previous page
+}
+
+// Page is one page of a windowed bar series
+type Page struct {
+	Bars       []Bar
+	NextCursor string // Empty on the last page
+}
+
+// IsZero reports whether the window selects the whole series
+func (w Window) IsZero() bool {
+	return w.From.IsZero() && w.To.IsZero() && w.Limit == 0 && w.Cursor == ""
+}
+
+// Paginate returns the page of chronologically ordered bars selected by w
+func Paginate(bars []Bar, w Window) (Page, error) {
+	start := w.From
+	if w.Cursor != "" {
+		cursor, err := decodeCursor(w.Cursor)
+		if err != nil {
+			return Page{}, err
+		}
+		if cursor.After(start) {
+			start = cursor
+		}
+	}
+
+	lo := sort.Search(len(bars), func(i int) bool {
+		return !bars[i].Time.Before(start)
+	})
+	hi := len(bars)
+	if !w.To.IsZero() {
+		hi = sort.Search(len(bars), func(i int) bool {
+			return bars[i].Time.After(w.To)
+		})
+	}
+	if lo > hi {
+		lo = hi
+	}
+
+	page := Page{Bars: bars[lo:hi]}
+	if w.Limit > 0 && len(page.Bars) > w.Limit {
+		page.NextCursor = encodeCursor(page.Bars[w.Limit].Time)
+		page.Bars = page.Bars[:w.Limit]
+	}
+	return page, nil
+}
+
+// encodeCursor returns an opaque cursor pointing at the bar starting at t
+func encodeCursor(t time.Time) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(t.Format(time.RFC3339Nano)))
+}
+
+// decodeCursor returns the bar time a cursor points at
+func decodeCursor(cursor string) (time.Time, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, string(raw))
+	if err != nil {
+		return time.Time{}, ErrInvalidCursor
+	}
+	return t, nil
+}
+
+// ParseTime parses a window bound given as a date, a series timestamp or
+// RFC 3339 in the exchange time zone. Date-only upper bounds cover the whole day.
+func ParseTime(value string, loc *time.Location, upper bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04:05", value, loc); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		if upper {
+			t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected YYYY-MM-DD, YYYY-MM-DD HH:MM:SS or RFC 3339", value)
+}
+
+// Subset returns a copy of the response holding only the data points of bars
+func (r *TimeSeriesResponse) Subset(bars []Bar) (*TimeSeriesResponse, error) {
+	loc, err := r.MetaData.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make(map[int64]bool, len(bars))
+	for _, bar := range bars {
+		keep[bar.Time.UnixNano()] = true
+	}
+
+	subset := &TimeSeriesResponse{
+		MetaData:   r.MetaData,
+		SeriesKey:  r.SeriesKey,
+		TimeSeries: make(map[string]TimeSeriesData, len(bars)),
+	}
+	for timestamp, data := range r.TimeSeries {
+		t, err := parseTimestamp(timestamp, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", timestamp, err)
+		}
+		if keep[t.UnixNano()] {
+			subset.TimeSeries[timestamp] = data
+		}
+	}
+	return subset, nil
+}
@@ -0,0 +1,161 @@
+package timeseries
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPaginate(t *testing.T) {
+	day := time.Date(2024, time.March, 1, 9, 30, 0, 0, newYork)
+	bars := minuteBars(day, day.Add(9*time.Minute), time.Minute) // 09:30 through 09:39
+	at := func(minute int) time.Time { return day.Add(time.Duration(minute) * time.Minute) }
+
+	tests := []struct {
+		name       string
+		window     Window
+		want       []int // Minutes after 09:30 of the returned bars
+		wantCursor int   // Minute the next cursor points at, -1 for none
+	}{
+		{"everything", Window{}, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, -1},
+		{"from", Window{From: at(7)}, []int{7, 8, 9}, -1},
+		{"to", Window{To: at(2)}, []int{0, 1, 2}, -1},
+		{"from and to", Window{From: at(3), To: at(5)}, []int{3, 4, 5}, -1},
+		{"between bars", Window{From: at(3).Add(30 * time.Second), To: at(5).Add(30 * time.Second)}, []int{4, 5}, -1},
+		{"before the series", Window{To: day.Add(-time.Hour)}, nil, -1},
+		{"after the series", Window{From: at(20)}, nil, -1},
+		{"inverted", Window{From: at(6), To: at(4)}, nil, -1},
+		{"limit", Window{Limit: 4}, []int{0, 1, 2, 3}, 4},
+		{"limit covers the rest", Window{From: at(6), Limit: 4}, []int{6, 7, 8, 9}, -1},
+		{"limit within range", Window{From: at(2), To: at(6), Limit: 2}, []int{2, 3}, 4},
+		{"cursor", Window{Limit: 4, Cursor: encodeCursor(at(4))}, []int{4, 5, 6, 7}, 8},
+		{"cursor last page", Window{Limit: 4, Cursor: encodeCursor(at(8))}, []int{8, 9}, -1},
+		{"cursor before from", Window{From: at(5), Cursor: encodeCursor(at(1))}, []int{5, 6, 7, 8, 9}, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := Paginate(bars, tt.window)
+			if err != nil {
+				t.Fatalf("Paginate() error = %v", err)
+			}
+			if len(page.Bars) != len(tt.want) {
+				t.Fatalf("Paginate() = %v, want minutes %v", stamps(page.Bars), tt.want)
+			}
+			for i, minute := range tt.want {
+				if !page.Bars[i].Time.Equal(at(minute)) {
+					t.Errorf("bar %d = %s, want %s", i, page.Bars[i].Time, at(minute))
+				}
+			}
+
+			switch {
+			case tt.wantCursor < 0 && page.NextCursor != "":
+				t.Errorf("NextCursor = %q, want none", page.NextCursor)
+			case tt.wantCursor >= 0:
+				next, err := decodeCursor(page.NextCursor)
+				if err != nil || !next.Equal(at(tt.wantCursor)) {
+					t.Errorf("NextCursor points at %s (%v), want %s", next, err, at(tt.wantCursor))
+				}
+			}
+		})
+	}
+}
+
+func TestPaginateWalksEveryPage(t *testing.T) {
+	day := time.Date(2024, time.March, 1, 9, 30, 0, 0, newYork)
+	bars := minuteBars(day, day.Add(24*time.Minute), time.Minute)
+
+	var seen []Bar
+	window := Window{Limit: 7}
+	for pages := 0; ; pages++ {
+		if pages > len(bars) {
+			t.Fatal("pagination did not terminate")
+		}
+		page, err := Paginate(bars, window)
+		if err != nil {
+			t.Fatalf("Paginate() error = %v", err)
+		}
+		seen = append(seen, page.Bars...)
+		if page.NextCursor == "" {
+			break
+		}
+		window.Cursor = page.NextCursor
+	}
+
+	if len(seen) != len(bars) {
+		t.Fatalf("pages held %d bars, want %d", len(seen), len(bars))
+	}
+	for i := range bars {
+		if !seen[i].Time.Equal(bars[i].Time) {
+			t.Errorf("bar %d = %s, want %s", i, seen[i].Time, bars[i].Time)
+		}
+	}
+}
+
+func TestPaginateInvalidCursor(t *testing.T) {
+	bars := minuteBars(time.Date(2024, time.March, 1, 9, 30, 0, 0, newYork), time.Date(2024, time.March, 1, 9, 35, 0, 0, newYork), time.Minute)
+
+	for _, cursor := range []string{"not base64!", encodeCursor(time.Time{})[:3], "bm90IGEgdGltZQ"} { // The last one is "not a time"
+		if _, err := Paginate(bars, Window{Cursor: cursor}); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Paginate(cursor %q) error = %v, want ErrInvalidCursor", cursor, err)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		value   string
+		upper   bool
+		want    time.Time
+		wantErr bool
+	}{
+		{"2024-03-01", false, time.Date(2024, time.March, 1, 0, 0, 0, 0, newYork), false},
+		{"2024-03-01", true, time.Date(2024, time.March, 2, 0, 0, 0, 0, newYork).Add(-time.Nanosecond), false},
+		{"2024-03-01 09:30:00", true, time.Date(2024, time.March, 1, 9, 30, 0, 0, newYork), false},
+		{"2024-03-01T14:30:00Z", false, time.Date(2024, time.March, 1, 14, 30, 0, 0, time.UTC), false},
+		{"2024-03-01T09:30:00-05:00", false, time.Date(2024, time.March, 1, 9, 30, 0, 0, newYork), false},
+		{"03/01/2024", false, time.Time{}, true},
+		{"", false, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.value, newYork, tt.upper)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTime(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseTime(%q, upper %v) = %s, want %s", tt.value, tt.upper, got, tt.want)
+		}
+	}
+}
+
+func TestSubset(t *testing.T) {
+	resp := &TimeSeriesResponse{
+		MetaData:  TimeSeriesMetaData{Symbol: "IBM", TimeZone: "US/Eastern"},
+		SeriesKey: "Time Series (Daily)",
+		TimeSeries: map[string]TimeSeriesData{
+			"2024-02-28": {Close: "1"},
+			"2024-02-29": {Close: "2"},
+			"2024-03-01": {Close: "3"},
+		},
+	}
+	bars, err := resp.Bars()
+	if err != nil {
+		t.Fatalf("Bars() error = %v", err)
+	}
+	page, err := Paginate(bars, Window{From: bars[1].Time})
+	if err != nil {
+		t.Fatalf("Paginate() error = %v", err)
+	}
+
+	subset, err := resp.Subset(page.Bars)
+	if err != nil {
+		t.Fatalf("Subset() error = %v", err)
+	}
+	if subset.SeriesKey != resp.SeriesKey || subset.MetaData != resp.MetaData {
+		t.Errorf("Subset() = %+v, want the key and metadata kept", subset)
+	}
+	if len(subset.TimeSeries) != 2 || subset.TimeSeries["2024-02-29"].Close != "2" || subset.TimeSeries["2024-03-01"].Close != "3" {
+		t.Errorf("Subset().TimeSeries = %+v, want 2024-02-29 and 2024-03-01", subset.TimeSeries)
+	}
+}
@@ -1,11 +1,15 @@
 package alphavantage
 
 import (
-	"net/http"
+	"context"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	"stock/alphavantage/market"
 	"stock/alphavantage/timeseries"
+	"stock/common"
 	"stock/config"
 
 	"github.com/gin-gonic/gin"
@@ -13,7 +17,7 @@ import (
 
 // TimeSeriesParams holds parameters for retrieving time series data
 type TimeSeriesParams struct {
-	Function      string // TIME_SERIES_INTRADAY, TIME_SERIES_DAILY, TIME_SERIES_WEEKLY, TIME_SERIES_MONTHLY
+	Function      string // TIME_SERIES_INTRADAY, TIME_SERIES_DAILY, TIME_SERIES_WEEKLY, TIME_SERIES_MONTHLY and their _ADJUSTED variants
 	Symbol        string
 	Interval      string // Required for INTRADAY: 1min, 5min, 15min, 30min, 60min
 	OutputSize    string // compact or full
@@ -26,26 +30,53 @@ type TimeSeriesParams struct {
 // TimeSeriesResponse defines the response format for time series data
 // @Description Time series response data structure
 type TimeSeriesResponse struct {
-	Version   string                         `json:"version"`
-	Timestamp string                         `json:"timestamp"`
-	Symbol    string                         `json:"symbol"`
-	Interval  string                         `json:"interval,omitempty"`
-	Data      *timeseries.TimeSeriesResponse `json:"data"`
+	Version    string `json:"version"`
+	Timestamp  string `json:"timestamp"`
+	Symbol     string `json:"symbol"`
+	Interval   string `json:"interval,omitempty"`
+	Cache      string `json:"cache,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+
+	MissingMonths   []timeseries.MissingMonth `json:"missing_months,omitempty"`
+	MissingSessions []string                  `json:"missing_sessions,omitempty"` // Trading days without bars, with gaps=true
+	Data            interface{}               `json:"data"`                       // *timeseries.TimeSeriesResponse, or []timeseries.Bar when shape=bars
 }
 
+// Time series data shapes
+const (
+	seriesShapeMap  = "map"  // Upstream shape keyed by timestamp
+	seriesShapeBars = "bars" // Chronologically ordered bars
+)
+
 // GetTimeSeriesForSymbol handles requests for time series data for a specific symbol
 // @Summary Get time series data for a specific symbol
 // @Description Returns time series data for the specified stock symbol
 // @Tags timeseries
-// @Produce json
+// @Produce json,text/csv,application/x-ndjson
 // @Param symbol path string true "Stock symbol (e.g., AAPL, MSFT)"
-// @Param function query string false "Time series function" Enums(TIME_SERIES_DAILY, TIME_SERIES_WEEKLY, TIME_SERIES_MONTHLY) default(TIME_SERIES_DAILY)
+// @Param function query string false "Time series function" Enums(TIME_SERIES_DAILY, TIME_SERIES_DAILY_ADJUSTED, TIME_SERIES_WEEKLY, TIME_SERIES_WEEKLY_ADJUSTED, TIME_SERIES_MONTHLY, TIME_SERIES_MONTHLY_ADJUSTED) default(TIME_SERIES_DAILY)
 // @Param outputsize query string false "Amount of data to return" Enums(compact, full) default(compact)
 // @Param datatype query string false "Data type for response" Enums(json, csv) default(json)
+// @Param shape query string false "Shape of the JSON data field, CSV and NDJSON always carry one bar per row" Enums(map, bars) default(map)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Param from query string false "Earliest bar to include (YYYY-MM-DD, YYYY-MM-DD HH:MM:SS or RFC 3339)"
+// @Param to query string false "Latest bar to include (YYYY-MM-DD, YYYY-MM-DD HH:MM:SS or RFC 3339)"
+// @Param limit query int false "Maximum number of bars per page"
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param gaps query boolean false "List trading days without bars in missing_sessions (daily and intraday series)" default(false)
+// @Param resample query string false "Aggregate bars to a duration (e.g. 10min, 2h, 4h) or calendar period (day, week, month, quarter, year), implies shape=bars"
 // @Success 200 {object} TimeSeriesResponse "Successful operation"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, also reported as next_cursor"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
 // @Router /v1/timeseries/{symbol} [get]
-func GetTimeSeriesForSymbol(c *gin.Context) {
+func (h *Handler) GetTimeSeriesForSymbol(c *gin.Context) {
 	symbol := c.Param("symbol")
 	function := c.DefaultQuery("function", "TIME_SERIES_DAILY")
 	outputSize := c.DefaultQuery("outputsize", "compact")
@@ -59,42 +90,75 @@ func GetTimeSeriesForSymbol(c *gin.Context) {
 		DataType:   dataType,
 	}
 
+	ctx, cache := requestContext(c)
+
 	// Get time series data
-	data, err := getTimeSeriesData(params)
+	data, err := h.getTimeSeriesData(ctx, params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	body, nextCursor, err := timeSeriesBody(c, data)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
+		return
+	}
+
+	gaps, err := seriesGaps(c, data)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
 	// Create response with versioning
 	response := TimeSeriesResponse{
-		Version:   config.GetConfig().DefaultAPIVersion,
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Symbol:    symbol,
-		Data:      data,
+		Version:         config.GetConfig().DefaultAPIVersion,
+		Timestamp:       time.Now().UTC().Format(time.RFC3339),
+		Symbol:          symbol,
+		Cache:           setCacheHeader(c, cache),
+		NextCursor:      nextCursor,
+		MissingSessions: gaps,
+		Data:            body,
 	}
 
-	c.JSON(http.StatusOK, response)
+	setCursorHeader(c, nextCursor)
+	respond(c, response, body)
 }
 
 // GetTimeSeriesWithInterval handles requests for time series data with interval
 // @Summary Get intraday time series data with specific interval
 // @Description Returns intraday time series data with the specified interval
 // @Tags timeseries
-// @Produce json
+// @Produce json,text/csv,application/x-ndjson
 // @Param symbol path string true "Stock symbol (e.g., AAPL, MSFT)"
 // @Param interval path string true "Time interval for data" Enums(1min, 5min, 15min, 30min, 60min)
 // @Param outputsize query string false "Amount of data to return" Enums(compact, full) default(compact)
 // @Param datatype query string false "Data type for response" Enums(json, csv) default(json)
+// @Param shape query string false "Shape of the JSON data field, CSV and NDJSON always carry one bar per row" Enums(map, bars) default(map)
+// @Param format query string false "Output encoding, also negotiable with the Accept header" Enums(json, csv, ndjson) default(json)
+// @Param from query string false "Earliest bar to include (YYYY-MM-DD, YYYY-MM-DD HH:MM:SS or RFC 3339)"
+// @Param to query string false "Latest bar to include (YYYY-MM-DD, YYYY-MM-DD HH:MM:SS or RFC 3339)"
+// @Param limit query int false "Maximum number of bars per page"
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param gaps query boolean false "List trading days without bars in missing_sessions (daily and intraday series)" default(false)
+// @Param resample query string false "Aggregate bars to a duration (e.g. 10min, 2h, 4h) or calendar period (day, week, month, quarter, year), implies shape=bars"
 // @Param extended_hours query boolean false "Whether to include extended hours data" default(false)
 // @Param adjusted query boolean false "Whether to adjust for split and dividend events" default(true)
 // @Param month query string false "Month for historical intraday data (YYYY-MM format)"
+// @Description When both from and to are months (YYYY-MM) the series is stitched from one request per month and returned as bars, months that fail are listed in missing_months, and once a month is rate limited the remaining ones are listed there without being fetched
 // @Success 200 {object} TimeSeriesResponse "Successful operation"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Header 200 {string} X-Cache "HIT when served from cache, MISS otherwise"
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page, also reported as next_cursor"
+// @Failure 400 {object} ErrorResponse "Invalid parameter"
+// @Failure 402 {object} ErrorResponse "Premium endpoint"
+// @Failure 404 {object} ErrorResponse "Invalid symbol"
+// @Failure 429 {object} ErrorResponse "Upstream rate limit reached"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 502 {object} ErrorResponse "Upstream error"
+// @Failure 504 {object} ErrorResponse "Upstream timeout"
 // @Router /v1/timeseries/{symbol}/{interval} [get]
-func GetTimeSeriesWithInterval(c *gin.Context) {
+func (h *Handler) GetTimeSeriesWithInterval(c *gin.Context) {
 	symbol := c.Param("symbol")
 	interval := c.Param("interval")
 	function := "TIME_SERIES_INTRADAY" // Forced for interval-based queries
@@ -129,29 +193,120 @@ func GetTimeSeriesWithInterval(c *gin.Context) {
 		params.Month = month
 	}
 
+	ctx, cache := requestContext(c)
+
+	// Month ranges are stitched from one request per month
+	if isMonth(c.Query("from")) && isMonth(c.Query("to")) {
+		h.getIntradayHistory(ctx, c, cache, params)
+		return
+	}
+
 	// Get time series data
-	data, err := getTimeSeriesData(params)
+	data, err := h.getTimeSeriesData(ctx, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		respondError(c, err)
+		return
+	}
+
+	body, nextCursor, err := timeSeriesBody(c, data)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	gaps, err := seriesGaps(c, data)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
 	// Create response with versioning
 	response := TimeSeriesResponse{
-		Version:   config.GetConfig().DefaultAPIVersion,
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Symbol:    symbol,
-		Interval:  interval,
-		Data:      data,
+		Version:         config.GetConfig().DefaultAPIVersion,
+		Timestamp:       time.Now().UTC().Format(time.RFC3339),
+		Symbol:          symbol,
+		Interval:        interval,
+		Cache:           setCacheHeader(c, cache),
+		NextCursor:      nextCursor,
+		MissingSessions: gaps,
+		Data:            body,
 	}
 
-	c.JSON(http.StatusOK, response)
+	setCursorHeader(c, nextCursor)
+	respond(c, response, body)
+}
+
+// getIntradayHistory serves intraday bars stitched from the months between the from and to query parameters
+func (h *Handler) getIntradayHistory(ctx context.Context, c *gin.Context, cache *common.CacheStatus, params TimeSeriesParams) {
+	if params.Month != "" {
+		respondError(c, fmt.Errorf("%w: month cannot be combined with a from/to month range", common.ErrInvalidParameter))
+		return
+	}
+	if seriesShape(c, seriesShapeBars) != seriesShapeBars {
+		respondError(c, fmt.Errorf("%w: month ranges are only available as %s", common.ErrInvalidParameter, seriesShapeBars))
+		return
+	}
+
+	stitched, err := h.client.GetIntradayHistory(ctx, timeseries.TimeSeriesParams{
+		Symbol:        params.Symbol,
+		Interval:      params.Interval,
+		ExtendedHours: params.ExtendedHours,
+		Adjusted:      params.Adjusted,
+	}, c.Query("from"), c.Query("to"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	bars := stitched.Bars
+	gaps, err := missingSessions(c, bars, "")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if timeframe, resample, err := parseResample(c); err != nil {
+		respondError(c, err)
+		return
+	} else if resample {
+		bars = timeseries.Resample(bars, timeframe, sessionCalendar(""))
+	}
+
+	// The months already bound the range, only the page size and cursor apply
+	window := timeseries.Window{Cursor: c.Query("cursor")}
+	if window.Limit, err = parseLimit(c); err != nil {
+		respondError(c, err)
+		return
+	}
+	page, err := timeseries.Paginate(bars, window)
+	if err != nil {
+		respondError(c, fmt.Errorf("%w: %v", common.ErrInvalidParameter, err))
+		return
+	}
+
+	response := TimeSeriesResponse{
+		Version:         config.GetConfig().DefaultAPIVersion,
+		Timestamp:       time.Now().UTC().Format(time.RFC3339),
+		Symbol:          params.Symbol,
+		Interval:        params.Interval,
+		Cache:           setCacheHeader(c, cache),
+		NextCursor:      page.NextCursor,
+		MissingMonths:   stitched.MissingMonths,
+		MissingSessions: gaps,
+		Data:            page.Bars,
+	}
+
+	setCursorHeader(c, page.NextCursor)
+	respond(c, response, page.Bars)
+}
+
+// isMonth reports whether value is a YYYY-MM month
+func isMonth(value string) bool {
+	_, err := time.Parse("2006-01", value)
+	return err == nil
 }
 
 // getTimeSeriesData fetches time series data from Alpha Vantage API
-func getTimeSeriesData(params TimeSeriesParams) (*timeseries.TimeSeriesResponse, error) {
+func (h *Handler) getTimeSeriesData(ctx context.Context, params TimeSeriesParams) (*timeseries.TimeSeriesResponse, error) {
 	// Convert API params to stock package params
 	stockParams := timeseries.TimeSeriesParams{
 		Function:      params.Function,
@@ -168,5 +323,174 @@ func getTimeSeriesData(params TimeSeriesParams) (*timeseries.TimeSeriesResponse,
 	// in the API call if it's explicitly set to false
 	stockParams.Adjusted = params.Adjusted
 	// Use the library function directly
-	return timeseries.GetTimeSeries(stockParams)
+	return h.client.GetRawTimeSeries(ctx, stockParams)
+}
+
+// seriesShape returns the requested shape of the data field. CSV and NDJSON
+// output always carry one bar per row.
+func seriesShape(c *gin.Context, fallback string) string {
+	if format, err := outputFormat(c); err == nil && format != outputJSON {
+		return seriesShapeBars
+	}
+	return c.DefaultQuery("shape", fallback)
+}
+
+// setCursorHeader reports the next page cursor in a header, since CSV and NDJSON bodies have no envelope
+func setCursorHeader(c *gin.Context, cursor string) {
+	if cursor != "" {
+		c.Header("X-Next-Cursor", cursor)
+	}
+}
+
+// timeSeriesBody applies the requested window and returns the data in the
+// requested shape together with the cursor of the next page
+func timeSeriesBody(c *gin.Context, data *timeseries.TimeSeriesResponse) (interface{}, string, error) {
+	timeframe, resample, err := parseResample(c)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Resampled bars have no upstream equivalent, so they are only served as bars
+	shape := seriesShape(c, seriesShapeMap)
+	if resample {
+		shape = seriesShape(c, seriesShapeBars)
+		if shape != seriesShapeBars {
+			return nil, "", fmt.Errorf("%w: resampled series are only available as %s", common.ErrInvalidParameter, seriesShapeBars)
+		}
+	}
+	if shape != seriesShapeMap && shape != seriesShapeBars {
+		return nil, "", fmt.Errorf("%w: shape must be %s or %s", common.ErrInvalidParameter, seriesShapeMap, seriesShapeBars)
+	}
+
+	window, err := parseWindow(c, data.MetaData)
+	if err != nil {
+		return nil, "", err
+	}
+	if window.IsZero() && shape == seriesShapeMap {
+		return data, "", nil
+	}
+
+	// Windows are applied to the normalized series, whatever the output format
+	bars, err := data.Bars()
+	if err != nil {
+		return nil, "", err
+	}
+	if resample {
+		bars = timeseries.Resample(bars, timeframe, sessionCalendar(data.MetaData.TimeZone))
+	}
+	page, err := timeseries.Paginate(bars, window)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", common.ErrInvalidParameter, err)
+	}
+
+	if shape == seriesShapeBars {
+		return page.Bars, page.NextCursor, nil
+	}
+	subset, err := data.Subset(page.Bars)
+	return subset, page.NextCursor, err
+}
+
+// seriesGaps lists the trading days missing from data when gaps=true was requested
+func seriesGaps(c *gin.Context, data *timeseries.TimeSeriesResponse) ([]string, error) {
+	if !gapsRequested(c) {
+		return nil, nil
+	}
+	if strings.HasPrefix(data.SeriesKey, "Weekly") || strings.HasPrefix(data.SeriesKey, "Monthly") {
+		return nil, fmt.Errorf("%w: gaps are only detected in daily and intraday series", common.ErrInvalidParameter)
+	}
+
+	bars, err := data.Bars()
+	if err != nil {
+		return nil, err
+	}
+	return missingSessions(c, bars, data.MetaData.TimeZone)
+}
+
+// missingSessions lists the trading days without bars on the calendar of
+// timeZone, US equities when empty
+func missingSessions(c *gin.Context, bars []timeseries.Bar, timeZone string) ([]string, error) {
+	if !gapsRequested(c) {
+		return nil, nil
+	}
+
+	cal := market.NYSE
+	if timeZone != "" {
+		var ok bool
+		if cal, ok = market.CalendarForTimeZone(timeZone); !ok {
+			return nil, fmt.Errorf("%w: no trading calendar for time zone %s", common.ErrInvalidParameter, timeZone)
+		}
+	}
+
+	var days []string
+	for _, day := range timeseries.MissingSessions(bars, cal) {
+		days = append(days, day.Format(common.DateLayout))
+	}
+	return days, nil
+}
+
+// sessionCalendar returns the calendar intraday buckets are anchored on for
+// a series in timeZone, US equities when empty and nil when none is known
+func sessionCalendar(timeZone string) *market.Calendar {
+	if timeZone == "" {
+		return market.NYSE
+	}
+	cal, _ := market.CalendarForTimeZone(timeZone)
+	return cal
+}
+
+// gapsRequested reports whether the caller asked for missing sessions
+func gapsRequested(c *gin.Context) bool {
+	gaps, err := strconv.ParseBool(c.DefaultQuery("gaps", "false"))
+	return err == nil && gaps
+}
+
+// parseWindow reads the from, to, limit and cursor query parameters
+func parseWindow(c *gin.Context, meta timeseries.TimeSeriesMetaData) (timeseries.Window, error) {
+	window := timeseries.Window{Cursor: c.Query("cursor")}
+
+	loc, err := meta.Location()
+	if err != nil {
+		return window, err
+	}
+
+	if from := c.Query("from"); from != "" {
+		if window.From, err = timeseries.ParseTime(from, loc, false); err != nil {
+			return window, fmt.Errorf("%w: from: %v", common.ErrInvalidParameter, err)
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if window.To, err = timeseries.ParseTime(to, loc, true); err != nil {
+			return window, fmt.Errorf("%w: to: %v", common.ErrInvalidParameter, err)
+		}
+	}
+	window.Limit, err = parseLimit(c)
+	return window, err
+}
+
+// parseResample reads the optional resample query parameter
+func parseResample(c *gin.Context) (timeseries.Timeframe, bool, error) {
+	value := c.Query("resample")
+	if value == "" {
+		return timeseries.Timeframe{}, false, nil
+	}
+
+	timeframe, err := timeseries.ParseTimeframe(value)
+	if err != nil {
+		return timeframe, false, fmt.Errorf("%w: resample: %v", common.ErrInvalidParameter, err)
+	}
+	return timeframe, true, nil
+}
+
+// parseLimit reads the optional limit query parameter, zero when absent
+func parseLimit(c *gin.Context) (int, error) {
+	value := c.Query("limit")
+	if value == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 {
+		return 0, fmt.Errorf("%w: limit must be a positive integer", common.ErrInvalidParameter)
+	}
+	return limit, nil
 }
@@ -0,0 +1,399 @@
+package common
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores upstream response bodies by key
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// CacheKey builds a cache key from normalized query parameters, the API key is excluded
+func CacheKey(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	normalized := make(map[string]string, len(params))
+	for key, value := range params {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "apikey" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if key == "function" || key == "symbol" {
+			value = strings.ToUpper(value)
+		}
+		keys = append(keys, key)
+		normalized[key] = value
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(normalized[key])
+	}
+	return b.String()
+}
+
+// lruEntry is a single cached body in an LRUCache
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// LRUCache is an in-memory cache bounded by the total size of stored bodies
+type LRUCache struct {
+	mu       sync.Mutex
+	maxBytes int
+	size     int
+	order    *list.List // Front is most recently used
+	entries  map[string]*list.Element
+}
+
+// NewLRUCache creates an in-memory cache holding at most maxBytes of bodies
+func NewLRUCache(maxBytes int) *LRUCache {
+	return &LRUCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get returns a cached body if present and not expired
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*lruEntry)
+	if time.Now().After(entry.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set stores a body, evicting the least recently used entries to stay within bounds
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	if len(value) > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{
+		key:     key,
+		value:   value,
+		expires: time.Now().Add(ttl),
+	})
+	c.size += len(value)
+
+	for c.size > c.maxBytes {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove drops an entry, callers must hold c.mu
+func (c *LRUCache) remove(elem *list.Element) {
+	entry := c.order.Remove(elem).(*lruEntry)
+	delete(c.entries, entry.key)
+	c.size -= len(entry.value)
+}
+
+// diskSweepInterval is how often writes trigger a sweep of the cache directory
+const diskSweepInterval = 10 * time.Minute
+
+// DiskCache persists bodies as files so they survive restarts. Expired files
+// are swept periodically and the least recently used ones are deleted while
+// the directory holds more than maxBytes.
+type DiskCache struct {
+	dir      string
+	maxBytes int64 // Unbounded when 0
+
+	mu        sync.Mutex
+	lastSweep time.Time
+	sweeping  bool
+}
+
+// NewDiskCache creates a cache storing at most maxBytes of files under dir,
+// creating it if needed, and sweeps what a previous run left behind
+func NewDiskCache(dir string, maxBytes int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	c := &DiskCache{dir: dir, maxBytes: maxBytes}
+	c.Sweep()
+	return c, nil
+}
+
+// path returns the file holding the body for key
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// Get returns a cached body if present and not expired
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	body, _, ok := c.load(key)
+	return body, ok
+}
+
+// load returns a cached body together with its expiry time
+func (c *DiskCache) load(key string) ([]byte, time.Time, bool) {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+
+	// Files start with the expiry time in unix nanoseconds on its own line
+	header, body, ok := bytes.Cut(data, []byte("\n"))
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	nanos, err := strconv.ParseInt(string(header), 10, 64)
+	expires := time.Unix(0, nanos)
+	if err != nil || time.Now().After(expires) {
+		os.Remove(path)
+		return nil, time.Time{}, false
+	}
+
+	// Hits refresh the modification time the sweep evicts by
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return body, expires, true
+}
+
+// Set writes a body to disk, failures are ignored since the cache is best effort
+func (c *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	header := strconv.FormatInt(time.Now().Add(ttl).UnixNano(), 10) + "\n"
+	if _, err := tmp.WriteString(header); err != nil {
+		tmp.Close()
+		return
+	}
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+
+	os.Rename(tmp.Name(), c.path(key))
+	c.maybeSweep()
+}
+
+// maybeSweep starts a background sweep when the last one is old enough
+func (c *DiskCache) maybeSweep() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.sweeping || time.Since(c.lastSweep) < diskSweepInterval {
+		return
+	}
+	c.sweeping = true
+	go c.Sweep()
+}
+
+// Sweep deletes expired files and abandoned temporary files, then the least
+// recently used files until the directory fits in maxBytes
+func (c *DiskCache) Sweep() {
+	defer func() {
+		c.mu.Lock()
+		c.sweeping, c.lastSweep = false, time.Now()
+		c.mu.Unlock()
+	}()
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+
+	type cached struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var files []cached
+	var total int64
+	now := time.Now()
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		path := filepath.Join(c.dir, entry.Name())
+
+		if strings.HasPrefix(entry.Name(), "tmp-") {
+			// Left over by a write that crashed, live ones are renamed within seconds
+			if now.Sub(info.ModTime()) > time.Hour {
+				os.Remove(path)
+			}
+			continue
+		}
+		if expires, ok := readExpiry(path); !ok || now.After(expires) {
+			os.Remove(path)
+			continue
+		}
+
+		files = append(files, cached{path: path, size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+	}
+
+	if c.maxBytes <= 0 || total <= c.maxBytes {
+		return
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for _, f := range files {
+		if total <= c.maxBytes {
+			break
+		}
+		if os.Remove(f.path) == nil {
+			total -= f.size
+		}
+	}
+}
+
+// readExpiry reads the expiry time header of a cache file
+func readExpiry(path string) (time.Time, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	defer f.Close()
+
+	// Unix nanoseconds take at most 19 digits and a sign
+	buf := make([]byte, 21)
+	n, _ := io.ReadFull(f, buf)
+	header, _, ok := bytes.Cut(buf[:n], []byte("\n"))
+	if !ok {
+		return time.Time{}, false
+	}
+	nanos, err := strconv.ParseInt(string(header), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos), true
+}
+
+// TieredCache checks a memory cache before the on-disk one
+type TieredCache struct {
+	Memory *LRUCache
+	Disk   *DiskCache
+}
+
+// Get returns a body from memory, falling back to disk and promoting disk hits
+func (c *TieredCache) Get(key string) ([]byte, bool) {
+	if value, ok := c.Memory.Get(key); ok {
+		return value, true
+	}
+
+	value, expires, ok := c.Disk.load(key)
+	if ok {
+		c.Memory.Set(key, value, time.Until(expires))
+	}
+	return value, ok
+}
+
+// Set stores a body in both tiers
+func (c *TieredCache) Set(key string, value []byte, ttl time.Duration) {
+	c.Memory.Set(key, value, ttl)
+	c.Disk.Set(key, value, ttl)
+}
+
+// Cache status values reported to API clients
+const (
+	CacheHit  = "HIT"
+	CacheMiss = "MISS"
+)
+
+// CacheStatus records whether the upstream calls made for one request were cached
+type CacheStatus struct {
+	mu     sync.Mutex
+	hits   int
+	misses int
+}
+
+// String returns HIT when every call was served from cache, MISS when any call
+// went upstream and an empty string when no calls were made
+func (s *CacheStatus) String() string {
+	if s == nil {
+		return ""
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case s.misses > 0:
+		return CacheMiss
+	case s.hits > 0:
+		return CacheHit
+	}
+	return ""
+}
+
+// record adds the outcome of one upstream call
+func (s *CacheStatus) record(hit bool) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if hit {
+		s.hits++
+	} else {
+		s.misses++
+	}
+}
+
+type cacheStatusKey struct{}
+
+// WithCacheStatus returns a context that records cache outcomes into the returned status
+func WithCacheStatus(ctx context.Context) (context.Context, *CacheStatus) {
+	status := &CacheStatus{}
+	return context.WithValue(ctx, cacheStatusKey{}, status), status
+}
+
+// cacheStatusFrom returns the status recorder carried by ctx, if any
+func cacheStatusFrom(ctx context.Context) *CacheStatus {
+	status, _ := ctx.Value(cacheStatusKey{}).(*CacheStatus)
+	return status
+}
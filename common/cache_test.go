@@ -0,0 +1,163 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheKey(t *testing.T) {
+	a := CacheKey(map[string]string{"function": "overview", "symbol": " ibm ", "apikey": "one"})
+	b := CacheKey(map[string]string{"symbol": "IBM", "function": "OVERVIEW", "apikey": "two"})
+	if a != b || a != "function=OVERVIEW&symbol=IBM" {
+		t.Errorf("CacheKey() = %q and %q, want both function=OVERVIEW&symbol=IBM", a, b)
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	c := NewLRUCache(10)
+	c.Set("a", []byte("aaaa"), time.Minute)
+	c.Set("b", []byte("bbbb"), time.Minute)
+	c.Get("a") // a becomes the most recently used
+	c.Set("c", []byte("cccc"), time.Minute)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("b still cached, want it evicted as least recently used")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("%s evicted, want it cached", key)
+		}
+	}
+
+	c.Set("big", make([]byte, 11), time.Minute)
+	if _, ok := c.Get("big"); ok {
+		t.Error("body larger than the cache was stored")
+	}
+
+	c.Set("expired", []byte("x"), -time.Second)
+	if _, ok := c.Get("expired"); ok {
+		t.Error("expired entry returned")
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	c, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Set("key", []byte("body"), time.Minute)
+	if got, ok := c.Get("key"); !ok || string(got) != "body" {
+		t.Errorf("Get() = %q, %v, want body", got, ok)
+	}
+	c.Set("expired", []byte("body"), -time.Second)
+	if _, ok := c.Get("expired"); ok {
+		t.Error("expired entry returned")
+	}
+	if _, ok := c.Get("missing"); ok {
+		t.Error("missing entry returned")
+	}
+}
+
+func TestDiskCacheSweep(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each file is a 20 byte expiry header and newline plus the body
+	c.Set("expired", []byte("x"), -time.Second)
+	c.Set("old", []byte("x"), time.Hour)
+	c.Set("new", []byte("x"), time.Hour)
+	past := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(c.path("old"), past, past); err != nil {
+		t.Fatal(err)
+	}
+	tmp := filepath.Join(dir, "tmp-abandoned")
+	if err := os.WriteFile(tmp, []byte("partial"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stale := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(tmp, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Sweep()
+
+	for _, removed := range []string{c.path("expired"), c.path("old"), tmp} {
+		if _, err := os.Stat(removed); !os.IsNotExist(err) {
+			t.Errorf("%s kept by Sweep(), want it removed", filepath.Base(removed))
+		}
+	}
+	if _, ok := c.Get("new"); !ok {
+		t.Error("most recently used entry removed by Sweep()")
+	}
+}
+
+func TestTieredCachePromotesDiskHits(t *testing.T) {
+	disk, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	disk.Set("key", []byte("body"), time.Minute)
+
+	c := &TieredCache{Memory: NewLRUCache(1 << 10), Disk: disk}
+	if got, ok := c.Get("key"); !ok || string(got) != "body" {
+		t.Fatalf("Get() = %q, %v, want the disk body", got, ok)
+	}
+	if _, ok := c.Memory.Get("key"); !ok {
+		t.Error("disk hit not promoted to memory")
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	upstream := newFakeUpstream(t, func(w http.ResponseWriter, r *http.Request, call int64) {
+		fmt.Fprintf(w, `{"call": %d}`, call)
+	})
+	client := newTestClient(upstream)
+	client.Cache = NewLRUCache(1 << 20)
+	client.CacheTTL = func(params map[string]string, now time.Time) time.Duration {
+		if params["function"] == "TIME_SERIES_INTRADAY" {
+			return 0
+		}
+		return time.Minute
+	}
+
+	get := func(params map[string]string) (string, string) {
+		ctx, status := WithCacheStatus(context.Background())
+		data, err := client.Get(ctx, params)
+		if err != nil {
+			t.Fatalf("Get(%v) error = %v", params, err)
+		}
+		return string(data), status.String()
+	}
+
+	first, status := get(map[string]string{"function": "OVERVIEW", "symbol": "IBM"})
+	if status != CacheMiss {
+		t.Errorf("first call status = %q, want %q", status, CacheMiss)
+	}
+	// Symbols are normalized, so a lower case symbol hits the same entry
+	second, status := get(map[string]string{"function": "OVERVIEW", "symbol": "ibm"})
+	if status != CacheHit || second != first {
+		t.Errorf("second call = %s (%q), want cached %s (%q)", second, status, first, CacheHit)
+	}
+
+	get(map[string]string{"function": "TIME_SERIES_INTRADAY", "symbol": "IBM"})
+	if _, status := get(map[string]string{"function": "TIME_SERIES_INTRADAY", "symbol": "IBM"}); status != CacheMiss {
+		t.Errorf("uncacheable call status = %q, want %q", status, CacheMiss)
+	}
+
+	if got := upstream.calls.Load(); got != 3 {
+		t.Errorf("upstream calls = %d, want 3", got)
+	}
+	stats := client.Stats()
+	if stats.Requests != 4 || stats.CacheHits != 1 || stats.CacheMisses != 3 {
+		t.Errorf("Stats() = %+v, want 4 requests, 1 hit and 3 misses", stats)
+	}
+}
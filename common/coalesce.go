@@ -0,0 +1,121 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// flight is one upstream fetch shared by every caller asking for the same key
+type flight struct {
+	done    chan struct{}
+	data    []byte
+	err     error
+	waiters int
+	ctx     *flightContext
+	cancel  context.CancelFunc
+}
+
+// flightGroup deduplicates concurrent fetches for the same key
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// do runs fn once for all concurrent callers with the same key. The fetch runs
+// detached from the callers' cancellation and deadlines and is only cancelled
+// once every waiting caller has given up, each caller enforcing its own
+// deadline while it waits. The fetch still reports the latest deadline of its
+// waiters, so the limiter and retries inside it give up on waits nobody would
+// see the end of. shared reports whether the caller joined a fetch started by
+// someone else.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) ([]byte, error)) (data []byte, shared bool, err error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+
+	f, shared := g.flights[key]
+	if !shared {
+		// Neither the starter's cancellation nor its deadline may cut the
+		// fetch short for the other waiters, the last one to leave cancels it
+		detached, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		fetchCtx := &flightContext{Context: detached}
+
+		f = &flight{done: make(chan struct{}), ctx: fetchCtx, cancel: cancel}
+		g.flights[key] = f
+	}
+	f.waiters++
+	f.ctx.extend(ctx)
+	if !shared {
+		go func() {
+			f.data, f.err = fn(f.ctx)
+			g.forget(key, f)
+			f.cancel()
+			close(f.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.data, shared, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			// Nobody is left to use the result
+			f.cancel()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, shared, ctx.Err()
+	}
+}
+
+// forget removes a finished flight unless a newer one replaced it
+func (g *flightGroup) forget(key string, f *flight) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.flights[key] == f {
+		delete(g.flights, key)
+	}
+}
+
+// flightContext is the context of a shared fetch. It is only cancelled
+// explicitly, its deadline is the latest of its waiters' and none as soon as
+// one waiter has none.
+type flightContext struct {
+	context.Context
+
+	mu       sync.Mutex
+	joined   bool
+	deadline time.Time
+	bounded  bool
+}
+
+// extend makes the deadline of ctx count for the fetch
+func (c *flightContext) extend(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deadline, ok := ctx.Deadline()
+	switch {
+	case !c.joined:
+		c.joined, c.deadline, c.bounded = true, deadline, ok
+	case !ok:
+		c.bounded = false
+	case c.bounded && deadline.After(c.deadline):
+		c.deadline = deadline
+	}
+}
+
+// Deadline returns the latest deadline of the waiters
+func (c *flightContext) Deadline() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.deadline, c.bounded
+}
@@ -0,0 +1,215 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFlightSurvivesStarterCancellation(t *testing.T) {
+	var g flightGroup
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fn := func(ctx context.Context) ([]byte, error) {
+		close(started)
+		select {
+		case <-release:
+			return []byte("body"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	starterCtx, cancelStarter := context.WithCancel(context.Background())
+	starterErr := make(chan error, 1)
+	go func() {
+		_, _, err := g.do(starterCtx, "key", fn)
+		starterErr <- err
+	}()
+	<-started
+
+	joined := make(chan []byte, 1)
+	go func() {
+		data, shared, err := g.do(context.Background(), "key", fn)
+		if err != nil || !shared {
+			t.Errorf("joined caller got shared=%v err=%v", shared, err)
+		}
+		joined <- data
+	}()
+	waitFor(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.flights["key"] != nil && g.flights["key"].waiters == 2
+	})
+
+	cancelStarter()
+	if err := <-starterErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("starter error = %v, want context.Canceled", err)
+	}
+	close(release)
+	if data := <-joined; string(data) != "body" {
+		t.Errorf("joined caller data = %q, want body", data)
+	}
+}
+
+func TestFlightOutlivesStarterDeadline(t *testing.T) {
+	var g flightGroup
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fetchDeadline := make(chan time.Time, 1)
+	fn := func(ctx context.Context) ([]byte, error) {
+		close(started)
+		select {
+		case <-release:
+			deadline, _ := ctx.Deadline()
+			fetchDeadline <- deadline
+			return []byte("body"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	shortCtx, cancelShort := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancelShort()
+	shortErr := make(chan error, 1)
+	go func() {
+		_, _, err := g.do(shortCtx, "key", fn)
+		shortErr <- err
+	}()
+	<-started
+
+	longCtx, cancelLong := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelLong()
+	joined := make(chan []byte, 1)
+	go func() {
+		data, shared, err := g.do(longCtx, "key", fn)
+		if err != nil || !shared {
+			t.Errorf("long-deadline caller got shared=%v err=%v", shared, err)
+		}
+		joined <- data
+	}()
+	waitFor(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return g.flights["key"] != nil && g.flights["key"].waiters == 2
+	})
+
+	// The short deadline only ends its own wait
+	if err := <-shortErr; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("short-deadline caller error = %v, want context.DeadlineExceeded", err)
+	}
+	close(release)
+	if data := <-joined; string(data) != "body" {
+		t.Errorf("long-deadline caller data = %q, want body", data)
+	}
+	if want, _ := longCtx.Deadline(); !(<-fetchDeadline).Equal(want) {
+		t.Error("shared fetch does not report the latest waiter deadline")
+	}
+}
+
+func TestFlightDeadlineOfWaiters(t *testing.T) {
+	early := time.Now().Add(time.Minute)
+	late := early.Add(time.Hour)
+	withDeadline := func(d time.Time) context.Context {
+		ctx, cancel := context.WithDeadline(context.Background(), d)
+		t.Cleanup(cancel)
+		return ctx
+	}
+
+	tests := []struct {
+		name    string
+		waiters []context.Context
+		want    time.Time
+		bounded bool
+	}{
+		{"single", []context.Context{withDeadline(early)}, early, true},
+		{"later joiner", []context.Context{withDeadline(early), withDeadline(late)}, late, true},
+		{"earlier joiner", []context.Context{withDeadline(late), withDeadline(early)}, late, true},
+		{"unbounded joiner", []context.Context{withDeadline(early), context.Background(), withDeadline(late)}, time.Time{}, false},
+		{"unbounded starter", []context.Context{context.Background(), withDeadline(early)}, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		fc := &flightContext{Context: context.Background()}
+		for _, waiter := range tt.waiters {
+			fc.extend(waiter)
+		}
+		got, ok := fc.Deadline()
+		if ok != tt.bounded || (ok && !got.Equal(tt.want)) {
+			t.Errorf("%s: Deadline() = %v, %v, want %v, %v", tt.name, got, ok, tt.want, tt.bounded)
+		}
+	}
+}
+
+func TestFlightCancelledWhenEveryCallerLeaves(t *testing.T) {
+	var g flightGroup
+	fetchDone := make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	_, _, err := g.do(ctx, "key", func(fetchCtx context.Context) ([]byte, error) {
+		<-fetchCtx.Done()
+		fetchDone <- fetchCtx.Err()
+		return nil, fetchCtx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("do() error = %v, want context.Canceled", err)
+	}
+	select {
+	case err := <-fetchDone:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("fetch error = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("fetch kept running after its only caller left")
+	}
+}
+
+func TestClientCoalescesConcurrentRequests(t *testing.T) {
+	release := make(chan struct{})
+	upstream := newFakeUpstream(t, func(w http.ResponseWriter, r *http.Request, call int64) {
+		<-release
+		fmt.Fprint(w, `{"Symbol": "IBM"}`)
+	})
+	client := newTestClient(upstream)
+
+	const callers = 5
+	params := map[string]string{"function": "OVERVIEW", "symbol": "IBM"}
+	var wg sync.WaitGroup
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = client.Get(context.Background(), params)
+		}(i)
+	}
+
+	// Hold the upstream response until every caller waits on the same flight
+	waitFor(t, func() bool {
+		client.flights.mu.Lock()
+		defer client.flights.mu.Unlock()
+		f := client.flights.flights[CacheKey(params)]
+		return f != nil && f.waiters == callers
+	})
+	close(release)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("caller %d error = %v", i, err)
+		}
+	}
+	if got := upstream.calls.Load(); got != 1 {
+		t.Errorf("upstream calls = %d, want 1", got)
+	}
+	if stats := client.Stats(); stats.Fetches != 1 || stats.Coalesced != callers-1 {
+		t.Errorf("Stats() = %+v, want 1 fetch and %d coalesced", stats, callers-1)
+	}
+}
@@ -0,0 +1,21 @@
+// Package commontest provides test helpers for code calling Alpha Vantage
+// through common.Client
+package commontest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"stock/common"
+)
+
+// NewClient returns a client whose upstream is handler, without limiter,
+// retries or cache. The upstream is closed when the test ends.
+func NewClient(t testing.TB, handler http.HandlerFunc) *common.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &common.Client{BaseURL: server.URL, APIKey: "test", HTTPClient: common.NewHTTPClient(5*time.Second, nil)}
+}
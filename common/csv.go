@@ -0,0 +1,67 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CSVRecord is one CSV row keyed by its lowercased header column
+type CSVRecord map[string]string
+
+// DecodeCSV parses a body with a header row into one record per data row
+func DecodeCSV(data []byte) ([]CSVRecord, error) {
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("\xef\xbb\xbf"))
+	if len(data) > 0 && data[0] == '{' {
+		// Soft errors are caught by CheckEnvelope, anything else is unexpected
+		return nil, errors.New("expected CSV, got a JSON body")
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("csv header: %w", err)
+	}
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+	}
+
+	var records []CSVRecord
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("csv: %w", err)
+		}
+
+		record := make(CSVRecord, len(header))
+		for i, column := range header {
+			if i < len(row) {
+				record[column] = strings.TrimSpace(row[i])
+			}
+		}
+		records = append(records, record)
+	}
+}
+
+// GetCSV performs a GET request and decodes the CSV response into records
+func (c *Client) GetCSV(ctx context.Context, params map[string]string) ([]CSVRecord, error) {
+	data, err := c.Get(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeCSV(data)
+}
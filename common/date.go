@@ -0,0 +1,25 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateLayout is the layout Alpha Vantage uses for calendar dates
+const DateLayout = "2006-01-02"
+
+// ParseDate parses a YYYY-MM-DD date as UTC midnight. Alpha Vantage
+// placeholders like "None" return nil.
+func ParseDate(s string) (*time.Time, error) {
+	s = strings.TrimSpace(s)
+	if nullValues[s] {
+		return nil, nil
+	}
+
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", s)
+	}
+	return &t, nil
+}
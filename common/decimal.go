@@ -0,0 +1,221 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// nullValues are the strings Alpha Vantage uses for missing numbers
+var nullValues = map[string]bool{"": true, "None": true, "none": true, "-": true, "null": true, "n/a": true}
+
+// maxExponent bounds the exponent of parsed decimals, far beyond any market
+// figure but small enough to keep coefficients from growing unbounded
+const maxExponent = 400
+
+// Decimal is an exact, nullable decimal number. The zero value is null.
+// Its value is coef * 10^-scale.
+type Decimal struct {
+	coef  *big.Int
+	scale int32
+}
+
+// NewDecimal returns the decimal coef * 10^-scale
+func NewDecimal(coef int64, scale int32) Decimal {
+	return Decimal{coef: big.NewInt(coef), scale: scale}
+}
+
+// ParseDecimal parses a decimal string such as "-12.50" or "1.5E3".
+// Alpha Vantage placeholders like "None" parse as null.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if nullValues[s] {
+		return Decimal{}, nil
+	}
+
+	mantissa, exponent := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		if exp > maxExponent || exp < -maxExponent {
+			return Decimal{}, fmt.Errorf("decimal exponent out of range %q", s)
+		}
+		mantissa, exponent = s[:i], exp
+	}
+
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	digits := intPart + fracPart
+	if digits == "" || digits == "-" || digits == "+" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	scale := int64(len(fracPart)) - exponent
+	if scale < 0 {
+		coef.Mul(coef, new(big.Int).Exp(big.NewInt(10), big.NewInt(-scale), nil))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics on invalid input
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Valid reports whether the decimal holds a value
+func (d Decimal) Valid() bool {
+	return d.coef != nil
+}
+
+// String returns the exact decimal representation, or "None" when null
+func (d Decimal) String() string {
+	if !d.Valid() {
+		return "None"
+	}
+
+	digits := new(big.Int).Abs(d.coef).String()
+	sign := ""
+	if d.coef.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+
+	scale := int(d.scale)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
+// Rat returns the exact value as a rational number, or nil when null
+func (d Decimal) Rat() *big.Rat {
+	if !d.Valid() {
+		return nil
+	}
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale)), nil)
+	return new(big.Rat).SetFrac(d.coef, denom)
+}
+
+// Float64 returns the nearest float64 and whether the decimal holds a value
+func (d Decimal) Float64() (float64, bool) {
+	if !d.Valid() {
+		return 0, false
+	}
+	f, _ := d.Rat().Float64()
+	return f, true
+}
+
+// Sign returns -1, 0 or +1, null decimals report 0
+func (d Decimal) Sign() int {
+	if !d.Valid() {
+		return 0
+	}
+	return d.coef.Sign()
+}
+
+// align returns both coefficients rescaled to the larger of the two scales
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	return rescale(a, scale), rescale(b, scale), scale
+}
+
+// rescale returns the coefficient of d expressed with the given, larger scale,
+// zero when d is null
+func rescale(d Decimal, scale int32) *big.Int {
+	if !d.Valid() {
+		return new(big.Int)
+	}
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale-d.scale)), nil)
+	return factor.Mul(factor, d.coef)
+}
+
+// Add returns d + other, null if either operand is null
+func (d Decimal) Add(other Decimal) Decimal {
+	if !d.Valid() || !other.Valid() {
+		return Decimal{}
+	}
+	a, b, scale := align(d, other)
+	return Decimal{coef: a.Add(a, b), scale: scale}
+}
+
+// Sub returns d - other, null if either operand is null
+func (d Decimal) Sub(other Decimal) Decimal {
+	if !d.Valid() || !other.Valid() {
+		return Decimal{}
+	}
+	a, b, scale := align(d, other)
+	return Decimal{coef: a.Sub(a, b), scale: scale}
+}
+
+// Mul returns d * other, null if either operand is null
+func (d Decimal) Mul(other Decimal) Decimal {
+	if !d.Valid() || !other.Valid() {
+		return Decimal{}
+	}
+	return Decimal{coef: new(big.Int).Mul(d.coef, other.coef), scale: d.scale + other.scale}
+}
+
+// Cmp compares two decimals, returning -1, 0 or +1. Null sorts before any
+// value and equals another null.
+func (d Decimal) Cmp(other Decimal) int {
+	switch {
+	case !d.Valid() && !other.Valid():
+		return 0
+	case !d.Valid():
+		return -1
+	case !other.Valid():
+		return 1
+	}
+	a, b, _ := align(d, other)
+	return a.Cmp(b)
+}
+
+// MarshalJSON encodes the decimal as an exact JSON number, or null
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if !d.Valid() {
+		return []byte("null"), nil
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts JSON numbers, numeric strings and Alpha Vantage null placeholders
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,118 @@
+package common
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"0", "0", false},
+		{"-12.50", "-12.50", false},
+		{" 3.14159 ", "3.14159", false},
+		{"+7", "7", false},
+		{".5", "0.5", false},
+		{"1.5E3", "1500", false},
+		{"2.5e-3", "0.0025", false},
+		{"1e400", "1" + strings.Repeat("0", 400), false},
+		{"None", "None", false},
+		{"-", "None", false},
+		{"n/a", "None", false},
+		{"", "None", false},
+		{"1e401", "", true},
+		{"1e-401", "", true},
+		{"1e999999999", "", true},
+		{"abc", "", true},
+		{"1.2.3", "", true},
+		{"e5", "", true},
+		{"-.", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseDecimal(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDecimal(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("ParseDecimal(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, b := MustParseDecimal("0.1"), MustParseDecimal("0.2")
+	if got := a.Add(b).String(); got != "0.3" {
+		t.Errorf("0.1 + 0.2 = %s, want exactly 0.3", got)
+	}
+	if got := a.Sub(b).String(); got != "-0.1" {
+		t.Errorf("0.1 - 0.2 = %s, want -0.1", got)
+	}
+	if got := MustParseDecimal("1.5").Mul(MustParseDecimal("-0.25")).String(); got != "-0.375" {
+		t.Errorf("1.5 * -0.25 = %s, want -0.375", got)
+	}
+
+	var null Decimal
+	for name, d := range map[string]Decimal{"add": a.Add(null), "sub": null.Sub(a), "mul": a.Mul(null)} {
+		if d.Valid() {
+			t.Errorf("%s with null = %s, want null", name, d)
+		}
+	}
+	if f, ok := null.Float64(); ok || f != 0 {
+		t.Errorf("null Float64() = %v, %v", f, ok)
+	}
+	if null.Sign() != 0 || null.Rat() != nil {
+		t.Error("null decimal reports a value")
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	var null Decimal
+	tests := []struct {
+		a, b Decimal
+		want int
+	}{
+		{MustParseDecimal("1.10"), MustParseDecimal("1.1"), 0},
+		{MustParseDecimal("-2"), MustParseDecimal("1"), -1},
+		{MustParseDecimal("1e3"), MustParseDecimal("999.99"), 1},
+		{null, null, 0},
+		{null, MustParseDecimal("-1e100"), -1},
+		{MustParseDecimal("0"), null, 1},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Cmp(tt.b); got != tt.want {
+			t.Errorf("%s.Cmp(%s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		Number Decimal `json:"number"`
+		Text   Decimal `json:"text"`
+		Null   Decimal `json:"null"`
+		None   Decimal `json:"none"`
+	}
+	if err := json.Unmarshal([]byte(`{"number": 1.25, "text": "-0.5", "null": null, "none": "None"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Number.String() != "1.25" || v.Text.String() != "-0.5" || v.Null.Valid() || v.None.Valid() {
+		t.Errorf("Unmarshal() = %+v", v)
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"number":1.25,"text":-0.5,"null":null,"none":null}`; string(out) != want {
+		t.Errorf("Marshal() = %s, want %s", out, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"number": "abc"}`), &v); err == nil {
+		t.Error("Unmarshal() of a non-numeric string succeeded")
+	}
+}
@@ -0,0 +1,49 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// envelopeKeys are the top-level keys Alpha Vantage uses to report soft errors
+var envelopeKeys = []string{"Error Message", "Information", "Note"}
+
+// CheckEnvelope inspects a raw response body for Alpha Vantage soft errors
+func CheckEnvelope(data []byte, params map[string]string) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		// Not a JSON object, let the caller's decoder report the problem
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &fields); err != nil {
+		return nil
+	}
+
+	// Fundamental endpoints answer an unknown symbol with an empty object
+	if len(fields) == 0 {
+		return &APIError{Kind: ErrInvalidSymbol, Function: params["function"]}
+	}
+
+	for _, key := range envelopeKeys {
+		raw, ok := fields[key]
+		if !ok {
+			continue
+		}
+		// Informational notes can accompany real data, only a bare envelope is an error
+		if len(fields) > 1 {
+			continue
+		}
+
+		var message string
+		_ = json.Unmarshal(raw, &message)
+		return &APIError{
+			Kind:     classifyMessage(key, message, params),
+			Function: params["function"],
+			Message:  message,
+		}
+	}
+
+	return nil
+}
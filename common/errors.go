@@ -0,0 +1,91 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Sentinel errors for the soft failures Alpha Vantage reports inside HTTP 200 responses
+var (
+	ErrRateLimited      = errors.New("rate limited")
+	ErrInvalidSymbol    = errors.New("invalid symbol")
+	ErrPremiumRequired  = errors.New("premium endpoint")
+	ErrInvalidParameter = errors.New("invalid parameter")
+)
+
+// APIError is a soft error decoded from an Alpha Vantage response body
+type APIError struct {
+	Kind     error  // One of the Err* sentinels above
+	Function string // Upstream function that was called (e.g., BALANCE_SHEET)
+	Message  string // Message returned by Alpha Vantage, if any
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	msg := e.Kind.Error()
+	if e.Function != "" {
+		msg = fmt.Sprintf("%s: %s", e.Function, msg)
+	}
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	}
+	return msg
+}
+
+// Unwrap allows errors.Is to match against the sentinel kind
+func (e *APIError) Unwrap() error {
+	return e.Kind
+}
+
+// Retryable reports whether Alpha Vantage asked to retry later. Per-minute
+// rate limit notes are transient while daily limits and other kinds are not.
+func (e *APIError) Retryable() bool {
+	if !errors.Is(e.Kind, ErrRateLimited) {
+		return false
+	}
+	lower := strings.ToLower(e.Message)
+	return !strings.Contains(lower, "per day") && !strings.Contains(lower, "daily")
+}
+
+// StatusError is returned when Alpha Vantage answers with a non-2xx status code
+type StatusError struct {
+	StatusCode int
+	Function   string
+	RetryAfter time.Duration // Parsed Retry-After header capped at the retry MaxDelay, zero if absent
+}
+
+// Error implements the error interface
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: upstream returned status %d", e.Function, e.StatusCode)
+}
+
+// classifyMessage maps an Alpha Vantage envelope key and message to an error kind
+func classifyMessage(key, message string, params map[string]string) error {
+	lower := strings.ToLower(message)
+
+	switch key {
+	case "Note":
+		// Notes are only sent when the call frequency limit is exceeded
+		return ErrRateLimited
+	case "Information":
+		switch {
+		case strings.Contains(lower, "premium"):
+			return ErrPremiumRequired
+		case strings.Contains(lower, "rate limit"),
+			strings.Contains(lower, "call frequency"),
+			strings.Contains(lower, "requests per day"),
+			strings.Contains(lower, "per minute"):
+			return ErrRateLimited
+		}
+		return ErrInvalidParameter
+	default:
+		// "Error Message" carries the same generic text for bad symbols and bad
+		// parameters, so a call that names a symbol is reported as an invalid symbol
+		if params["symbol"] != "" && strings.Contains(lower, "invalid api call") {
+			return ErrInvalidSymbol
+		}
+		return ErrInvalidParameter
+	}
+}
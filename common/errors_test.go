@@ -0,0 +1,82 @@
+package common
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckEnvelope(t *testing.T) {
+	symbol := map[string]string{"function": "TIME_SERIES_DAILY", "symbol": "NOPE"}
+	noSymbol := map[string]string{"function": "TOP_GAINERS_LOSERS"}
+
+	tests := []struct {
+		name   string
+		body   string
+		params map[string]string
+		want   error // nil when the body is not an error envelope
+	}{
+		{"data", `{"Meta Data": {}, "Time Series (Daily)": {}}`, symbol, nil},
+		{"not an object", `[1, 2]`, symbol, nil},
+		{"csv", "timestamp,open\n2024-01-02,1", symbol, nil},
+		{"malformed json", `{"Note": `, symbol, nil},
+		{"empty body", ``, symbol, nil},
+		{"empty object", `{}`, symbol, ErrInvalidSymbol},
+		{"empty object with spaces", " \n{ }\n", symbol, ErrInvalidSymbol},
+		{"note", `{"Note": "Thank you for using Alpha Vantage! Our standard API call frequency is 5 calls per minute."}`, symbol, ErrRateLimited},
+		{"information rate limit", `{"Information": "We have detected your API key and our standard API rate limit is 25 requests per day."}`, symbol, ErrRateLimited},
+		{"information per minute", `{"Information": "Please consider spreading out your free API requests more sparingly (1 request per minute)."}`, symbol, ErrRateLimited},
+		{"information premium", `{"Information": "Thank you for using Alpha Vantage! This is a premium endpoint."}`, symbol, ErrPremiumRequired},
+		{"information other", `{"Information": "The **demo** API key is for demo purposes only."}`, symbol, ErrInvalidParameter},
+		{"error message with symbol", `{"Error Message": "Invalid API call. Please retry or visit the documentation (https://www.alphavantage.co/documentation/) for TIME_SERIES_DAILY."}`, symbol, ErrInvalidSymbol},
+		{"error message without symbol", `{"Error Message": "Invalid API call. Please retry or visit the documentation."}`, noSymbol, ErrInvalidParameter},
+		{"error message other", `{"Error Message": "the parameter apikey is invalid or missing."}`, symbol, ErrInvalidParameter},
+		{"information beside data", `{"Information": "Data is delayed.", "Symbol": "IBM"}`, symbol, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckEnvelope([]byte(tt.body), tt.params)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("CheckEnvelope() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("CheckEnvelope() = %v, want %v", err, tt.want)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.Function != tt.params["function"] {
+				t.Errorf("CheckEnvelope() = %#v, want an *APIError for %s", err, tt.params["function"])
+			}
+		})
+	}
+}
+
+func TestAPIErrorRetryable(t *testing.T) {
+	tests := []struct {
+		err  *APIError
+		want bool
+	}{
+		{&APIError{Kind: ErrRateLimited, Message: "Our standard API call frequency is 5 calls per minute."}, true},
+		{&APIError{Kind: ErrRateLimited, Message: "Our standard API rate limit is 25 requests per day."}, false},
+		{&APIError{Kind: ErrRateLimited, Message: "You have reached your daily limit."}, false},
+		{&APIError{Kind: ErrPremiumRequired, Message: "This is a premium endpoint."}, false},
+		{&APIError{Kind: ErrInvalidSymbol}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Retryable(); got != tt.want {
+			t.Errorf("Retryable(%q) = %v, want %v", tt.err.Message, got, tt.want)
+		}
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	err := &APIError{Kind: ErrRateLimited, Function: "OVERVIEW", Message: "slow down"}
+	if got, want := err.Error(), "OVERVIEW: rate limited: slow down"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got, want := (&APIError{Kind: ErrInvalidSymbol}).Error(), "invalid symbol"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
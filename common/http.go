@@ -1,11 +1,51 @@
 package common
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"log"
+	"net"
 	"net/http"
 	"net/url"
+	"time"
 )
 
+// Client performs requests against the Alpha Vantage API
+type Client struct {
+	BaseURL    string
+	APIKey     string
+	HTTPClient *http.Client
+	Limiter    *RateLimiter // Optional, nil disables throttling
+	Retry      RetryPolicy
+	Cache      Cache     // Optional, nil disables caching
+	CacheTTL   TTLPolicy // Optional, nil uses DefaultTTL
+
+	flights flightGroup
+	metrics metrics
+}
+
+// NewHTTPClient returns an http.Client with sensible timeouts for upstream calls.
+// A nil transport uses a clone of http.DefaultTransport.
+func NewHTTPClient(timeout time.Duration, transport http.RoundTripper) *http.Client {
+	if transport == nil {
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.DialContext = (&net.Dialer{
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+		t.TLSHandshakeTimeout = 5 * time.Second
+		t.ResponseHeaderTimeout = timeout
+		transport = t
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
 // BuildRequestURL builds the complete URL with query parameters
 func BuildRequestURL(baseURL string, params map[string]string) (string, error) {
 	u, err := url.Parse(baseURL)
@@ -22,31 +62,145 @@ func BuildRequestURL(baseURL string, params map[string]string) (string, error) {
 	return u.String(), nil
 }
 
-// GetAPIRequest performs the Get HTTP request to an API and returns the parsed JSON response
-func GetAPIRequest(baseURL string, params map[string]string) (io.ReadCloser, error) {
-	return MakeAPIRequest(baseURL, params, "GET")
+// Get performs a GET request with the given query parameters and returns the
+// response body, served from cache when a fresh copy is available. The body
+// may be shared with other callers and must not be modified.
+// The API key is added by the client and must not be in params.
+func (c *Client) Get(ctx context.Context, params map[string]string) ([]byte, error) {
+	c.metrics.requests.Add(1)
+	status := cacheStatusFrom(ctx)
+
+	key := CacheKey(params)
+	ttl := c.ttl(params)
+	cacheable := c.Cache != nil && ttl > 0
+
+	if cacheable {
+		if data, ok := c.Cache.Get(key); ok {
+			c.metrics.cacheHits.Add(1)
+			status.record(true)
+			return data, nil
+		}
+	}
+
+	c.metrics.cacheMisses.Add(1)
+	status.record(false)
+
+	// Concurrent identical requests share a single upstream fetch
+	data, shared, err := c.flights.do(ctx, key, func(ctx context.Context) ([]byte, error) {
+		c.metrics.fetches.Add(1)
+		data, err := c.getWithRetry(ctx, params)
+		if err == nil && cacheable {
+			c.Cache.Set(key, data, ttl)
+		}
+		return data, err
+	})
+	if shared {
+		c.metrics.coalesced.Add(1)
+	}
+	return data, err
+}
+
+// Stats returns a snapshot of the client's request counters
+func (c *Client) Stats() Stats {
+	return c.metrics.snapshot()
+}
+
+// ttl returns how long the response for params may be cached
+func (c *Client) ttl(params map[string]string) time.Duration {
+	if c.CacheTTL != nil {
+		return c.CacheTTL(params, time.Now())
+	}
+	return DefaultTTL(params, time.Now())
+}
+
+// getWithRetry performs the upstream call, retrying transient failures
+// according to the client's policy
+func (c *Client) getWithRetry(ctx context.Context, params map[string]string) ([]byte, error) {
+	function := params["function"]
+	attempts := c.Retry.attempts()
+
+	for attempt := 1; ; attempt++ {
+		started := time.Now()
+		data, err := c.fetch(ctx, params)
+		if err == nil {
+			log.Printf("upstream %s attempt %d/%d succeeded in %s", function, attempt, attempts, time.Since(started))
+			return data, nil
+		}
+
+		delay, retryable := c.Retry.retryDelay(err, attempt)
+		if !retryable || attempt >= attempts {
+			log.Printf("upstream %s attempt %d/%d failed: %v", function, attempt, attempts, err)
+			return nil, err
+		}
+
+		log.Printf("upstream %s attempt %d/%d failed: %v, retrying in %s", function, attempt, attempts, err, delay)
+		if !sleep(ctx, delay) {
+			return nil, err
+		}
+	}
 }
 
-// MakeAPIRequest performs the HTTP request to an API and returns the parsed JSON response
-func MakeAPIRequest(baseURL string, params map[string]string, apiMethod string) (io.ReadCloser, error) {
-	client := &http.Client{}
+// GetJSON performs a GET request and decodes the JSON response into v
+func (c *Client) GetJSON(ctx context.Context, params map[string]string, v interface{}) error {
+	data, err := c.Get(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// fetch performs a single upstream call and checks the body for soft errors
+func (c *Client) fetch(ctx context.Context, params map[string]string) ([]byte, error) {
+	// Wait for an upstream call slot
+	if err := c.Limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	query := make(map[string]string, len(params)+1)
+	for key, value := range params {
+		query[key] = value
+	}
+	query["apikey"] = c.APIKey
 
 	// Build URL with query parameters
-	fullURL, err := BuildRequestURL(baseURL, params)
+	fullURL, err := BuildRequestURL(c.BaseURL, query)
 	if err != nil {
 		return nil, err
 	}
 
 	// Create request
-	req, err := http.NewRequest(apiMethod, fullURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	// Execute request
-	resp, err := client.Do(req)
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		// The URL holds the API key, only report the underlying cause
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("%s: %w", params["function"], err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &StatusError{
+			StatusCode: resp.StatusCode,
+			Function:   params["function"],
+			RetryAfter: c.Retry.parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	data, err := io.ReadAll(resp.Body)
 	if err != nil {
+		return nil, fmt.Errorf("%s: %w", params["function"], err)
+	}
+
+	if err := CheckEnvelope(data, params); err != nil {
 		return nil, err
 	}
-	return resp.Body, nil
+	return data, nil
 }
@@ -0,0 +1,96 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeUpstream serves handler as Alpha Vantage and counts the calls it receives
+type fakeUpstream struct {
+	*httptest.Server
+	calls atomic.Int64
+}
+
+func newFakeUpstream(t *testing.T, handler func(w http.ResponseWriter, r *http.Request, call int64)) *fakeUpstream {
+	t.Helper()
+	f := &fakeUpstream{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r, f.calls.Add(1))
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// newTestClient returns a client pointed at the fake upstream without limiter, retries or cache
+func newTestClient(upstream *fakeUpstream) *Client {
+	return &Client{
+		BaseURL:    upstream.URL,
+		APIKey:     "test",
+		HTTPClient: NewHTTPClient(5*time.Second, nil),
+	}
+}
+
+func TestClientSoftErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]string
+		body   string
+		want   error
+	}{
+		{"data", map[string]string{"function": "OVERVIEW", "symbol": "IBM"}, `{"Symbol": "IBM"}`, nil},
+		{"note", map[string]string{"function": "OVERVIEW", "symbol": "IBM"}, `{"Note": "Thank you for using Alpha Vantage! Our standard API call frequency is 5 calls per minute."}`, ErrRateLimited},
+		{"premium", map[string]string{"function": "REALTIME_OPTIONS", "symbol": "IBM"}, `{"Information": "This is a premium endpoint."}`, ErrPremiumRequired},
+		{"daily limit", map[string]string{"function": "OVERVIEW", "symbol": "IBM"}, `{"Information": "You have reached the rate limit of 25 requests per day."}`, ErrRateLimited},
+		{"bad symbol", map[string]string{"function": "TIME_SERIES_DAILY", "symbol": "NOPE"}, `{"Error Message": "Invalid API call. Please retry or visit the documentation."}`, ErrInvalidSymbol},
+		{"bad parameter", map[string]string{"function": "TIME_SERIES_DAILY"}, `{"Error Message": "Invalid API call. Please retry or visit the documentation."}`, ErrInvalidParameter},
+		{"empty object", map[string]string{"function": "OVERVIEW", "symbol": "NOPE"}, `{}`, ErrInvalidSymbol},
+		{"note beside data", map[string]string{"function": "OVERVIEW", "symbol": "IBM"}, `{"Symbol": "IBM", "Information": "Data is delayed."}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := newFakeUpstream(t, func(w http.ResponseWriter, r *http.Request, call int64) {
+				fmt.Fprint(w, tt.body)
+			})
+			_, err := newTestClient(upstream).Get(context.Background(), tt.params)
+			if tt.want == nil && err != nil {
+				t.Fatalf("Get() error = %v, want nil", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("Get() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientSendsAPIKeyOnlyUpstream(t *testing.T) {
+	upstream := newFakeUpstream(t, func(w http.ResponseWriter, r *http.Request, call int64) {
+		if got := r.URL.Query().Get("apikey"); got != "test" {
+			t.Errorf("apikey = %q, want test", got)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	_, err := newTestClient(upstream).Get(context.Background(), map[string]string{"function": "OVERVIEW"})
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Get() error = %v, want status 400", err)
+	}
+}
+
+// waitFor polls cond until it holds or a second has passed
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if cond() {
+			return
+		}
+	}
+	t.Fatal("condition not met within a second")
+}
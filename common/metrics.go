@@ -0,0 +1,37 @@
+package common
+
+import "sync/atomic"
+
+// Stats is a snapshot of the upstream request counters
+type Stats struct {
+	Requests    int64   `json:"requests"`     // Calls made through Client.Get
+	CacheHits   int64   `json:"cache_hits"`   // Requests served from cache
+	CacheMisses int64   `json:"cache_misses"` // Requests that needed upstream data
+	Fetches     int64   `json:"fetches"`      // Upstream fetches started, retries included once
+	Coalesced   int64   `json:"coalesced"`    // Requests that joined a fetch already in flight
+	DedupRatio  float64 `json:"dedup_ratio"`  // Share of cache misses answered by another request's fetch
+}
+
+// metrics holds the live counters behind Stats
+type metrics struct {
+	requests    atomic.Int64
+	cacheHits   atomic.Int64
+	cacheMisses atomic.Int64
+	fetches     atomic.Int64
+	coalesced   atomic.Int64
+}
+
+// snapshot returns the current counter values
+func (m *metrics) snapshot() Stats {
+	stats := Stats{
+		Requests:    m.requests.Load(),
+		CacheHits:   m.cacheHits.Load(),
+		CacheMisses: m.cacheMisses.Load(),
+		Fetches:     m.fetches.Load(),
+		Coalesced:   m.coalesced.Load(),
+	}
+	if total := stats.Fetches + stats.Coalesced; total > 0 {
+		stats.DedupRatio = float64(stats.Coalesced) / float64(total)
+	}
+	return stats
+}
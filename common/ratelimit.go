@@ -0,0 +1,183 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExceeded is returned when a request cannot obtain an upstream call
+// slot before its context deadline
+var ErrQuotaExceeded = fmt.Errorf("%w: client-side quota exhausted", ErrRateLimited)
+
+// Quota reports the configured budgets and the calls currently available
+type Quota struct {
+	PerMinute          int `json:"per_minute"`
+	PerDay             int `json:"per_day"`
+	RemainingPerMinute int `json:"remaining_per_minute"`
+	RemainingPerDay    int `json:"remaining_per_day"`
+}
+
+// tokenBucket is a single refilling budget, tokens may go negative to queue callers
+type tokenBucket struct {
+	capacity float64
+	rate     float64 // tokens per second
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(capacity int, period time.Duration, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		capacity: float64(capacity),
+		rate:     float64(capacity) / period.Seconds(),
+		tokens:   float64(capacity),
+		last:     now,
+	}
+}
+
+// refill adds the tokens earned since the last update
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(b.capacity, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+}
+
+// reserve takes one token and returns how long the caller must wait for it
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.refill(now)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// remaining returns the whole tokens available right now
+func (b *tokenBucket) remaining(now time.Time) int {
+	b.refill(now)
+	if b.tokens < 0 {
+		return 0
+	}
+	return int(b.tokens)
+}
+
+// RateLimiter throttles upstream calls with per-minute and per-day token buckets.
+// A budget of zero or less disables that bucket.
+type RateLimiter struct {
+	mu        sync.Mutex
+	perMinute int
+	perDay    int
+	maxWait   time.Duration
+	minute    *tokenBucket
+	day       *tokenBucket
+}
+
+// NewRateLimiter creates a limiter with the given budgets. maxWait bounds how
+// long a caller without a context deadline may be queued.
+func NewRateLimiter(perMinute, perDay int, maxWait time.Duration) *RateLimiter {
+	now := time.Now()
+	l := &RateLimiter{
+		perMinute: perMinute,
+		perDay:    perDay,
+		maxWait:   maxWait,
+	}
+	if perMinute > 0 {
+		l.minute = newTokenBucket(perMinute, time.Minute, now)
+	}
+	if perDay > 0 {
+		l.day = newTokenBucket(perDay, 24*time.Hour, now)
+	}
+	return l
+}
+
+// Wait blocks until an upstream call may be made or the context deadline would
+// pass first, in which case ErrQuotaExceeded is returned without waiting
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	var wait time.Duration
+	for _, b := range l.buckets() {
+		if d := b.reserve(now); d > wait {
+			wait = d
+		}
+	}
+
+	if wait == 0 {
+		l.mu.Unlock()
+		return nil
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = now.Add(l.maxWait)
+	}
+	if now.Add(wait).After(deadline) {
+		l.cancel()
+		l.mu.Unlock()
+		return ErrQuotaExceeded
+	}
+	l.mu.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.cancel()
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Quota returns the configured budgets and what is left of them
+func (l *RateLimiter) Quota() Quota {
+	if l == nil {
+		return Quota{}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	quota := Quota{
+		PerMinute: l.perMinute,
+		PerDay:    l.perDay,
+	}
+	if l.minute != nil {
+		quota.RemainingPerMinute = l.minute.remaining(now)
+	}
+	if l.day != nil {
+		quota.RemainingPerDay = l.day.remaining(now)
+	}
+	return quota
+}
+
+// buckets returns the enabled buckets, callers must hold l.mu
+func (l *RateLimiter) buckets() []*tokenBucket {
+	var buckets []*tokenBucket
+	if l.minute != nil {
+		buckets = append(buckets, l.minute)
+	}
+	if l.day != nil {
+		buckets = append(buckets, l.day)
+	}
+	return buckets
+}
+
+// cancel returns a reserved token to every bucket, callers must hold l.mu
+func (l *RateLimiter) cancel() {
+	for _, b := range l.buckets() {
+		b.tokens = math.Min(b.capacity, b.tokens+1)
+	}
+}
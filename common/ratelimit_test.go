@@ -0,0 +1,102 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// drain takes every token the limiter holds right now
+func drain(t *testing.T, l *RateLimiter) {
+	t.Helper()
+	for l.Quota().RemainingPerMinute > 0 {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() while draining error = %v", err)
+		}
+	}
+}
+
+func TestRateLimiterQueuesUntilDeadline(t *testing.T) {
+	// 1200 calls per minute refill one token every 50ms
+	l := NewRateLimiter(1200, 0, time.Second)
+	drain(t, l)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	started := time.Now()
+	if err := l.Wait(ctx); err != nil {
+		t.Fatalf("Wait() error = %v, want a queued slot", err)
+	}
+	if elapsed := time.Since(started); elapsed < 30*time.Millisecond {
+		t.Errorf("Wait() returned after %s, want it to queue for the next token", elapsed)
+	}
+}
+
+func TestRateLimiterFailsFastPastDeadline(t *testing.T) {
+	l := NewRateLimiter(5, 0, time.Second)
+	drain(t, l)
+
+	// The next token is 12s away, beyond both the deadline and maxWait
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	if err := l.Wait(ctx); !errors.Is(err, ErrQuotaExceeded) || !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Wait() error = %v, want ErrQuotaExceeded", err)
+	}
+	if err := l.Wait(context.Background()); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Wait() without deadline error = %v, want ErrQuotaExceeded after maxWait", err)
+	}
+	if elapsed := time.Since(started); elapsed > 50*time.Millisecond {
+		t.Errorf("rejections took %s, want them without waiting", elapsed)
+	}
+
+	// Rejected callers hand their reservation back
+	if q := l.Quota(); q.PerMinute != 5 || q.RemainingPerMinute != 0 {
+		t.Errorf("Quota() = %+v", q)
+	}
+}
+
+func TestRateLimiterDailyBudget(t *testing.T) {
+	l := NewRateLimiter(0, 2, time.Second)
+	for i := 0; i < 2; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() %d error = %v", i, err)
+		}
+	}
+	if err := l.Wait(context.Background()); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Wait() past the daily budget error = %v, want ErrQuotaExceeded", err)
+	}
+	if q := l.Quota(); q.PerDay != 2 || q.RemainingPerDay != 0 {
+		t.Errorf("Quota() = %+v, want the daily budget spent", q)
+	}
+}
+
+func TestNilRateLimiter(t *testing.T) {
+	var l *RateLimiter
+	if err := l.Wait(context.Background()); err != nil {
+		t.Errorf("nil limiter Wait() error = %v", err)
+	}
+}
+
+func TestClientQueuesOnLimiter(t *testing.T) {
+	upstream := newFakeUpstream(t, func(w http.ResponseWriter, r *http.Request, call int64) {
+		w.Write([]byte(`{"Symbol": "IBM"}`))
+	})
+	client := newTestClient(upstream)
+	client.Limiter = NewRateLimiter(5, 0, time.Second)
+
+	params := map[string]string{"function": "OVERVIEW", "symbol": "IBM"}
+	for i := 0; i < 5; i++ {
+		if _, err := client.Get(context.Background(), params); err != nil {
+			t.Fatalf("call %d error = %v", i, err)
+		}
+	}
+	if _, err := client.Get(context.Background(), params); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("sixth call error = %v, want ErrRateLimited", err)
+	}
+	if got := upstream.calls.Load(); got != 5 {
+		t.Errorf("upstream calls = %d, want 5", got)
+	}
+}
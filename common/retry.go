@@ -0,0 +1,102 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how transient upstream failures are retried
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts including the first, values below 1 mean 1
+	BaseDelay   time.Duration // Backoff before the second attempt
+	MaxDelay    time.Duration // Upper bound for a single backoff
+}
+
+// attempts returns the number of attempts the policy allows
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns the delay before the given retry (1-based) using full jitter
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	ceiling := p.BaseDelay << (retry - 1)
+	if ceiling <= 0 || (p.MaxDelay > 0 && ceiling > p.MaxDelay) {
+		ceiling = p.MaxDelay
+	}
+	return time.Duration(rand.Int64N(int64(ceiling) + 1))
+}
+
+// retryDelay returns how long to wait before retrying err, or false when err is not transient
+func (p RetryPolicy) retryDelay(err error, retry int) (time.Duration, bool) {
+	// The local limiter already waited as long as the caller allows
+	if errors.Is(err, ErrQuotaExceeded) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return 0, false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		if statusErr.StatusCode != http.StatusTooManyRequests && statusErr.StatusCode < 500 {
+			return 0, false
+		}
+		if statusErr.RetryAfter > 0 {
+			return statusErr.RetryAfter, true
+		}
+		return p.backoff(retry), true
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return p.backoff(retry), apiErr.Retryable()
+	}
+
+	// Anything else is a network level failure
+	return p.backoff(retry), true
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP
+// date, capped at MaxDelay so a far-off value cannot stall a call without a deadline
+func (p RetryPolicy) parseRetryAfter(value string) time.Duration {
+	var d time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		d = time.Duration(seconds) * time.Second
+		if d/time.Second != time.Duration(seconds) {
+			d = math.MaxInt64 // Overflowed
+		}
+	} else if at, err := http.ParseTime(value); err == nil {
+		d = max(time.Until(at), 0)
+	}
+
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// sleep waits for d unless the context is done or its deadline would pass first
+func sleep(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(d).After(deadline) {
+		return false
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,186 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int64 // Calls answered with the failure before succeeding
+		failure   func(w http.ResponseWriter)
+		wantCalls int64
+		wantErr   bool
+	}{
+		{"server error recovers", 2, func(w http.ResponseWriter) { w.WriteHeader(http.StatusServiceUnavailable) }, 3, false},
+		{"status 429 recovers", 1, func(w http.ResponseWriter) { w.WriteHeader(http.StatusTooManyRequests) }, 2, false},
+		{"per-minute note recovers", 1, func(w http.ResponseWriter) {
+			fmt.Fprint(w, `{"Note": "Our standard API call frequency is 5 calls per minute."}`)
+		}, 2, false},
+		{"attempts exhausted", 5, func(w http.ResponseWriter) { w.WriteHeader(http.StatusBadGateway) }, 3, true},
+		{"client error not retried", 5, func(w http.ResponseWriter) { w.WriteHeader(http.StatusNotFound) }, 1, true},
+		{"daily limit not retried", 5, func(w http.ResponseWriter) {
+			fmt.Fprint(w, `{"Information": "You have reached the rate limit of 25 requests per day."}`)
+		}, 1, true},
+		{"bad symbol not retried", 5, func(w http.ResponseWriter) { fmt.Fprint(w, `{}`) }, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := newFakeUpstream(t, func(w http.ResponseWriter, r *http.Request, call int64) {
+				if call <= tt.failures {
+					tt.failure(w)
+					return
+				}
+				fmt.Fprint(w, `{"Symbol": "IBM"}`)
+			})
+			client := newTestClient(upstream)
+			client.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+			_, err := client.Get(context.Background(), map[string]string{"function": "OVERVIEW", "symbol": "IBM"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := upstream.calls.Load(); got != tt.wantCalls {
+				t.Errorf("upstream calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestClientRetryStopsAtDeadline(t *testing.T) {
+	upstream := newFakeUpstream(t, func(w http.ResponseWriter, r *http.Request, call int64) {
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client := newTestClient(upstream)
+	client.Retry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	started := time.Now()
+	if _, err := client.Get(ctx, map[string]string{"function": "OVERVIEW", "symbol": "IBM"}); err == nil {
+		t.Fatal("Get() error = nil, want the upstream failure")
+	}
+	if elapsed := time.Since(started); elapsed > 500*time.Millisecond {
+		t.Errorf("Get() took %s, the 10s Retry-After is past the deadline and should not be waited for", elapsed)
+	}
+	if got := upstream.calls.Load(); got != 1 {
+		t.Errorf("upstream calls = %d, want 1", got)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 250 * time.Millisecond}
+	ceilings := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 250 * time.Millisecond, 250 * time.Millisecond}
+
+	for i, ceiling := range ceilings {
+		for n := 0; n < 100; n++ {
+			if d := policy.backoff(i + 1); d < 0 || d > ceiling {
+				t.Fatalf("backoff(%d) = %s, want within [0, %s]", i+1, d, ceiling)
+			}
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	unbounded := RetryPolicy{}
+	capped := RetryPolicy{MaxDelay: 30 * time.Second}
+
+	tests := []struct {
+		policy RetryPolicy
+		value  string
+		want   time.Duration
+	}{
+		{unbounded, "", 0},
+		{unbounded, "3", 3 * time.Second},
+		{unbounded, "-1", 0},
+		{unbounded, "0", 0},
+		{unbounded, "soon", 0},
+		{unbounded, time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0},
+		{unbounded, "86400", 24 * time.Hour},
+		{capped, "3", 3 * time.Second},
+		{capped, "86400", 30 * time.Second},
+		{capped, "99999999999999999", 30 * time.Second},
+		{capped, time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), 30 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.parseRetryAfter(tt.value); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) with MaxDelay %s = %s, want %s", tt.value, tt.policy.MaxDelay, got, tt.want)
+		}
+	}
+
+	future := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if got := unbounded.parseRetryAfter(future); got <= 0 || got > time.Minute {
+		t.Errorf("parseRetryAfter(%q) = %s, want within a minute", future, got)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		name      string
+		err       error
+		wantRetry bool
+		wantDelay time.Duration // Exact delay, or -1 for any backoff
+	}{
+		{"server error", &StatusError{StatusCode: http.StatusInternalServerError}, true, -1},
+		{"bad gateway", &StatusError{StatusCode: http.StatusBadGateway}, true, -1},
+		{"too many requests", &StatusError{StatusCode: http.StatusTooManyRequests}, true, -1},
+		{"retry after", &StatusError{StatusCode: http.StatusServiceUnavailable, RetryAfter: 500 * time.Millisecond}, true, 500 * time.Millisecond},
+		{"not found", &StatusError{StatusCode: http.StatusNotFound}, false, 0},
+		{"bad request", &StatusError{StatusCode: http.StatusBadRequest}, false, 0},
+		{"per-minute note", &APIError{Kind: ErrRateLimited, Message: "5 calls per minute"}, true, -1},
+		{"daily limit", &APIError{Kind: ErrRateLimited, Message: "25 requests per day"}, false, -1},
+		{"invalid symbol", &APIError{Kind: ErrInvalidSymbol}, false, -1},
+		{"premium", &APIError{Kind: ErrPremiumRequired}, false, -1},
+		{"local quota", ErrQuotaExceeded, false, 0},
+		{"cancelled", fmt.Errorf("OVERVIEW: %w", context.Canceled), false, 0},
+		{"deadline", fmt.Errorf("OVERVIEW: %w", context.DeadlineExceeded), false, 0},
+		{"network", fmt.Errorf("OVERVIEW: %w", errors.New("connection reset by peer")), true, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := policy.retryDelay(tt.err, 1)
+			if retry != tt.wantRetry {
+				t.Fatalf("retryDelay() retry = %v, want %v", retry, tt.wantRetry)
+			}
+			if tt.wantDelay >= 0 && delay != tt.wantDelay {
+				t.Errorf("retryDelay() delay = %s, want %s", delay, tt.wantDelay)
+			}
+			if delay < 0 || delay > time.Second {
+				t.Errorf("retryDelay() delay = %s, want within [0, 1s]", delay)
+			}
+		})
+	}
+}
+
+func TestClientRetryAfterCappedAtMaxDelay(t *testing.T) {
+	upstream := newFakeUpstream(t, func(w http.ResponseWriter, r *http.Request, call int64) {
+		if call == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"Symbol": "IBM"}`)
+	})
+	client := newTestClient(upstream)
+	client.Retry = RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+	// No deadline, an uncapped Retry-After would stall the call for an hour
+	started := time.Now()
+	if _, err := client.Get(context.Background(), map[string]string{"function": "OVERVIEW", "symbol": "IBM"}); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("Get() took %s, want the Retry-After capped at 10ms", elapsed)
+	}
+}
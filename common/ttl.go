@@ -0,0 +1,95 @@
+package common
+
+import (
+	"strings"
+	"time"
+)
+
+// TTLPolicy returns how long a response for params may be cached, zero disables caching
+type TTLPolicy func(params map[string]string, now time.Time) time.Duration
+
+// marketLocation is the time zone US equity sessions close in
+var marketLocation = loadLocation("America/New_York")
+
+// marketCloseHour is the regular session close in marketLocation
+const marketCloseHour = 16
+
+func loadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// DefaultTTL caches each Alpha Vantage function for as long as its data stays
+// unchanged, assuming sessions close at 16:00 New York time on every weekday
+func DefaultTTL(params map[string]string, now time.Time) time.Duration {
+	return CalendarTTL(NextMarketClose)(params, now)
+}
+
+// CalendarTTL returns the DefaultTTL policy with session closes taken from
+// nextClose, so a trading calendar can account for holidays and early closes
+func CalendarTTL(nextClose func(now time.Time) time.Time) TTLPolicy {
+	return func(params map[string]string, now time.Time) time.Duration {
+		return functionTTL(params, now, nextClose)
+	}
+}
+
+// functionTTL returns the TTL of params given the next session close
+func functionTTL(params map[string]string, now time.Time, nextClose func(time.Time) time.Time) time.Duration {
+	function := strings.ToUpper(params["function"])
+
+	switch function {
+	case "OVERVIEW", "ETF_PROFILE", "EARNINGS", "DIVIDENDS", "SPLITS", "SYMBOL_SEARCH":
+		return 24 * time.Hour
+	case "LISTING_STATUS":
+		// Shorter than the default symbol index refresh so refreshes see new listings
+		return 12 * time.Hour
+	case "EARNINGS_CALENDAR", "IPO_CALENDAR":
+		// Both calendars are rebuilt once a day, upcoming dates move rarely
+		return 12 * time.Hour
+	case "BALANCE_SHEET", "CASH_FLOW", "INCOME_STATEMENT":
+		return 7 * 24 * time.Hour
+	case "MARKET_STATUS":
+		// Statuses only flip at session boundaries, a short TTL keeps them close to real time
+		return time.Minute
+	case "HISTORICAL_OPTIONS":
+		// Chains of past sessions never change, the latest one until the next close
+		if date, err := time.ParseInLocation(DateLayout, params["date"], marketLocation); err == nil {
+			if date.AddDate(0, 0, 1).Before(now) {
+				return 7 * 24 * time.Hour
+			}
+		}
+		return nextClose(now).Sub(now)
+	case "TREASURY_YIELD":
+		// Daily yields are published once per session
+		return nextClose(now).Sub(now)
+	case "TIME_SERIES_INTRADAY":
+		// Months that have ended never change again
+		if month, err := time.ParseInLocation("2006-01", params["month"], marketLocation); err == nil {
+			if month.AddDate(0, 1, 0).Before(now) {
+				return 7 * 24 * time.Hour
+			}
+		}
+		return time.Minute
+	}
+
+	if strings.HasPrefix(function, "TIME_SERIES_") {
+		// Daily, weekly and monthly bars only change when a session closes
+		return nextClose(now).Sub(now)
+	}
+
+	return 0
+}
+
+// NextMarketClose returns the next weekday regular session close after now
+func NextMarketClose(now time.Time) time.Time {
+	local := now.In(marketLocation)
+	close := time.Date(local.Year(), local.Month(), local.Day(), marketCloseHour, 0, 0, 0, marketLocation)
+
+	for !close.After(now) || close.Weekday() == time.Saturday || close.Weekday() == time.Sunday {
+		close = close.AddDate(0, 0, 1)
+	}
+	return close
+}
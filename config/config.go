@@ -2,7 +2,10 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 
 // Config holds all configuration settings for the application
@@ -12,6 +15,39 @@ type Config struct {
 	AlphaVantageBaseURL string
 	DefaultAPIVersion   string
 	Port                string
+	UpstreamTimeout     time.Duration
+
+	// Upstream rate limiting, a budget of 0 disables that limit
+	RateLimitPerMinute int
+	RateLimitPerDay    int
+	RateLimitMaxWait   time.Duration // Longest a request without a deadline is queued
+
+	// Retries for transient upstream failures
+	RetryMaxAttempts int
+	RetryBaseDelay   time.Duration
+	RetryMaxDelay    time.Duration
+
+	// Response cache, an empty CacheDir keeps the cache in memory only
+	CacheMaxBytes     int
+	CacheDir          string
+	CacheDiskMaxBytes int64
+
+	// Interval at which the local symbol index is rebuilt from LISTING_STATUS, 0 disables it
+	SymbolIndexRefresh time.Duration
+
+	// JSON trading calendars of exchanges other than NYSE and NASDAQ
+	MarketCalendars []string
+
+	// Symbols the calendar endpoints narrow to with watchlist=true
+	Watchlist []string
+
+	// Continuously compounded rate used to compute option Greeks
+	RiskFreeRate float64
+
+	// Where option valuations take the risk-free rate from: fixed uses
+	// RiskFreeRate, treasury the daily Treasury yield of RiskFreeRateMaturity
+	RiskFreeRateSource   string
+	RiskFreeRateMaturity string
 }
 
 var (
@@ -23,10 +59,26 @@ var (
 func GetConfig() *Config {
 	configOnce.Do(func() {
 		config = &Config{
-			AlphaVantageAPIKey:  getEnvWithDefault("ALPHAVANTAGE_API_KEY", "demo"),
-			AlphaVantageBaseURL: "https://www.alphavantage.co/query",
-			DefaultAPIVersion:   getEnvWithDefault("API_DEFAULT_VERSION", "1.0"),
-			Port:                getEnvWithDefault("PORT", "8080"),
+			AlphaVantageAPIKey:   getEnvWithDefault("ALPHAVANTAGE_API_KEY", "demo"),
+			AlphaVantageBaseURL:  "https://www.alphavantage.co/query",
+			DefaultAPIVersion:    getEnvWithDefault("API_DEFAULT_VERSION", "1.0"),
+			Port:                 getEnvWithDefault("PORT", "8080"),
+			UpstreamTimeout:      getEnvDurationWithDefault("ALPHAVANTAGE_TIMEOUT", 30*time.Second),
+			RateLimitPerMinute:   getEnvIntWithDefault("ALPHAVANTAGE_CALLS_PER_MINUTE", 5),
+			RateLimitPerDay:      getEnvIntWithDefault("ALPHAVANTAGE_CALLS_PER_DAY", 25),
+			RateLimitMaxWait:     getEnvDurationWithDefault("ALPHAVANTAGE_MAX_QUEUE_WAIT", 30*time.Second),
+			RetryMaxAttempts:     getEnvIntWithDefault("ALPHAVANTAGE_RETRY_MAX_ATTEMPTS", 3),
+			RetryBaseDelay:       getEnvDurationWithDefault("ALPHAVANTAGE_RETRY_BASE_DELAY", 500*time.Millisecond),
+			RetryMaxDelay:        getEnvDurationWithDefault("ALPHAVANTAGE_RETRY_MAX_DELAY", 10*time.Second),
+			CacheMaxBytes:        getEnvIntWithDefault("CACHE_MAX_BYTES", 64<<20),
+			CacheDir:             getEnvWithDefault("CACHE_DIR", ""),
+			CacheDiskMaxBytes:    int64(getEnvIntWithDefault("CACHE_DISK_MAX_BYTES", 1<<30)),
+			SymbolIndexRefresh:   getEnvDurationWithDefault("SYMBOL_INDEX_REFRESH", 24*time.Hour),
+			MarketCalendars:      getEnvListWithDefault("MARKET_CALENDARS", nil),
+			Watchlist:            getEnvListWithDefault("WATCHLIST", nil),
+			RiskFreeRate:         getEnvFloatWithDefault("RISK_FREE_RATE", 0.045),
+			RiskFreeRateSource:   getEnvWithDefault("RISK_FREE_RATE_SOURCE", "fixed"),
+			RiskFreeRateMaturity: getEnvWithDefault("RISK_FREE_RATE_MATURITY", "3month"),
 		}
 	})
 	return config
@@ -39,3 +91,49 @@ func getEnvWithDefault(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvIntWithDefault returns an environment variable parsed as an int or a default value
+func getEnvIntWithDefault(key string, defaultValue int) int {
+	if value, exists := os.LookupEnv(key); exists {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getEnvFloatWithDefault returns an environment variable parsed as a float or a default value
+func getEnvFloatWithDefault(key string, defaultValue float64) float64 {
+	if value, exists := os.LookupEnv(key); exists {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getEnvDurationWithDefault returns an environment variable parsed as a duration or a default value
+func getEnvDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getEnvListWithDefault returns a comma-separated environment variable as a list or a default value
+func getEnvListWithDefault(key string, defaultValue []string) []string {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
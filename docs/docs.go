@@ -22,195 +22,403 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/fundamental/balance-sheet/{symbol}": {
+        "/v1/admin/metrics": {
             "get": {
-                "description": "Returns the balance sheet data for the specified stock symbol",
+                "description": "Returns cache hit/miss counts, upstream fetches and the ratio of requests deduplicated onto an in-flight fetch",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "fundamental"
+                    "admin"
                 ],
-                "summary": "Get balance sheet data for a specific symbol",
+                "summary": "Get upstream request metrics",
                 "parameters": [
                     {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
                         "type": "string",
-                        "description": "Stock symbol (e.g., AAPL, MSFT)",
-                        "name": "symbol",
-                        "in": "path",
-                        "required": true
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Successful operation",
                         "schema": {
-                            "$ref": "#/definitions/alphavantage.BalanceSheetResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/alphavantage.MetricsResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/fundamental/cash-flow/{symbol}": {
+        "/v1/admin/quota": {
             "get": {
-                "description": "Returns the cash flow data for the specified stock symbol",
+                "description": "Returns the configured per-minute and per-day budgets and the calls still available",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "fundamental"
+                    "admin"
                 ],
-                "summary": "Get cash flow data for a specific symbol",
+                "summary": "Get the remaining Alpha Vantage call budget",
                 "parameters": [
                     {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
                         "type": "string",
-                        "description": "Stock symbol (e.g., AAPL, MSFT)",
-                        "name": "symbol",
-                        "in": "path",
-                        "required": true
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Successful operation",
                         "schema": {
-                            "$ref": "#/definitions/alphavantage.CashFlowResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/alphavantage.QuotaResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/fundamental/company-overview/{symbol}": {
+        "/v1/analytics/fixed": {
             "get": {
-                "description": "Returns the company overview data for the specified stock symbol (sector, industry, PE ratio, EBITDA, and more)",
+                "description": "Computes statistics of daily returns over the whole range locally from cached daily series, modeled on ANALYTICS_FIXED_WINDOW. Symbols are aligned on the dates all of them traded, symbols whose series cannot be fetched are listed in missing_symbols. CSV and NDJSON output list one row per calculation and symbol.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "fundamental"
+                    "analytics"
                 ],
-                "summary": "Get company overview data for a specific symbol",
+                "summary": "Compute return statistics over a fixed window",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Stock symbol (e.g., AAPL, MSFT)",
-                        "name": "symbol",
-                        "in": "path",
+                        "description": "Comma-separated symbols (e.g., AAPL,MSFT,IBM)",
+                        "name": "symbols",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "full, a period back from the latest bar (e.g., 5day, 6month, 2year) or a start and end date (YYYY-MM-DD or YYYY-MM) as two values",
+                        "name": "range",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "open",
+                            "high",
+                            "low",
+                            "close"
+                        ],
+                        "type": "string",
+                        "default": "close",
+                        "description": "Price returns are computed from",
+                        "name": "ohlc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated MIN, MAX, MEAN, MEDIAN, CUMULATIVE_RETURN, VARIANCE, STDDEV, MAX_DRAWDOWN, HISTOGRAM, AUTOCORRELATION, COVARIANCE, CORRELATION with options such as STDDEV(annualized=true), HISTOGRAM(bins=20) up to 1000 bins, AUTOCORRELATION(lag=2), CORRELATION(method=kendall)",
+                        "name": "calculations",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Successful operation",
                         "schema": {
-                            "$ref": "#/definitions/alphavantage.CompanyOverviewResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/alphavantage.AnalyticsResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/analytics.FixedWindow"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/fundamental/income-statement/{symbol}": {
+        "/v1/analytics/sliding": {
             "get": {
-                "description": "Returns the income statement data for the specified stock symbol",
+                "description": "Computes statistics of daily returns over every window of window_size returns locally from cached daily series, modeled on ANALYTICS_SLIDING_WINDOW. Each window is dated with its last day, pairwise results are keyed by SYMBOL1/SYMBOL2. Symbols whose series cannot be fetched are listed in missing_symbols. CSV and NDJSON output list one row per calculation, symbol and window.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "fundamental"
+                    "analytics"
                 ],
-                "summary": "Get income statement data for a specific symbol",
+                "summary": "Compute return statistics over sliding windows",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Stock symbol (e.g., AAPL, MSFT)",
-                        "name": "symbol",
-                        "in": "path",
+                        "description": "Comma-separated symbols (e.g., AAPL,MSFT,IBM)",
+                        "name": "symbols",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "full, a period back from the latest bar (e.g., 5day, 6month, 2year) or a start and end date (YYYY-MM-DD or YYYY-MM) as two values",
+                        "name": "range",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "open",
+                            "high",
+                            "low",
+                            "close"
+                        ],
+                        "type": "string",
+                        "default": "close",
+                        "description": "Price returns are computed from",
+                        "name": "ohlc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Returns per window, at most 252",
+                        "name": "window_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated MEAN, MEDIAN, CUMULATIVE_RETURN, VARIANCE, STDDEV, COVARIANCE, CORRELATION with options such as STDDEV(annualized=true), CORRELATION(method=spearman)",
+                        "name": "calculations",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Successful operation",
                         "schema": {
-                            "$ref": "#/definitions/alphavantage.IncomeStatementResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/alphavantage.AnalyticsResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/analytics.SlidingWindow"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/news/sentiment": {
+        "/v1/calendar/earnings": {
             "get": {
-                "description": "Returns news articles and sentiment analysis based on tickers, topics, and time range",
+                "description": "Returns the earnings reports expected within the horizon from EARNINGS_CALENDAR, ordered by report date. Request text/calendar or format=ics to subscribe from a calendar app.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "text/calendar"
                 ],
                 "tags": [
-                    "news"
+                    "calendar"
                 ],
-                "summary": "Get news and sentiment data for specified parameters",
+                "summary": "Get upcoming earnings reports",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Comma-separated list of stock symbols (e.g., AAPL,MSFT)",
-                        "name": "tickers",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comma-separated list of topics",
-                        "name": "topics",
+                        "description": "Stock symbol (e.g., AAPL), all companies when omitted",
+                        "name": "symbol",
                         "in": "query"
                     },
                     {
+                        "enum": [
+                            "3month",
+                            "6month",
+                            "12month"
+                        ],
                         "type": "string",
-                        "description": "Start time in YYYYMMDDTHHMM format",
-                        "name": "time_from",
+                        "default": "3month",
+                        "description": "How far ahead to look",
+                        "name": "horizon",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "End time in YYYYMMDDTHHMM format",
-                        "name": "time_to",
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Only list symbols of the configured WATCHLIST",
+                        "name": "watchlist",
                         "in": "query"
                     },
                     {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson",
+                            "ics"
+                        ],
                         "type": "string",
-                        "description": "Sort order: LATEST, EARLIEST, or RELEVANCE",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of results (default: 50, max: 1000)",
-                        "name": "limit",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
                         "in": "query"
                     }
                 ],
@@ -218,69 +426,92 @@ const docTemplate = `{
                     "200": {
                         "description": "Successful operation",
                         "schema": {
-                            "$ref": "#/definitions/alphavantage.NewsAndSentimentResponse"
+                            "$ref": "#/definitions/alphavantage.EarningsCalendarResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/timeseries/{symbol}": {
+        "/v1/calendar/ipo": {
             "get": {
-                "description": "Returns time series data for the specified stock symbol",
+                "description": "Returns the initial public offerings expected in the next three months from IPO_CALENDAR, ordered by IPO date. Request text/calendar or format=ics to subscribe from a calendar app.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "text/calendar"
                 ],
                 "tags": [
-                    "timeseries"
+                    "calendar"
                 ],
-                "summary": "Get time series data for a specific symbol",
+                "summary": "Get upcoming IPOs",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Stock symbol (e.g., AAPL, MSFT)",
-                        "name": "symbol",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "TIME_SERIES_DAILY",
-                            "TIME_SERIES_WEEKLY",
-                            "TIME_SERIES_MONTHLY"
-                        ],
-                        "type": "string",
-                        "default": "TIME_SERIES_DAILY",
-                        "description": "Time series function",
-                        "name": "function",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "compact",
-                            "full"
-                        ],
-                        "type": "string",
-                        "default": "compact",
-                        "description": "Amount of data to return",
-                        "name": "outputsize",
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Only list symbols of the configured WATCHLIST",
+                        "name": "watchlist",
                         "in": "query"
                     },
                     {
                         "enum": [
                             "json",
-                            "csv"
+                            "csv",
+                            "ndjson",
+                            "ics"
                         ],
                         "type": "string",
                         "default": "json",
-                        "description": "Data type for response",
-                        "name": "datatype",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
                         "in": "query"
                     }
                 ],
@@ -288,91 +519,411 @@ const docTemplate = `{
                     "200": {
                         "description": "Successful operation",
                         "schema": {
-                            "$ref": "#/definitions/alphavantage.TimeSeriesResponse"
+                            "$ref": "#/definitions/alphavantage.IPOCalendarResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/timeseries/{symbol}/{interval}": {
+        "/v1/etf/{symbol}": {
             "get": {
-                "description": "Returns intraday time series data with the specified interval",
+                "description": "Returns the net assets, expense ratio, turnover, dividend yield, inception date, sector weights and holdings of an ETF from ETF_PROFILE. Ratios and weights are fractions. CSV and NDJSON output list the holdings.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "timeseries"
+                    "etf"
                 ],
-                "summary": "Get intraday time series data with specific interval",
+                "summary": "Get the profile and holdings of an ETF",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Stock symbol (e.g., AAPL, MSFT)",
+                        "description": "ETF symbol (e.g., QQQ)",
                         "name": "symbol",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "enum": [
-                            "1min",
-                            "5min",
-                            "15min",
-                            "30min",
-                            "60min"
-                        ],
-                        "type": "string",
-                        "description": "Time interval for data",
-                        "name": "interval",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Serve the original Alpha Vantage strings instead of typed values",
+                        "name": "raw",
+                        "in": "query"
                     },
                     {
                         "enum": [
-                            "compact",
-                            "full"
+                            "json",
+                            "csv",
+                            "ndjson"
                         ],
                         "type": "string",
-                        "default": "compact",
-                        "description": "Amount of data to return",
-                        "name": "outputsize",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/alphavantage.ETFProfileResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/fundamental.ParsedETFProfile"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/etf/{symbol}/lookthrough": {
+            "get": {
+                "description": "Sums the weights of the ETF's largest holdings by the sector and country of each holding's company overview. Holdings without an overview, such as cash or other funds, are UNCLASSIFIED. Holdings whose overview fails are UNCLASSIFIED too and listed in missing with partial set, holdings left after a rate limit are not fetched. Every holding looked through costs an OVERVIEW call unless it is cached. CSV and NDJSON output list the holdings with their sector and country.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "etf"
+                ],
+                "summary": "Get the look-through sector and country exposure of an ETF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETF symbol (e.g., QQQ)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 50,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Largest holdings to look through",
+                        "name": "holdings",
                         "in": "query"
                     },
                     {
                         "enum": [
                             "json",
-                            "csv"
+                            "csv",
+                            "ndjson"
                         ],
                         "type": "string",
                         "default": "json",
-                        "description": "Data type for response",
-                        "name": "datatype",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ETFLookthroughResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fundamental/balance-sheet/{symbol}": {
+            "get": {
+                "description": "Returns the balance sheet data for the specified stock symbol",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "fundamental"
+                ],
+                "summary": "Get balance sheet data for a specific symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock symbol (e.g., AAPL, MSFT)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "boolean",
                         "default": false,
-                        "description": "Whether to include extended hours data",
-                        "name": "extended_hours",
+                        "description": "Serve the original Alpha Vantage strings instead of typed values",
+                        "name": "raw",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
                         "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/alphavantage.BalanceSheetResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/fundamental.ParsedBalanceSheet"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fundamental/cash-flow/{symbol}": {
+            "get": {
+                "description": "Returns the cash flow data for the specified stock symbol",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "fundamental"
+                ],
+                "summary": "Get cash flow data for a specific symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock symbol (e.g., AAPL, MSFT)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "boolean",
-                        "default": true,
-                        "description": "Whether to adjust for split and dividend events",
-                        "name": "adjusted",
+                        "default": false,
+                        "description": "Serve the original Alpha Vantage strings instead of typed values",
+                        "name": "raw",
                         "in": "query"
                     },
                     {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
                         "type": "string",
-                        "description": "Month for historical intraday data (YYYY-MM format)",
-                        "name": "month",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
                         "in": "query"
                     }
                 ],
@@ -380,261 +931,2915 @@ const docTemplate = `{
                     "200": {
                         "description": "Successful operation",
                         "schema": {
-                            "$ref": "#/definitions/alphavantage.TimeSeriesResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/alphavantage.CashFlowResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/fundamental.ParsedCashFlow"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "alphavantage.BalanceSheetResponse": {
-            "description": "Balance sheet response data structure",
+        },
+        "/v1/fundamental/company-overview/{symbol}": {
+            "get": {
+                "description": "Returns the company overview data for the specified stock symbol (sector, industry, PE ratio, EBITDA, and more)",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "fundamental"
+                ],
+                "summary": "Get company overview data for a specific symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock symbol (e.g., AAPL, MSFT)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Serve the original Alpha Vantage strings instead of typed values",
+                        "name": "raw",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/alphavantage.CompanyOverviewResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/fundamental.ParsedCompanyOverview"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fundamental/dividends/{symbol}": {
+            "get": {
+                "description": "Returns every dividend with its ex-dividend, declaration, record and payment dates, the annual totals, 1/3/5/10 year growth rates and the streak of consecutive yearly increases. CSV and NDJSON output list the dividends.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "fundamental"
+                ],
+                "summary": "Get dividend history for a specific symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock symbol (e.g., AAPL, MSFT)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Serve the original Alpha Vantage strings instead of typed values",
+                        "name": "raw",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/alphavantage.DividendsResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/fundamental.ParsedDividends"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fundamental/earnings/{symbol}": {
+            "get": {
+                "description": "Returns annual and quarterly reported and estimated EPS with the trailing four quarter EPS and the current beat or miss streak. CSV and NDJSON output list the quarterly earnings.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "fundamental"
+                ],
+                "summary": "Get EPS history for a specific symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock symbol (e.g., AAPL, MSFT)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Serve the original Alpha Vantage strings instead of typed values",
+                        "name": "raw",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/alphavantage.EarningsResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/fundamental.ParsedEarnings"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fundamental/income-statement/{symbol}": {
+            "get": {
+                "description": "Returns the income statement data for the specified stock symbol",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "fundamental"
+                ],
+                "summary": "Get income statement data for a specific symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock symbol (e.g., AAPL, MSFT)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Serve the original Alpha Vantage strings instead of typed values",
+                        "name": "raw",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/alphavantage.IncomeStatementResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/fundamental.ParsedIncomeStatement"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/fundamental/splits/{symbol}": {
+            "get": {
+                "description": "Returns every stock split with its effective date and split factor",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "fundamental"
+                ],
+                "summary": "Get split history for a specific symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock symbol (e.g., AAPL, MSFT)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Serve the original Alpha Vantage strings instead of typed values",
+                        "name": "raw",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/alphavantage.SplitsResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/fundamental.ParsedSplits"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/indicators/{symbol}/{name}": {
+            "get": {
+                "description": "Computes the indicator locally from the cached time series, so any number of indicators on one symbol cost a single upstream call. Bars before the indicator has enough history are left out. Available indicators: sma, ema, wma, dema, tema, rsi, macd, bbands, atr, stoch, obv and vwap (intraday only).",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "indicators"
+                ],
+                "summary": "Compute a technical indicator for a symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock symbol (e.g., AAPL, MSFT)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "sma",
+                            "ema",
+                            "wma",
+                            "dema",
+                            "tema",
+                            "rsi",
+                            "macd",
+                            "bbands",
+                            "atr",
+                            "stoch",
+                            "obv",
+                            "vwap"
+                        ],
+                        "type": "string",
+                        "description": "Indicator name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "1min",
+                            "5min",
+                            "15min",
+                            "30min",
+                            "60min",
+                            "daily",
+                            "weekly",
+                            "monthly"
+                        ],
+                        "type": "string",
+                        "default": "daily",
+                        "description": "Bar interval",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "compact",
+                            "full"
+                        ],
+                        "type": "string",
+                        "default": "compact",
+                        "description": "Size of the underlying series",
+                        "name": "outputsize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Look-back period in bars, at most 1000 (20 for moving averages and bbands, 14 for rsi, atr and stoch)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "open",
+                            "high",
+                            "low",
+                            "close"
+                        ],
+                        "type": "string",
+                        "default": "close",
+                        "description": "Price the indicator is computed from",
+                        "name": "series_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 12,
+                        "description": "MACD fast EMA period",
+                        "name": "fast_period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 26,
+                        "description": "MACD slow EMA period",
+                        "name": "slow_period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "MACD signal period (default 9) or stoch %D period (default 3)",
+                        "name": "signal_period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 2,
+                        "description": "Bollinger band width in standard deviations",
+                        "name": "stddev",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return the latest points",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.IndicatorResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/market/status": {
+            "get": {
+                "description": "Returns each region's market hours and status with the next open and close and the seconds until the market opens (while closed) or closes (while open)",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "market"
+                ],
+                "summary": "Get the current status of global markets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated regions to include (e.g., United States,Japan)",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.MarketStatusResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/news/sentiment": {
+            "get": {
+                "description": "Returns news articles and sentiment analysis based on tickers, topics, and time range",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Get news and sentiment data for specified parameters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of stock symbols (e.g., AAPL,MSFT)",
+                        "name": "tickers",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of topics",
+                        "name": "topics",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start time in YYYYMMDDTHHMM format",
+                        "name": "time_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End time in YYYYMMDDTHHMM format",
+                        "name": "time_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: LATEST, EARLIEST, or RELEVANCE",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of results (default: 50, max: 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.NewsAndSentimentResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/options/{symbol}/chain": {
+            "get": {
+                "description": "Returns every contract from HISTORICAL_OPTIONS with its days to expiry, moneyness (strike over the underlying's close) and Greeks. Greeks missing upstream are computed from the implied volatility with Black-Scholes or Black-76. Theta is per calendar day, vega per volatility point and rho per percentage point.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "options"
+                ],
+                "summary": "Get the options chain of a symbol on a date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Underlying symbol (e.g., IBM)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session date (YYYY-MM-DD), the previous session when omitted",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest expiration to include (YYYY-MM-DD)",
+                        "name": "expiry_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest expiration to include (YYYY-MM-DD)",
+                        "name": "expiry_to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "call",
+                            "put"
+                        ],
+                        "type": "string",
+                        "description": "Option type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "itm, atm (strike within 2.5% of spot), otm or a strike/spot range such as 0.9-1.1",
+                        "name": "moneyness",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "auto",
+                            "upstream",
+                            "computed"
+                        ],
+                        "type": "string",
+                        "default": "auto",
+                        "description": "Greeks source, auto computes the ones missing upstream",
+                        "name": "greeks",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "black_scholes",
+                            "black76"
+                        ],
+                        "type": "string",
+                        "default": "black_scholes",
+                        "description": "Pricing model for computed Greeks",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.OptionsChainResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/options/{symbol}/contracts/{contract}": {
+            "get": {
+                "description": "Returns a contract of the HISTORICAL_OPTIONS chain by its contract ID (e.g., IBM240119C00050000) with the same derived values as the chain endpoint",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "options"
+                ],
+                "summary": "Get one option contract on a date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Underlying symbol (e.g., IBM)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Contract ID (e.g., IBM240119C00050000)",
+                        "name": "contract",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session date (YYYY-MM-DD), the previous session when omitted",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "auto",
+                            "upstream",
+                            "computed"
+                        ],
+                        "type": "string",
+                        "default": "auto",
+                        "description": "Greeks source, auto computes the ones missing upstream",
+                        "name": "greeks",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "black_scholes",
+                            "black76"
+                        ],
+                        "type": "string",
+                        "default": "black_scholes",
+                        "description": "Pricing model for computed Greeks",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.OptionContractResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol or contract",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/options/{symbol}/surface": {
+            "get": {
+                "description": "Solves the implied volatility of every out of the money contract of the HISTORICAL_OPTIONS chain from its mid price (Newton-Raphson with a bisection fallback) and lays them out by expiration and strike or call delta. Missing nodes are interpolated linearly along the axis, then in total variance across expirations. The term structure lists the at the money volatility and 25 delta risk reversal and butterfly of each expiration, the skew lists the solved quotes.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "options"
+                ],
+                "summary": "Get the implied volatility surface of a symbol on a date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Underlying symbol (e.g., IBM)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session date (YYYY-MM-DD), the previous session when omitted",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "strike",
+                            "delta"
+                        ],
+                        "type": "string",
+                        "default": "strike",
+                        "description": "Columns of the grid, strikes or call deltas",
+                        "name": "axis",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest expiration to include (YYYY-MM-DD)",
+                        "name": "expiry_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest expiration to include (YYYY-MM-DD)",
+                        "name": "expiry_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "0.8-1.2",
+                        "description": "Strike/spot range to include",
+                        "name": "moneyness",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "black_scholes",
+                            "black76"
+                        ],
+                        "type": "string",
+                        "default": "black_scholes",
+                        "description": "Pricing model the volatilities are solved with",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.VolatilitySurfaceResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/search": {
+            "get": {
+                "description": "Returns matching symbols, best first. The local index is built from LISTING_STATUS and supports prefix and fuzzy lookups without spending upstream quota, upstream searches call SYMBOL_SEARCH.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search symbols by ticker or company name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticker or company name, or a prefix of either",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "auto",
+                            "local",
+                            "upstream"
+                        ],
+                        "type": "string",
+                        "default": "auto",
+                        "description": "Where to search, auto uses the local index once it is ready",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Maximum number of matches",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.SearchResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Local index not ready",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/timeseries/{symbol}": {
+            "get": {
+                "description": "Returns time series data for the specified stock symbol",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "timeseries"
+                ],
+                "summary": "Get time series data for a specific symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock symbol (e.g., AAPL, MSFT)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "TIME_SERIES_DAILY",
+                            "TIME_SERIES_DAILY_ADJUSTED",
+                            "TIME_SERIES_WEEKLY",
+                            "TIME_SERIES_WEEKLY_ADJUSTED",
+                            "TIME_SERIES_MONTHLY",
+                            "TIME_SERIES_MONTHLY_ADJUSTED"
+                        ],
+                        "type": "string",
+                        "default": "TIME_SERIES_DAILY",
+                        "description": "Time series function",
+                        "name": "function",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "compact",
+                            "full"
+                        ],
+                        "type": "string",
+                        "default": "compact",
+                        "description": "Amount of data to return",
+                        "name": "outputsize",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Data type for response",
+                        "name": "datatype",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "map",
+                            "bars"
+                        ],
+                        "type": "string",
+                        "default": "map",
+                        "description": "Shape of the JSON data field, CSV and NDJSON always carry one bar per row",
+                        "name": "shape",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest bar to include (YYYY-MM-DD, YYYY-MM-DD HH:MM:SS or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest bar to include (YYYY-MM-DD, YYYY-MM-DD HH:MM:SS or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of bars per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "List trading days without bars in missing_sessions (daily and intraday series)",
+                        "name": "gaps",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Aggregate bars to a duration (e.g. 10min, 2h, 4h) or calendar period (day, week, month, quarter, year), implies shape=bars",
+                        "name": "resample",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.TimeSeriesResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            },
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page, also reported as next_cursor"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/timeseries/{symbol}/{interval}": {
+            "get": {
+                "description": "Returns intraday time series data with the specified interval\nWhen both from and to are months (YYYY-MM) the series is stitched from one request per month and returned as bars, months that fail are listed in missing_months, and once a month is rate limited the remaining ones are listed there without being fetched",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "timeseries"
+                ],
+                "summary": "Get intraday time series data with specific interval",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stock symbol (e.g., AAPL, MSFT)",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "1min",
+                            "5min",
+                            "15min",
+                            "30min",
+                            "60min"
+                        ],
+                        "type": "string",
+                        "description": "Time interval for data",
+                        "name": "interval",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "compact",
+                            "full"
+                        ],
+                        "type": "string",
+                        "default": "compact",
+                        "description": "Amount of data to return",
+                        "name": "outputsize",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Data type for response",
+                        "name": "datatype",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "map",
+                            "bars"
+                        ],
+                        "type": "string",
+                        "default": "map",
+                        "description": "Shape of the JSON data field, CSV and NDJSON always carry one bar per row",
+                        "name": "shape",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output encoding, also negotiable with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest bar to include (YYYY-MM-DD, YYYY-MM-DD HH:MM:SS or RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest bar to include (YYYY-MM-DD, YYYY-MM-DD HH:MM:SS or RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of bars per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "List trading days without bars in missing_sessions (daily and intraday series)",
+                        "name": "gaps",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Aggregate bars to a duration (e.g. 10min, 2h, 4h) or calendar period (day, week, month, quarter, year), implies shape=bars",
+                        "name": "resample",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Whether to include extended hours data",
+                        "name": "extended_hours",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Whether to adjust for split and dividend events",
+                        "name": "adjusted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Month for historical intraday data (YYYY-MM format)",
+                        "name": "month",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.TimeSeriesResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when served from cache, MISS otherwise"
+                            },
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page, also reported as next_cursor"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Premium endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Invalid symbol",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Upstream rate limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Upstream error",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Upstream timeout",
+                        "schema": {
+                            "$ref": "#/definitions/alphavantage.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "alphavantage.AnalyticsResponse": {
+            "description": "Window analytics response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "*analytics.FixedWindow or *analytics.SlidingWindow"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.BalanceSheetResponse": {
+            "description": "Balance sheet response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "*fundamental.ParsedBalanceSheet, or *fundamental.BalanceSheetResponse when raw=true"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.CashFlowResponse": {
+            "description": "Cash flow response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "*fundamental.ParsedCashFlow, or *fundamental.CashFlowResponse when raw=true"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.CompanyOverviewResponse": {
+            "description": "Company overview response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "*fundamental.ParsedCompanyOverview, or *fundamental.CompanyOverviewResponse when raw=true"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.DividendsResponse": {
+            "description": "Dividend history response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "*fundamental.ParsedDividends, or *fundamental.DividendsResponse when raw=true"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.ETFLookthroughResponse": {
+            "description": "ETF look-through response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "$ref": "#/definitions/fundamental.Lookthrough"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.ETFProfileResponse": {
+            "description": "ETF profile response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "*fundamental.ParsedETFProfile, or *fundamental.ETFProfileResponse when raw=true"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.EarningsCalendarResponse": {
+            "description": "Earnings calendar response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/calendar.EarningsEvent"
+                    }
+                },
+                "horizon": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                },
+                "watchlist": {
+                    "description": "Only watchlist symbols are listed",
+                    "type": "boolean"
+                }
+            }
+        },
+        "alphavantage.EarningsResponse": {
+            "description": "Earnings response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "*fundamental.ParsedEarnings, or *fundamental.EarningsResponse when raw=true"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.ErrorResponse": {
+            "description": "Error response data structure",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.IPOCalendarResponse": {
+            "description": "IPO calendar response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/calendar.IPOEvent"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                },
+                "watchlist": {
+                    "description": "Only watchlist symbols are listed",
+                    "type": "boolean"
+                }
+            }
+        },
+        "alphavantage.IncomeStatementResponse": {
+            "description": "Income statement response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "*fundamental.ParsedIncomeStatement, or *fundamental.IncomeStatementResponse when raw=true"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.IndicatorResponse": {
+            "description": "Technical indicator response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "$ref": "#/definitions/indicators.Series"
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.MarketStatusResponse": {
+            "description": "Market status response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/market.MarketState"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.MetricsResponse": {
+            "description": "Upstream metrics response data structure",
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/common.Stats"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.NewsAndSentimentResponse": {
+            "description": "News and sentiment response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "$ref": "#/definitions/news.GetNewsAndSentimentResponse"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.OptionContractResponse": {
+            "description": "Option contract response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "$ref": "#/definitions/options.Contract"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "model": {
+                    "$ref": "#/definitions/options.Model"
+                },
+                "risk_free_rate": {
+                    "type": "number"
+                },
+                "spot": {
+                    "type": "number"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.OptionsChainResponse": {
+            "description": "Options chain response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "$ref": "#/definitions/options.Chain"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.QuotaResponse": {
+            "description": "Upstream quota response data structure",
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/common.Quota"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.SearchResponse": {
+            "description": "Symbol search response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/search.Match"
+                    }
+                },
+                "query": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "local or upstream",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.SplitsResponse": {
+            "description": "Split history response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "*fundamental.ParsedSplits, or *fundamental.SplitsResponse when raw=true"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.TimeSeriesResponse": {
+            "description": "Time series response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "*timeseries.TimeSeriesResponse, or []timeseries.Bar when shape=bars"
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "missing_months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/timeseries.MissingMonth"
+                    }
+                },
+                "missing_sessions": {
+                    "description": "Trading days without bars, with gaps=true",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "alphavantage.VolatilitySurfaceResponse": {
+            "description": "Implied volatility surface response data structure",
+            "type": "object",
+            "properties": {
+                "cache": {
+                    "type": "string"
+                },
+                "data": {
+                    "$ref": "#/definitions/options.Surface"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "analytics.FixedWindow": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "missing_symbols": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analytics.MissingSymbol"
+                    }
+                },
+                "observations": {
+                    "description": "Daily returns per symbol",
+                    "type": "integer"
+                },
+                "results": {
+                    "description": "Keyed by calculation",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "symbols": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "analytics.MissingSymbol": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/fundamental.BalanceSheetResponse"
+                "error": {
+                    "type": "string"
                 },
                 "symbol": {
                     "type": "string"
+                }
+            }
+        },
+        "analytics.SlidingWindow": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
                 },
-                "timestamp": {
+                "missing_symbols": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analytics.MissingSymbol"
+                    }
+                },
+                "observations": {
+                    "type": "integer"
+                },
+                "results": {
+                    "description": "Keyed by calculation, then symbol",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/analytics.WindowValue"
+                            }
+                        }
+                    }
+                },
+                "symbols": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "to": {
                     "type": "string"
                 },
-                "version": {
+                "window_size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "analytics.WindowValue": {
+            "type": "object",
+            "properties": {
+                "date": {
                     "type": "string"
+                },
+                "value": {
+                    "type": "number"
                 }
             }
         },
-        "alphavantage.CashFlowResponse": {
-            "description": "Cash flow response data structure",
+        "calendar.EarningsEvent": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/fundamental.CashFlowResponse"
+                "currency": {
+                    "type": "string"
                 },
-                "symbol": {
+                "estimate": {
+                    "description": "Consensus EPS estimate, null when not covered",
+                    "type": "number"
+                },
+                "fiscalDateEnding": {
                     "type": "string"
                 },
-                "timestamp": {
+                "name": {
                     "type": "string"
                 },
-                "version": {
+                "reportDate": {
+                    "type": "string"
+                },
+                "symbol": {
                     "type": "string"
                 }
             }
         },
-        "alphavantage.CompanyOverviewResponse": {
-            "description": "Company overview response data structure",
+        "calendar.IPOEvent": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/fundamental.CompanyOverviewResponse"
+                "currency": {
+                    "type": "string"
                 },
-                "symbol": {
+                "exchange": {
                     "type": "string"
                 },
-                "timestamp": {
+                "ipoDate": {
                     "type": "string"
                 },
-                "version": {
+                "name": {
+                    "type": "string"
+                },
+                "priceRangeHigh": {
+                    "type": "number"
+                },
+                "priceRangeLow": {
+                    "type": "number"
+                },
+                "symbol": {
                     "type": "string"
                 }
             }
         },
-        "alphavantage.IncomeStatementResponse": {
-            "description": "Income statement response data structure",
+        "common.Quota": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/fundamental.IncomeStatementResponse"
+                "per_day": {
+                    "type": "integer"
                 },
-                "symbol": {
-                    "type": "string"
+                "per_minute": {
+                    "type": "integer"
                 },
-                "timestamp": {
+                "remaining_per_day": {
+                    "type": "integer"
+                },
+                "remaining_per_minute": {
+                    "type": "integer"
+                }
+            }
+        },
+        "common.Stats": {
+            "type": "object",
+            "properties": {
+                "cache_hits": {
+                    "description": "Requests served from cache",
+                    "type": "integer"
+                },
+                "cache_misses": {
+                    "description": "Requests that needed upstream data",
+                    "type": "integer"
+                },
+                "coalesced": {
+                    "description": "Requests that joined a fetch already in flight",
+                    "type": "integer"
+                },
+                "dedup_ratio": {
+                    "description": "Share of cache misses answered by another request's fetch",
+                    "type": "number"
+                },
+                "fetches": {
+                    "description": "Upstream fetches started, retries included once",
+                    "type": "integer"
+                },
+                "requests": {
+                    "description": "Calls made through Client.Get",
+                    "type": "integer"
+                }
+            }
+        },
+        "fundamental.AnnualDividend": {
+            "type": "object",
+            "properties": {
+                "partial": {
+                    "description": "The year is still in progress",
+                    "type": "boolean"
+                },
+                "payments": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "number"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "fundamental.DividendGrowth": {
+            "type": "object",
+            "properties": {
+                "five_year": {
+                    "type": "number"
+                },
+                "one_year": {
+                    "type": "number"
+                },
+                "ten_year": {
+                    "type": "number"
+                },
+                "three_year": {
+                    "type": "number"
+                }
+            }
+        },
+        "fundamental.Exposure": {
+            "type": "object",
+            "properties": {
+                "holdings": {
+                    "description": "Holdings contributing to it",
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "version": {
+                "weight": {
+                    "description": "Fraction of the fund",
+                    "type": "number"
+                }
+            }
+        },
+        "fundamental.Lookthrough": {
+            "type": "object",
+            "properties": {
+                "countries": {
+                    "description": "Largest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.Exposure"
+                    }
+                },
+                "holdings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.LookthroughHolding"
+                    }
+                },
+                "holdings_weight": {
+                    "description": "Weight of the holdings looked through",
+                    "type": "number"
+                },
+                "missing": {
+                    "description": "Holdings whose overview failed",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.MissingHolding"
+                    }
+                },
+                "partial": {
+                    "description": "Some overviews failed, their holdings are UNCLASSIFIED",
+                    "type": "boolean"
+                },
+                "remainder_weight": {
+                    "description": "Weight of the holdings left out by MaxHoldings",
+                    "type": "number"
+                },
+                "reported_sectors": {
+                    "description": "Sector weights as the fund reports them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.ParsedETFSector"
+                    }
+                },
+                "sectors": {
+                    "description": "Largest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.Exposure"
+                    }
+                },
+                "symbol": {
                     "type": "string"
                 }
             }
         },
-        "alphavantage.NewsAndSentimentResponse": {
-            "description": "News and sentiment response data structure",
+        "fundamental.LookthroughHolding": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/news.GetNewsAndSentimentResponse"
+                "country": {
+                    "type": "string"
                 },
-                "timestamp": {
+                "description": {
                     "type": "string"
                 },
-                "version": {
+                "sector": {
                     "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
                 }
             }
         },
-        "alphavantage.TimeSeriesResponse": {
-            "description": "Time series response data structure",
+        "fundamental.MissingHolding": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/timeseries.TimeSeriesResponse"
-                },
-                "interval": {
+                "error": {
                     "type": "string"
                 },
                 "symbol": {
                     "type": "string"
                 },
-                "timestamp": {
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "fundamental.ParsedAnnualEarnings": {
+            "type": "object",
+            "properties": {
+                "fiscalDateEnding": {
                     "type": "string"
                 },
-                "version": {
+                "reportedEPS": {
+                    "type": "number"
+                }
+            }
+        },
+        "fundamental.ParsedBalanceSheet": {
+            "type": "object",
+            "properties": {
+                "annualReports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.ParsedBalanceSheetReport"
+                    }
+                },
+                "quarterlyReports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.ParsedBalanceSheetReport"
+                    }
+                },
+                "symbol": {
                     "type": "string"
                 }
             }
         },
-        "fundamental.BalanceSheetReport": {
+        "fundamental.ParsedBalanceSheetReport": {
             "type": "object",
             "properties": {
                 "accumulatedDepreciationAmortizationPPE": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "capitalLeaseObligations": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "cashAndCashEquivalentsAtCarryingValue": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "cashAndShortTermInvestments": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "commonStock": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "commonStockSharesOutstanding": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "currentAccountsPayable": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "currentDebt": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "currentLongTermDebt": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "currentNetReceivables": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "deferredRevenue": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "fiscalDateEnding": {
                     "type": "string"
                 },
                 "goodwill": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "intangibleAssets": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "intangibleAssetsExcludingGoodwill": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "inventory": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "investments": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "longTermDebt": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "longTermDebtNoncurrent": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "longTermInvestments": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "otherCurrentAssets": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "otherCurrentLiabilities": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "otherNonCurrentAssets": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "otherNonCurrentLiabilities": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "propertyPlantEquipment": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "reportedCurrency": {
                     "type": "string"
                 },
                 "retainedEarnings": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "shortLongTermDebtTotal": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "shortTermDebt": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "shortTermInvestments": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "totalAssets": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "totalCurrentAssets": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "totalCurrentLiabilities": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "totalLiabilities": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "totalNonCurrentAssets": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "totalNonCurrentLiabilities": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "totalShareholderEquity": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "treasuryStock": {
-                    "type": "string"
+                    "type": "number"
                 }
             }
         },
-        "fundamental.BalanceSheetResponse": {
+        "fundamental.ParsedCashFlow": {
             "type": "object",
             "properties": {
                 "annualReports": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/fundamental.BalanceSheetReport"
+                        "$ref": "#/definitions/fundamental.ParsedCashFlowReport"
                     }
                 },
                 "quarterlyReports": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/fundamental.BalanceSheetReport"
+                        "$ref": "#/definitions/fundamental.ParsedCashFlowReport"
                     }
                 },
                 "symbol": {
@@ -642,393 +3847,676 @@ const docTemplate = `{
                 }
             }
         },
-        "fundamental.CashFlowReport": {
+        "fundamental.ParsedCashFlowReport": {
             "type": "object",
             "properties": {
                 "capitalExpenditures": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "cashflowFromFinancing": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "cashflowFromInvestment": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "changeInCashAndCashEquivalents": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "changeInExchangeRate": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "changeInInventory": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "changeInOperatingAssets": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "changeInOperatingLiabilities": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "changeInReceivables": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "depreciationDepletionAndAmortization": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "dividendPayout": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "dividendPayoutCommonStock": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "dividendPayoutPreferredStock": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "fiscalDateEnding": {
                     "type": "string"
                 },
                 "netIncome": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "operatingCashflow": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "paymentsForOperatingActivities": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "paymentsForRepurchaseOfCommonStock": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "paymentsForRepurchaseOfEquity": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "paymentsForRepurchaseOfPreferredStock": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "proceedsFromIssuanceOfCommonStock": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "proceedsFromIssuanceOfLongTermDebtAndCapitalSecuritiesNet": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "proceedsFromIssuanceOfPreferredStock": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "proceedsFromOperatingActivities": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "proceedsFromRepaymentsOfShortTermDebt": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "proceedsFromRepurchaseOfEquity": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "proceedsFromSaleOfTreasuryStock": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "profitLoss": {
-                    "type": "string"
+                    "type": "number"
                 },
                 "reportedCurrency": {
                     "type": "string"
                 }
             }
         },
-        "fundamental.CashFlowResponse": {
+        "fundamental.ParsedCompanyOverview": {
             "type": "object",
             "properties": {
-                "annualReports": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/fundamental.CashFlowReport"
-                    }
+                "200DayMovingAverage": {
+                    "type": "number"
                 },
-                "quarterlyReports": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/fundamental.CashFlowReport"
-                    }
+                "50DayMovingAverage": {
+                    "type": "number"
                 },
-                "symbol": {
+                "52WeekHigh": {
+                    "type": "number"
+                },
+                "52WeekLow": {
+                    "type": "number"
+                },
+                "address": {
                     "type": "string"
-                }
-            }
-        },
-        "fundamental.CompanyOverviewResponse": {
-            "type": "object",
-            "properties": {
-                "200DayMovingAverage": {
+                },
+                "analystTargetPrice": {
+                    "type": "number"
+                },
+                "beta": {
+                    "type": "number"
+                },
+                "bookValue": {
+                    "type": "number"
+                },
+                "country": {
                     "type": "string"
                 },
-                "50DayMovingAverage": {
+                "currency": {
                     "type": "string"
                 },
-                "52WeekHigh": {
+                "description": {
                     "type": "string"
                 },
-                "52WeekLow": {
+                "dilutedEPSTTM": {
+                    "type": "number"
+                },
+                "dividendDate": {
                     "type": "string"
                 },
-                "address": {
+                "dividendPerShare": {
+                    "type": "number"
+                },
+                "dividendYield": {
+                    "type": "number"
+                },
+                "ebitda": {
+                    "type": "number"
+                },
+                "eps": {
+                    "type": "number"
+                },
+                "evToEBITDA": {
+                    "type": "number"
+                },
+                "evToRevenue": {
+                    "type": "number"
+                },
+                "exDividendDate": {
                     "type": "string"
                 },
-                "analystTargetPrice": {
+                "exchange": {
+                    "type": "string"
+                },
+                "fiscalYearEnd": {
+                    "type": "string"
+                },
+                "forwardAnnualDividendRate": {
+                    "type": "number"
+                },
+                "forwardAnnualDividendYield": {
+                    "type": "number"
+                },
+                "forwardPE": {
+                    "type": "number"
+                },
+                "grossProfitTTM": {
+                    "type": "number"
+                },
+                "industry": {
+                    "type": "string"
+                },
+                "lastSplitDate": {
+                    "type": "string"
+                },
+                "lastSplitFactor": {
+                    "type": "string"
+                },
+                "latestQuarter": {
+                    "type": "string"
+                },
+                "marketCapitalization": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "operatingMarginTTM": {
+                    "type": "number"
+                },
+                "payoutRatio": {
+                    "type": "number"
+                },
+                "peRatio": {
+                    "type": "number"
+                },
+                "pegRatio": {
+                    "type": "number"
+                },
+                "percentInsiders": {
+                    "type": "number"
+                },
+                "percentInstitutions": {
+                    "type": "number"
+                },
+                "priceToBookRatio": {
+                    "type": "number"
+                },
+                "priceToSalesRatioTTM": {
+                    "type": "number"
+                },
+                "profitMargin": {
+                    "type": "number"
+                },
+                "quarterlyEarningsGrowthYOY": {
+                    "type": "number"
+                },
+                "quarterlyRevenueGrowthYOY": {
+                    "type": "number"
+                },
+                "returnOnAssetsTTM": {
+                    "type": "number"
+                },
+                "returnOnEquityTTM": {
+                    "type": "number"
+                },
+                "revenuePerShareTTM": {
+                    "type": "number"
+                },
+                "revenueTTM": {
+                    "type": "number"
+                },
+                "sector": {
+                    "type": "string"
+                },
+                "sharesFloat": {
+                    "type": "number"
+                },
+                "sharesOutstanding": {
+                    "type": "number"
+                },
+                "sharesShort": {
+                    "type": "number"
+                },
+                "sharesShortPriorMonth": {
+                    "type": "number"
+                },
+                "shortPercentFloat": {
+                    "type": "number"
+                },
+                "shortPercentOutstanding": {
+                    "type": "number"
+                },
+                "shortRatio": {
+                    "type": "number"
+                },
+                "symbol": {
                     "type": "string"
                 },
-                "beta": {
+                "trailingPE": {
+                    "type": "number"
+                }
+            }
+        },
+        "fundamental.ParsedDividend": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "declaration_date": {
                     "type": "string"
                 },
-                "bookValue": {
+                "ex_dividend_date": {
                     "type": "string"
                 },
-                "country": {
+                "payment_date": {
                     "type": "string"
                 },
-                "currency": {
+                "record_date": {
                     "type": "string"
+                }
+            }
+        },
+        "fundamental.ParsedDividends": {
+            "type": "object",
+            "properties": {
+                "annual_totals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.AnnualDividend"
+                    }
                 },
-                "description": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.ParsedDividend"
+                    }
                 },
-                "dilutedEPSTTM": {
-                    "type": "string"
+                "growth": {
+                    "$ref": "#/definitions/fundamental.DividendGrowth"
                 },
-                "dividendDate": {
-                    "type": "string"
+                "increase_streak": {
+                    "description": "Consecutive complete years with a higher total than the year before",
+                    "type": "integer"
                 },
-                "dividendPerShare": {
+                "symbol": {
                     "type": "string"
-                },
-                "dividendYield": {
+                }
+            }
+        },
+        "fundamental.ParsedETFHolding": {
+            "type": "object",
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "ebitda": {
+                "symbol": {
                     "type": "string"
                 },
-                "eps": {
-                    "type": "string"
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "fundamental.ParsedETFProfile": {
+            "type": "object",
+            "properties": {
+                "dividend_yield": {
+                    "type": "number"
                 },
-                "evToEBITDA": {
-                    "type": "string"
+                "holdings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.ParsedETFHolding"
+                    }
                 },
-                "evToRevenue": {
+                "inception_date": {
                     "type": "string"
                 },
-                "exDividendDate": {
-                    "type": "string"
+                "leveraged": {
+                    "type": "boolean"
                 },
-                "exchange": {
-                    "type": "string"
+                "net_assets": {
+                    "type": "number"
                 },
-                "fiscalYearEnd": {
-                    "type": "string"
+                "net_expense_ratio": {
+                    "type": "number"
                 },
-                "forwardAnnualDividendRate": {
-                    "type": "string"
+                "portfolio_turnover": {
+                    "type": "number"
                 },
-                "forwardAnnualDividendYield": {
-                    "type": "string"
+                "sectors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.ParsedETFSector"
+                    }
                 },
-                "forwardPE": {
+                "symbol": {
                     "type": "string"
-                },
-                "grossProfitTTM": {
+                }
+            }
+        },
+        "fundamental.ParsedETFSector": {
+            "type": "object",
+            "properties": {
+                "sector": {
                     "type": "string"
                 },
-                "industry": {
-                    "type": "string"
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "fundamental.ParsedEarnings": {
+            "type": "object",
+            "properties": {
+                "annualEarnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.ParsedAnnualEarnings"
+                    }
                 },
-                "lastSplitDate": {
-                    "type": "string"
+                "beatStreak": {
+                    "description": "Consecutive latest quarters reported above the estimate",
+                    "type": "integer"
                 },
-                "lastSplitFactor": {
-                    "type": "string"
+                "missStreak": {
+                    "description": "Consecutive latest quarters reported below the estimate",
+                    "type": "integer"
                 },
-                "latestQuarter": {
-                    "type": "string"
+                "quarterlyEarnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.ParsedQuarterlyEarnings"
+                    }
                 },
-                "marketCapitalization": {
+                "symbol": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "trailingEPS": {
+                    "description": "Sum of the four latest quarters, null when one is missing",
+                    "type": "number"
+                }
+            }
+        },
+        "fundamental.ParsedIncomeStatement": {
+            "type": "object",
+            "properties": {
+                "annualReports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.ParsedIncomeStatementReport"
+                    }
                 },
-                "operatingMarginTTM": {
-                    "type": "string"
+                "quarterlyReports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.ParsedIncomeStatementReport"
+                    }
                 },
-                "payoutRatio": {
+                "symbol": {
                     "type": "string"
+                }
+            }
+        },
+        "fundamental.ParsedIncomeStatementReport": {
+            "type": "object",
+            "properties": {
+                "comprehensiveIncomeNetOfTax": {
+                    "type": "number"
                 },
-                "peRatio": {
-                    "type": "string"
+                "costOfRevenue": {
+                    "type": "number"
                 },
-                "pegRatio": {
-                    "type": "string"
+                "costofGoodsAndServicesSold": {
+                    "type": "number"
                 },
-                "percentInsiders": {
-                    "type": "string"
+                "depreciation": {
+                    "type": "number"
                 },
-                "percentInstitutions": {
-                    "type": "string"
+                "depreciationAndAmortization": {
+                    "type": "number"
                 },
-                "priceToBookRatio": {
-                    "type": "string"
+                "ebit": {
+                    "type": "number"
                 },
-                "priceToSalesRatioTTM": {
-                    "type": "string"
+                "ebitda": {
+                    "type": "number"
                 },
-                "profitMargin": {
+                "fiscalDateEnding": {
                     "type": "string"
                 },
-                "quarterlyEarningsGrowthYOY": {
-                    "type": "string"
+                "grossProfit": {
+                    "type": "number"
                 },
-                "quarterlyRevenueGrowthYOY": {
-                    "type": "string"
+                "incomeBeforeTax": {
+                    "type": "number"
                 },
-                "returnOnAssetsTTM": {
-                    "type": "string"
+                "incomeTaxExpense": {
+                    "type": "number"
                 },
-                "returnOnEquityTTM": {
-                    "type": "string"
+                "interestAndDebtExpense": {
+                    "type": "number"
                 },
-                "revenuePerShareTTM": {
-                    "type": "string"
+                "interestExpense": {
+                    "type": "number"
                 },
-                "revenueTTM": {
-                    "type": "string"
+                "interestIncome": {
+                    "type": "number"
                 },
-                "sector": {
-                    "type": "string"
+                "investmentIncomeNet": {
+                    "type": "number"
                 },
-                "sharesFloat": {
-                    "type": "string"
+                "netIncome": {
+                    "type": "number"
                 },
-                "sharesOutstanding": {
-                    "type": "string"
+                "netIncomeFromContinuingOperations": {
+                    "type": "number"
                 },
-                "sharesShort": {
-                    "type": "string"
+                "netInterestIncome": {
+                    "type": "number"
                 },
-                "sharesShortPriorMonth": {
-                    "type": "string"
+                "nonInterestIncome": {
+                    "type": "number"
                 },
-                "shortPercentFloat": {
-                    "type": "string"
+                "operatingExpenses": {
+                    "type": "number"
                 },
-                "shortPercentOutstanding": {
-                    "type": "string"
+                "operatingIncome": {
+                    "type": "number"
                 },
-                "shortRatio": {
-                    "type": "string"
+                "otherNonOperatingIncome": {
+                    "type": "number"
                 },
-                "symbol": {
+                "reportedCurrency": {
                     "type": "string"
                 },
-                "trailingPE": {
-                    "type": "string"
+                "researchAndDevelopment": {
+                    "type": "number"
+                },
+                "sellingGeneralAndAdministrative": {
+                    "type": "number"
+                },
+                "totalRevenue": {
+                    "type": "number"
                 }
             }
         },
-        "fundamental.IncomeStatementReport": {
+        "fundamental.ParsedQuarterlyEarnings": {
             "type": "object",
             "properties": {
-                "comprehensiveIncomeNetOfTax": {
-                    "type": "string"
+                "estimatedEPS": {
+                    "type": "number"
                 },
-                "costOfRevenue": {
+                "fiscalDateEnding": {
                     "type": "string"
                 },
-                "costofGoodsAndServicesSold": {
+                "reportTime": {
                     "type": "string"
                 },
-                "depreciation": {
+                "reportedDate": {
                     "type": "string"
                 },
-                "depreciationAndAmortization": {
-                    "type": "string"
+                "reportedEPS": {
+                    "type": "number"
                 },
-                "ebit": {
+                "surprise": {
+                    "type": "number"
+                },
+                "surprisePercentage": {
+                    "type": "number"
+                }
+            }
+        },
+        "fundamental.ParsedSplit": {
+            "type": "object",
+            "properties": {
+                "effective_date": {
                     "type": "string"
                 },
-                "ebitda": {
+                "split_factor": {
+                    "type": "number"
+                }
+            }
+        },
+        "fundamental.ParsedSplits": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/fundamental.ParsedSplit"
+                    }
+                },
+                "symbol": {
                     "type": "string"
+                }
+            }
+        },
+        "indicators.Params": {
+            "type": "object",
+            "properties": {
+                "fast_period": {
+                    "description": "MACD fast EMA period, 12 by default",
+                    "type": "integer"
                 },
-                "fiscalDateEnding": {
-                    "type": "string"
+                "period": {
+                    "description": "Look-back period in bars",
+                    "type": "integer"
                 },
-                "grossProfit": {
+                "series_type": {
+                    "description": "open, high, low or close, close when empty",
                     "type": "string"
                 },
-                "incomeBeforeTax": {
-                    "type": "string"
+                "signal_period": {
+                    "description": "MACD signal and Stochastic %D period, 9 and 3 by default",
+                    "type": "integer"
                 },
-                "incomeTaxExpense": {
-                    "type": "string"
+                "slow_period": {
+                    "description": "MACD slow EMA period, 26 by default",
+                    "type": "integer"
                 },
-                "interestAndDebtExpense": {
+                "stddev": {
+                    "description": "Bollinger band width in standard deviations, 2 by default",
+                    "type": "number"
+                }
+            }
+        },
+        "indicators.Point": {
+            "type": "object",
+            "properties": {
+                "time": {
                     "type": "string"
                 },
-                "interestExpense": {
-                    "type": "string"
+                "values": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "indicators.Series": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "description": "Keys of each point's values in output order",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "interestIncome": {
+                "name": {
                     "type": "string"
                 },
-                "investmentIncomeNet": {
-                    "type": "string"
+                "params": {
+                    "$ref": "#/definitions/indicators.Params"
                 },
-                "netIncome": {
-                    "type": "string"
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/indicators.Point"
+                    }
+                }
+            }
+        },
+        "market.MarketState": {
+            "type": "object",
+            "properties": {
+                "closes_in_seconds": {
+                    "description": "Set while open",
+                    "type": "integer"
                 },
-                "netIncomeFromContinuingOperations": {
+                "current_status": {
                     "type": "string"
                 },
-                "netInterestIncome": {
+                "local_close": {
                     "type": "string"
                 },
-                "nonInterestIncome": {
+                "local_open": {
                     "type": "string"
                 },
-                "operatingExpenses": {
+                "market_type": {
                     "type": "string"
                 },
-                "operatingIncome": {
+                "next_close": {
                     "type": "string"
                 },
-                "otherNonOperatingIncome": {
+                "next_open": {
                     "type": "string"
                 },
-                "reportedCurrency": {
+                "notes": {
                     "type": "string"
                 },
-                "researchAndDevelopment": {
-                    "type": "string"
+                "opens_in_seconds": {
+                    "description": "Set while closed",
+                    "type": "integer"
                 },
-                "sellingGeneralAndAdministrative": {
+                "primary_exchanges": {
                     "type": "string"
                 },
-                "totalRevenue": {
+                "region": {
                     "type": "string"
-                }
-            }
-        },
-        "fundamental.IncomeStatementResponse": {
-            "type": "object",
-            "properties": {
-                "annualReports": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/fundamental.IncomeStatementReport"
-                    }
-                },
-                "quarterlyReports": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/fundamental.IncomeStatementReport"
-                    }
                 },
-                "symbol": {
+                "time_zone": {
                     "type": "string"
                 }
             }
@@ -1075,7 +4563,7 @@ const docTemplate = `{
                 "topics": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/news.Topic"
                     }
                 },
                 "url": {
@@ -1093,7 +4581,7 @@ const docTemplate = `{
                     }
                 },
                 "items": {
-                    "type": "integer"
+                    "type": "string"
                 },
                 "relevance_score_definition": {
                     "type": "string"
@@ -1107,93 +4595,337 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "relevance_score": {
-                    "type": "number"
+                    "type": "string"
                 },
-                "sentiment_label": {
+                "ticker": {
                     "type": "string"
                 },
-                "sentiment_score": {
-                    "type": "number"
+                "ticker_sentiment_label": {
+                    "type": "string"
                 },
-                "ticker": {
+                "ticker_sentiment_score": {
                     "type": "string"
                 }
             }
         },
-        "timeseries.TimeSeriesData": {
+        "news.Topic": {
             "type": "object",
             "properties": {
-                "1. open": {
+                "relevance_score": {
                     "type": "string"
                 },
-                "2. high": {
+                "topic": {
                     "type": "string"
+                }
+            }
+        },
+        "options.Chain": {
+            "type": "object",
+            "properties": {
+                "contracts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/options.Contract"
+                    }
                 },
-                "3. low": {
+                "date": {
                     "type": "string"
                 },
-                "4. close": {
-                    "type": "string"
+                "model": {
+                    "$ref": "#/definitions/options.Model"
+                },
+                "risk_free_rate": {
+                    "type": "number"
+                },
+                "spot": {
+                    "description": "Underlying close on Date",
+                    "type": "number"
                 },
-                "5. volume": {
+                "symbol": {
                     "type": "string"
                 }
             }
         },
-        "timeseries.TimeSeriesMetaData": {
+        "options.Contract": {
             "type": "object",
             "properties": {
-                "1. Information": {
+                "ask": {
+                    "type": "number"
+                },
+                "ask_size": {
+                    "type": "integer"
+                },
+                "bid": {
+                    "type": "number"
+                },
+                "bid_size": {
+                    "type": "integer"
+                },
+                "contract_id": {
                     "type": "string"
                 },
-                "2. Symbol": {
+                "date": {
                     "type": "string"
                 },
-                "3. Last Refreshed": {
+                "days_to_expiry": {
+                    "type": "integer"
+                },
+                "expiration": {
                     "type": "string"
                 },
-                "4. Interval": {
+                "greeks": {
+                    "$ref": "#/definitions/options.Greeks"
+                },
+                "greeks_source": {
+                    "description": "upstream or computed, empty when unknown",
                     "type": "string"
                 },
-                "5. Output Size": {
+                "implied_volatility": {
+                    "type": "number"
+                },
+                "last": {
+                    "type": "number"
+                },
+                "mark": {
+                    "type": "number"
+                },
+                "moneyness": {
+                    "description": "Strike over spot",
+                    "type": "number"
+                },
+                "open_interest": {
+                    "type": "integer"
+                },
+                "strike": {
+                    "type": "number"
+                },
+                "symbol": {
                     "type": "string"
                 },
-                "6. Time Zone": {
+                "type": {
+                    "description": "call or put",
                     "type": "string"
+                },
+                "volume": {
+                    "type": "integer"
                 }
             }
         },
-        "timeseries.TimeSeriesResponse": {
+        "options.Greeks": {
             "type": "object",
             "properties": {
-                "Meta Data": {
-                    "$ref": "#/definitions/timeseries.TimeSeriesMetaData"
+                "delta": {
+                    "type": "number"
                 },
-                "Monthly Time Series": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/timeseries.TimeSeriesData"
+                "gamma": {
+                    "type": "number"
+                },
+                "rho": {
+                    "type": "number"
+                },
+                "theta": {
+                    "type": "number"
+                },
+                "vega": {
+                    "type": "number"
+                }
+            }
+        },
+        "options.Model": {
+            "type": "string",
+            "enum": [
+                "black_scholes",
+                "black76"
+            ],
+            "x-enum-comments": {
+                "Black76": "European options on the forward price",
+                "BlackScholes": "European options on the spot price"
+            },
+            "x-enum-varnames": [
+                "BlackScholes",
+                "Black76"
+            ]
+        },
+        "options.Quote": {
+            "type": "object",
+            "properties": {
+                "contract_id": {
+                    "type": "string"
+                },
+                "delta": {
+                    "description": "Call delta N(d1), whatever the type",
+                    "type": "number"
+                },
+                "implied_volatility": {
+                    "type": "number"
+                },
+                "mid": {
+                    "type": "number"
+                },
+                "moneyness": {
+                    "description": "Strike over spot",
+                    "type": "number"
+                },
+                "strike": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "options.Smile": {
+            "type": "object",
+            "properties": {
+                "days_to_expiry": {
+                    "type": "integer"
+                },
+                "expiration": {
+                    "type": "string"
+                },
+                "quotes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/options.Quote"
                     }
+                }
+            }
+        },
+        "options.Surface": {
+            "type": "object",
+            "properties": {
+                "axis": {
+                    "description": "strike or delta",
+                    "type": "string"
                 },
-                "Time Series (5min)": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/timeseries.TimeSeriesData"
+                "columns": {
+                    "description": "Strikes or call deltas",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
                     }
                 },
-                "Time Series (Daily)": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/timeseries.TimeSeriesData"
+                "date": {
+                    "type": "string"
+                },
+                "expirations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
                 },
-                "Weekly Time Series": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/timeseries.TimeSeriesData"
+                "interpolated": {
+                    "description": "Nodes without a quote of their own, every delta node",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "boolean"
+                        }
+                    }
+                },
+                "model": {
+                    "$ref": "#/definitions/options.Model"
+                },
+                "risk_free_rate": {
+                    "type": "number"
+                },
+                "skew": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/options.Smile"
+                    }
+                },
+                "spot": {
+                    "type": "number"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "term_structure": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/options.TermPoint"
+                    }
+                },
+                "volatility": {
+                    "description": "[expiration][column], null when it cannot be interpolated",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
                     }
                 }
             }
+        },
+        "options.TermPoint": {
+            "type": "object",
+            "properties": {
+                "atm_volatility": {
+                    "description": "Interpolated at a strike equal to spot",
+                    "type": "number"
+                },
+                "butterfly_25d": {
+                    "description": "Average of the 25 delta wings minus the 50 delta volatility",
+                    "type": "number"
+                },
+                "days_to_expiry": {
+                    "type": "integer"
+                },
+                "expiration": {
+                    "type": "string"
+                },
+                "risk_reversal_25d": {
+                    "description": "25 delta call minus 25 delta put volatility",
+                    "type": "number"
+                }
+            }
+        },
+        "search.Match": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "marketClose": {
+                    "type": "string"
+                },
+                "marketOpen": {
+                    "type": "string"
+                },
+                "matchScore": {
+                    "description": "0 to 1, higher is closer",
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "timeseries.MissingMonth": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "month": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
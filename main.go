@@ -1,10 +1,18 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
 	"os/exec"
+	"os/signal"
 	"stock/alphavantage"
+	"stock/alphavantage/market"
+	"syscall"
+	"time"
+	_ "time/tzdata" // Embed time zones so exchange hours resolve on minimal hosts
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -19,6 +27,9 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+// shutdownTimeout is how long in-flight requests may finish after a signal
+const shutdownTimeout = 10 * time.Second
+
 // @title Stock Market API
 // @version 1.0
 // @description API for retrieving time series data from stock markets
@@ -41,8 +52,28 @@ func main() {
 	// Get configuration
 	cfg := config.GetConfig()
 
+	// Cancelled on SIGINT or SIGTERM to stop the server and background work
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Create the upstream client, throttled to the configured budgets
+	client := alphavantage.NewClient(cfg)
+
+	// Register the trading calendars of other exchanges
+	for _, path := range cfg.MarketCalendars {
+		cal, err := market.LoadCalendar(path)
+		if err != nil {
+			log.Printf("Warning: trading calendar %s not loaded: %v", path, err)
+			continue
+		}
+		market.RegisterCalendar(cal)
+	}
+
+	// Rebuild the local symbol index on a schedule
+	go client.RunSymbolIndex(ctx)
+
 	// Setup router
-	router := SetupRouter()
+	router := SetupRouter(alphavantage.NewHandler(client))
 
 	// Setup Swagger endpoints
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -50,13 +81,27 @@ func main() {
 	// Start server
 	log.Printf("Starting server on port %s\n", cfg.Port)
 	log.Printf("Swagger UI available at http://localhost:%s/swagger/index.html\n", cfg.Port)
-	if err := router.Run(":" + cfg.Port); err != nil {
+	server := &http.Server{Addr: ":" + cfg.Port, Handler: router}
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Warning: server shutdown: %v", err)
+		}
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Fatalf("Failed to start server: %v", err)
 	}
+
+	// Let in-flight requests finish
+	<-stopped
 }
 
 // SetupRouter initializes the Gin router with API routes
-func SetupRouter() *gin.Engine {
+func SetupRouter(h *alphavantage.Handler) *gin.Engine {
 	router := gin.Default()
 
 	// Configure CORS
@@ -74,22 +119,73 @@ func SetupRouter() *gin.Engine {
 		// Time series endpoints
 		timeseries := v1.Group("/timeseries")
 		{
-			timeseries.GET("/:symbol", alphavantage.GetTimeSeriesForSymbol)
-			timeseries.GET("/:symbol/:interval", alphavantage.GetTimeSeriesWithInterval)
+			timeseries.GET("/:symbol", h.GetTimeSeriesForSymbol)
+			timeseries.GET("/:symbol/:interval", h.GetTimeSeriesWithInterval)
 		}
 
 		// Fundamental data endpoints
 		fundamental := v1.Group("/fundamental")
 		{
-			fundamental.GET("/balance-sheet/:symbol", alphavantage.GetBalanceSheet)
-			fundamental.GET("/cash-flow/:symbol", alphavantage.GetCashFlow)
-			fundamental.GET("/income-statement/:symbol", alphavantage.GetIncomeStatement)
-			fundamental.GET("/company-overview/:symbol", alphavantage.GetCompanyOverview)
+			fundamental.GET("/balance-sheet/:symbol", h.GetBalanceSheet)
+			fundamental.GET("/cash-flow/:symbol", h.GetCashFlow)
+			fundamental.GET("/income-statement/:symbol", h.GetIncomeStatement)
+			fundamental.GET("/earnings/:symbol", h.GetEarnings)
+			fundamental.GET("/dividends/:symbol", h.GetDividends)
+			fundamental.GET("/splits/:symbol", h.GetSplits)
+			fundamental.GET("/company-overview/:symbol", h.GetCompanyOverview)
+		}
+		// ETF endpoints
+		etf := v1.Group("/etf")
+		{
+			etf.GET("/:symbol", h.GetETFProfile)
+			etf.GET("/:symbol/lookthrough", h.GetETFLookthrough)
 		}
+
 		// News and sentiment endpoints
 		news := v1.Group("/news")
 		{
-			news.GET("/sentiment", alphavantage.GetNewsAndSentiment)
+			news.GET("/sentiment", h.GetNewsAndSentiment)
+		}
+
+		// Market endpoints
+		market := v1.Group("/market")
+		{
+			market.GET("/status", h.GetMarketStatus)
+		}
+
+		// Technical indicator endpoints
+		v1.GET("/indicators/:symbol/:name", h.GetIndicator)
+
+		// Options endpoints
+		options := v1.Group("/options")
+		{
+			options.GET("/:symbol/chain", h.GetOptionsChain)
+			options.GET("/:symbol/contracts/:contract", h.GetOptionContract)
+			options.GET("/:symbol/surface", h.GetVolatilitySurface)
+		}
+
+		// Window analytics endpoints
+		analytics := v1.Group("/analytics")
+		{
+			analytics.GET("/fixed", h.GetFixedWindowAnalytics)
+			analytics.GET("/sliding", h.GetSlidingWindowAnalytics)
+		}
+
+		// Earnings and IPO calendar endpoints
+		calendar := v1.Group("/calendar")
+		{
+			calendar.GET("/earnings", h.GetEarningsCalendar)
+			calendar.GET("/ipo", h.GetIPOCalendar)
+		}
+
+		// Symbol search endpoints
+		v1.GET("/search", h.SearchSymbols)
+
+		// Administrative endpoints
+		admin := v1.Group("/admin")
+		{
+			admin.GET("/quota", h.GetQuota)
+			admin.GET("/metrics", h.GetMetrics)
 		}
 	}
 